@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/Yolto7/api-candidates/internal/application/dispatcher"
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/infrastructure/container"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+)
+
+var (
+	initStart time.Time
+	initErr   error
+
+	log             logger.Logger
+	processFollowUp *commands.ProcessFollowUpService
+	eventDispatcher *dispatcher.Dispatcher
+)
+
+func init() {
+	initStart = time.Now()
+	ctx := context.Background()
+
+	mainContainer, err := container.NewMainLambdaContainer(ctx)
+	if err != nil {
+		initErr = err
+		return
+	}
+	log = mainContainer.Logger()
+
+	processFollowUp, err = mainContainer.GetProcessFollowUpService()
+	if err != nil {
+		initErr = err
+		return
+	}
+	eventDispatcher = mainContainer.GetEventDispatcher()
+
+	log.Info(fmt.Sprintf("Follow-up lambda init completed in %v", time.Since(initStart)))
+}
+
+// Invocada por EventBridge Scheduler con el payload de cada seguimiento
+// programado. Un error hace que el scheduler reintente la invocación.
+func main() {
+	lambda.Start(func(ctx context.Context, payload entities.FollowUpPayload) (*commands.ProcessFollowUpServiceOutput, error) {
+		if initErr != nil {
+			return nil, initErr
+		}
+		defer eventDispatcher.Wait()
+
+		result, err := processFollowUp.Execute(ctx, &payload)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Info(map[string]any{
+			"msg":         "Follow-up processed",
+			"candidateId": result.CandidateID,
+			"attempt":     result.Attempt,
+			"action":      result.Action,
+		})
+		return result, nil
+	})
+}
@@ -3,24 +3,23 @@ package main
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"time"
 
-	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
 
+	"github.com/Yolto7/api-candidates/internal/application/dispatcher"
 	"github.com/Yolto7/api-candidates/internal/infrastructure/container"
-	"github.com/Yolto7/api-candidates/pkg/infrastructure/middlewares"
-	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+	"github.com/Yolto7/api-candidates/internal/presentation/routes"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/router"
 )
 
-const PREFIX = "candidates"
-
 var (
 	initStart     time.Time
 	initErr       error
 	
-	routes        map[string]map[string]middlewares.LambdaHandlerFunc
+	apiRouter     *router.Router
+	eventDispatcher *dispatcher.Dispatcher
 )
 
 func init() {
@@ -35,46 +34,78 @@ func init() {
 		return
 	}
 
-	// Configurar middlewares ya resueltos
-	trace, base, errorMw := mainContainer.GetMiddlewares()
-	baseMiddlewares := []middlewares.Middleware{trace, base, errorMw}
-
 	controller, err := mainContainer.GetCandidateController()
 	if err != nil {
 		initErr = err
 		return
 	}
 
-	// Configurar rutas con el controller específico	
-	routes = map[string]map[string]middlewares.LambdaHandlerFunc{
-		http.MethodGet: {
-			PREFIX + "/{id}": middlewares.ChainMiddlewares(controller.GetByID, baseMiddlewares...),
-		},
-		http.MethodPost: {
-			PREFIX + "/": middlewares.ChainMiddlewares(controller.Create, baseMiddlewares...),
-		},
-		http.MethodDelete: {
-			PREFIX + "/{id}": middlewares.ChainMiddlewares(controller.Delete, baseMiddlewares...),
-		},
+	whatsAppController, err := mainContainer.GetWhatsAppWebhookController()
+	if err != nil {
+		initErr = err
+		return
+	}
+
+	outboxController, err := mainContainer.GetOutboxController()
+	if err != nil {
+		initErr = err
+		return
 	}
 
+	slotController, err := mainContainer.GetInterviewSlotController()
+	if err != nil {
+		initErr = err
+		return
+	}
+
+	messageController, err := mainContainer.GetMessageController()
+	if err != nil {
+		initErr = err
+		return
+	}
+
+	twilioController, err := mainContainer.GetTwilioWebhookController()
+	if err != nil {
+		initErr = err
+		return
+	}
+
+	adminAuth, err := mainContainer.GetHMACAuthMiddleware()
+	if err != nil {
+		initErr = err
+		return
+	}
+
+	eventDispatcher = mainContainer.GetEventDispatcher()
+
+	// Compilar rutas una sola vez por contenedor
+	apiRouter = router.New()
+	apiRouter.Use(mainContainer.GetMiddlewares()...)
+
+	routes.Register(apiRouter, routes.Dependencies{
+		CandidateController:       controller,
+		WhatsAppWebhookController: whatsAppController,
+		WhatsAppSignature:         mainContainer.GetWhatsAppSignatureMiddleware(),
+		OutboxController:          outboxController,
+		InterviewSlotController:   slotController,
+		MessageController:         messageController,
+		TwilioWebhookController:   twilioController,
+		TwilioSignature:           mainContainer.GetTwilioSignatureMiddleware(),
+		AdminAuth:                 adminAuth,
+	})
+
 	mainContainer.Logger().Info(fmt.Sprintf("Main lambda init completed in %v", time.Since(initStart)))
 }
 
 func main() {
-	lambda.Start(func(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+	// Acepta eventos de API Gateway REST (v1), HTTP API (v2) y ALB
+	lambda.Start(gateway.Lambda(func(ctx context.Context, req gateway.Request) (*gateway.Response, error) {
 		if initErr != nil {
 			return nil, initErr
 		}
 
-		utilsRoutes := make(map[string]map[string]utils.LambdaHandlerFunc)
-		for method, methodRoutes := range routes {
-			utilsRoutes[method] = make(map[string]utils.LambdaHandlerFunc)
-			for pattern, handler := range methodRoutes {
-				utilsRoutes[method][pattern] = utils.LambdaHandlerFunc(handler)
-			}
-		}
-
-		return utils.HandleRoutes(ctx, event, utilsRoutes)
-	})
+		// Los handlers asíncronos deben terminar antes de que se congele el contenedor
+		defer eventDispatcher.Wait()
+		return apiRouter.Serve(ctx, req)
+	}))
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Yolto7/api-candidates/internal/presentation/controllers"
+	"github.com/Yolto7/api-candidates/internal/presentation/routes"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/openapi"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/router"
+)
+
+// Genera el contrato OpenAPI sin inicializar dependencias de AWS:
+// los handlers se registran pero nunca se invocan.
+func main() {
+	output := flag.String("o", "", "output file (defaults to stdout)")
+	serverURL := flag.String("server", "", "optional server URL to include in the document")
+	flag.Parse()
+
+	r := router.New()
+	routes.Register(r, routes.Dependencies{
+		CandidateController:       controllers.NewCandidateController(controllers.CandidateControllerConfig{}),
+		WhatsAppWebhookController: controllers.NewWhatsAppWebhookController(controllers.WhatsAppWebhookControllerConfig{}),
+		OutboxController:          controllers.NewOutboxController(controllers.OutboxControllerConfig{}),
+		InterviewSlotController:   controllers.NewInterviewSlotController(controllers.InterviewSlotControllerConfig{}),
+		MessageController:         controllers.NewMessageController(controllers.MessageControllerConfig{}),
+		TwilioWebhookController:   controllers.NewTwilioWebhookController(controllers.TwilioWebhookControllerConfig{}),
+	})
+
+	var servers []openapi.Server
+	if *serverURL != "" {
+		servers = append(servers, openapi.Server{URL: *serverURL})
+	}
+
+	body, err := json.MarshalIndent(openapi.Generate(routes.Info, r.Routes(), servers...), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to generate OpenAPI document: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		fmt.Println(string(body))
+		return
+	}
+	if err := os.WriteFile(*output, append(body, '\n'), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", *output, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/infrastructure/container"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+)
+
+var (
+	initStart time.Time
+	initErr   error
+
+	log           logger.Logger
+	processOutbox *commands.ProcessOutboxService
+)
+
+func init() {
+	initStart = time.Now()
+	ctx := context.Background()
+
+	mainContainer, err := container.NewMainLambdaContainer(ctx)
+	if err != nil {
+		initErr = err
+		return
+	}
+	log = mainContainer.Logger()
+
+	processOutbox, err = mainContainer.GetProcessOutboxService()
+	if err != nil {
+		initErr = err
+		return
+	}
+
+	log.Info(fmt.Sprintf("Outbox lambda init completed in %v", time.Since(initStart)))
+}
+
+// Invocada por una regla programada de EventBridge: entrega un lote de
+// registros vencidos por invocación
+func main() {
+	lambda.Start(func(ctx context.Context, event events.CloudWatchEvent) (*commands.ProcessOutboxServiceOutput, error) {
+		if initErr != nil {
+			return nil, initErr
+		}
+
+		result, err := processOutbox.Execute(ctx, commands.ProcessOutboxServiceInput{Now: event.Time})
+		if err != nil {
+			return nil, err
+		}
+
+		log.Info(map[string]any{
+			"msg":       "Outbox batch processed",
+			"delivered": result.Delivered,
+			"retried":   result.Retried,
+			"skipped":   result.Skipped,
+			"failed":    result.Failed,
+		})
+		return result, nil
+	})
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/Yolto7/api-candidates/internal/infrastructure/container"
+	"github.com/Yolto7/api-candidates/internal/presentation/consumers"
+)
+
+var (
+	initStart time.Time
+	initErr   error
+
+	streamConsumer *consumers.CandidateStreamConsumer
+)
+
+func init() {
+	initStart = time.Now()
+	ctx := context.Background()
+
+	mainContainer, err := container.NewMainLambdaContainer(ctx)
+	if err != nil {
+		initErr = err
+		return
+	}
+
+	streamConsumer, err = mainContainer.GetCandidateStreamConsumer()
+	if err != nil {
+		initErr = err
+		return
+	}
+
+	mainContainer.Logger().Info(fmt.Sprintf("Streams lambda init completed in %v", time.Since(initStart)))
+}
+
+// Invocada por el stream de DynamoDB de la tabla de candidatos; devuelve los
+// registros fallidos para que Lambda reintente solo desde ellos
+func main() {
+	lambda.Start(func(ctx context.Context, event events.DynamoDBEvent) (events.DynamoDBEventResponse, error) {
+		if initErr != nil {
+			return events.DynamoDBEventResponse{}, initErr
+		}
+
+		return streamConsumer.Handle(ctx, event)
+	})
+}
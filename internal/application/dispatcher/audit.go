@@ -0,0 +1,25 @@
+package dispatcher
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+)
+
+// AuditLog writes an audit line per event. Only identifiers are logged,
+// never the event images.
+func AuditLog(log logger.Logger) Handler {
+	return HandlerFunc(func(_ context.Context, event events.Event) error {
+		meta := event.Meta()
+		log.Info(map[string]any{
+			"msg":         "Domain event",
+			"audit":       true,
+			"event":       event.Name(),
+			"eventId":     meta.ID,
+			"aggregateId": meta.AggregateID,
+			"occurredAt":  meta.OccurredAt,
+		})
+		return nil
+	})
+}
@@ -0,0 +1,184 @@
+package dispatcher
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// AllEvents subscribes a handler to every event name
+const AllEvents = "*"
+
+const defaultAsyncTimeout = 10 * time.Second
+
+// Handler reacts to a domain event
+type Handler interface {
+	Handle(ctx context.Context, event events.Event) error
+}
+
+// HandlerFunc adapts a function to Handler
+type HandlerFunc func(ctx context.Context, event events.Event) error
+
+func (f HandlerFunc) Handle(ctx context.Context, event events.Event) error {
+	return f(ctx, event)
+}
+
+type subscription struct {
+	name    string
+	handler Handler
+	async   bool
+}
+
+type task struct {
+	ctx   context.Context
+	sub   subscription
+	event events.Event
+}
+
+// Dispatcher runs the handlers subscribed to domain events.
+//
+// Events are handled in the order they are dispatched, and the handlers of an
+// event in the order they were subscribed. Sync handlers run before Dispatch
+// returns. Async handlers run on a single background worker, so they keep the
+// same order among themselves but do not delay the caller.
+//
+// A handler error or panic is logged and does not affect the other handlers
+// nor the caller.
+type Dispatcher struct {
+	logger       logger.Logger
+	asyncTimeout time.Duration
+
+	mu            sync.RWMutex
+	subscriptions []subscription
+
+	queueMu sync.Mutex
+	queue   []task
+	running bool
+	pending sync.WaitGroup
+}
+
+// Config of a Dispatcher. AsyncTimeout bounds each async handler, 10s by default.
+type Config struct {
+	Logger       logger.Logger
+	AsyncTimeout time.Duration
+}
+
+func New(cfg Config) *Dispatcher {
+	d := &Dispatcher{
+		logger:       cfg.Logger,
+		asyncTimeout: cfg.AsyncTimeout,
+	}
+	if d.asyncTimeout <= 0 {
+		d.asyncTimeout = defaultAsyncTimeout
+	}
+	return d
+}
+
+// On subscribes a handler that runs inside Dispatch
+func (d *Dispatcher) On(name string, handler Handler) {
+	d.subscribe(subscription{name: name, handler: handler})
+}
+
+// OnAsync subscribes a handler that runs in the background after Dispatch.
+// It gets a context detached from the caller's cancellation.
+func (d *Dispatcher) OnAsync(name string, handler Handler) {
+	d.subscribe(subscription{name: name, handler: handler, async: true})
+}
+
+func (d *Dispatcher) subscribe(sub subscription) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions = append(d.subscriptions, sub)
+}
+
+// Dispatch runs the handlers of each event. A nil Dispatcher does nothing.
+func (d *Dispatcher) Dispatch(ctx context.Context, evts ...events.Event) {
+	if d == nil {
+		return
+	}
+
+	d.mu.RLock()
+	subscriptions := d.subscriptions
+	d.mu.RUnlock()
+
+	for _, event := range evts {
+		for _, sub := range subscriptions {
+			if sub.name != AllEvents && sub.name != event.Name() {
+				continue
+			}
+			if sub.async {
+				d.enqueue(task{ctx: context.WithoutCancel(ctx), sub: sub, event: event})
+				continue
+			}
+			d.run(ctx, sub, event)
+		}
+	}
+}
+
+// Wait blocks until the async handlers dispatched so far are done. Lambda
+// entrypoints call it before returning, since the sandbox is frozen between
+// invocations.
+func (d *Dispatcher) Wait() {
+	if d == nil {
+		return
+	}
+	d.pending.Wait()
+}
+
+func (d *Dispatcher) enqueue(t task) {
+	d.pending.Add(1)
+
+	d.queueMu.Lock()
+	defer d.queueMu.Unlock()
+	d.queue = append(d.queue, t)
+	if !d.running {
+		d.running = true
+		go d.work()
+	}
+}
+
+// work drains the queue in order and exits when it is empty
+func (d *Dispatcher) work() {
+	for {
+		d.queueMu.Lock()
+		if len(d.queue) == 0 {
+			d.running = false
+			d.queueMu.Unlock()
+			return
+		}
+		t := d.queue[0]
+		d.queue = d.queue[1:]
+		d.queueMu.Unlock()
+
+		ctx, cancel := context.WithTimeout(t.ctx, d.asyncTimeout)
+		d.run(ctx, t.sub, t.event)
+		cancel()
+		d.pending.Done()
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context, sub subscription, event events.Event) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			d.logFailure(sub, event, fmt.Errorf("panic: %v", recovered))
+		}
+	}()
+
+	if err := sub.handler.Handle(ctx, event); err != nil {
+		d.logFailure(sub, event, err)
+	}
+}
+
+func (d *Dispatcher) logFailure(sub subscription, event events.Event, err error) {
+	d.logger.Error(utils.NewSafeError(err, fmt.Sprintf(
+		"Error in Dispatcher: Handler of %s failed (subscription %q, async %t, event %s)",
+		event.Name(), sub.name, sub.async, event.Meta().ID)))
+}
+
+var _ ports.EventDispatcher = (*Dispatcher)(nil)
@@ -0,0 +1,153 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+)
+
+// recordingLogger keeps the errors logged by the dispatcher
+type recordingLogger struct {
+	mu     sync.Mutex
+	errors []any
+}
+
+func (l *recordingLogger) Debug(any) {}
+func (l *recordingLogger) Info(any)  {}
+func (l *recordingLogger) Warn(any)  {}
+func (l *recordingLogger) Error(input any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, input)
+}
+
+// recorder appends the name of each handler run, in order
+type recorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (r *recorder) handler(name string, err error) Handler {
+	return HandlerFunc(func(ctx context.Context, event events.Event) error {
+		r.mu.Lock()
+		r.calls = append(r.calls, name+":"+event.Name())
+		r.mu.Unlock()
+		return err
+	})
+}
+
+func (r *recorder) result() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+func newEvents() []events.Event {
+	return []events.Event{
+		&events.CandidateCreated{Metadata: events.Metadata{ID: "1", AggregateID: "c1"}},
+		&events.CandidateDeleted{Metadata: events.Metadata{ID: "2", AggregateID: "c1"}},
+	}
+}
+
+func TestSyncHandlersRunInOrder(t *testing.T) {
+	log := &recordingLogger{}
+	d := New(Config{Logger: log})
+	rec := &recorder{}
+
+	d.On(events.CandidateDeletedName, rec.handler("deleted", nil))
+	d.On(AllEvents, rec.handler("all", nil))
+	d.On(events.CandidateCreatedName, rec.handler("created", nil))
+
+	d.Dispatch(context.Background(), newEvents()...)
+
+	want := []string{
+		"all:CandidateCreated",
+		"created:CandidateCreated",
+		"deleted:CandidateDeleted",
+		"all:CandidateDeleted",
+	}
+	if got := rec.result(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("calls = %v, want %v", got, want)
+	}
+	if len(log.errors) != 0 {
+		t.Fatalf("logged %d errors, want none", len(log.errors))
+	}
+}
+
+func TestAsyncHandlersRunInOrderAfterSync(t *testing.T) {
+	log := &recordingLogger{}
+	d := New(Config{Logger: log})
+	rec := &recorder{}
+
+	d.OnAsync(AllEvents, rec.handler("async1", nil))
+	d.On(AllEvents, rec.handler("sync", nil))
+	d.OnAsync(AllEvents, rec.handler("async2", nil))
+
+	// Sync handlers are done when Dispatch returns
+	block := make(chan struct{})
+	d.OnAsync(events.CandidateCreatedName, HandlerFunc(func(ctx context.Context, event events.Event) error {
+		<-block
+		return nil
+	}))
+	d.Dispatch(context.Background(), newEvents()...)
+	if got := rec.result(); len(got) == 0 || got[0] != "sync:CandidateCreated" {
+		t.Fatalf("first call = %v, want the sync handler", got)
+	}
+	close(block)
+	d.Wait()
+
+	var async []string
+	for _, call := range rec.result() {
+		if call != "sync:CandidateCreated" && call != "sync:CandidateDeleted" {
+			async = append(async, call)
+		}
+	}
+	want := []string{
+		"async1:CandidateCreated",
+		"async2:CandidateCreated",
+		"async1:CandidateDeleted",
+		"async2:CandidateDeleted",
+	}
+	if !reflect.DeepEqual(async, want) {
+		t.Fatalf("async calls = %v, want %v", async, want)
+	}
+}
+
+func TestFailingHandlersDoNotStopTheOthers(t *testing.T) {
+	for _, async := range []bool{false, true} {
+		log := &recordingLogger{}
+		d := New(Config{Logger: log})
+		rec := &recorder{}
+
+		subscribe := d.On
+		if async {
+			subscribe = d.OnAsync
+		}
+		subscribe(AllEvents, rec.handler("failing", errors.New("boom")))
+		subscribe(AllEvents, HandlerFunc(func(ctx context.Context, event events.Event) error {
+			panic("handler panicked")
+		}))
+		subscribe(AllEvents, rec.handler("last", nil))
+
+		d.Dispatch(context.Background(), newEvents()[0])
+		d.Wait()
+
+		want := []string{"failing:CandidateCreated", "last:CandidateCreated"}
+		if got := rec.result(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("async=%t: calls = %v, want %v", async, got, want)
+		}
+		if len(log.errors) != 2 {
+			t.Fatalf("async=%t: logged %d errors, want the error and the panic", async, len(log.errors))
+		}
+	}
+}
+
+func TestNilDispatcher(t *testing.T) {
+	var d *Dispatcher
+	d.Dispatch(context.Background(), newEvents()...)
+	d.Wait()
+}
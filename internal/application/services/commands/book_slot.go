@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// BookSlotServiceInput books a seat of a slot for a candidate
+type BookSlotServiceInput struct {
+	CandidateID string `json:"-" validate:"required,notblank"`
+	SlotID      string `json:"slotId" validate:"required,notblank"`
+}
+
+// BookSlotServiceOutput returns the slot booked, with the seat taken, and
+// the interview link of the candidate
+type BookSlotServiceOutput struct {
+	CandidateID   string                  `json:"candidateId"`
+	Slot          *entities.InterviewSlot `json:"slot"`
+	InterviewLink string                  `json:"interviewLink,omitempty"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// BookSlotService handles interview bookings
+type BookSlotService struct {
+	config              *config.Config
+	logger              logger.Logger
+	candidateRepository repositories.CandidateRepository
+	slotRepository      repositories.InterviewSlotRepository
+	sheetSync           *SheetSync
+	meetings            *InterviewMeetings
+}
+
+// BookSlotServiceConfig holds the configuration dependencies for BookSlotService
+type BookSlotServiceConfig struct {
+	Config              *config.Config
+	Logger              logger.Logger
+	CandidateRepository repositories.CandidateRepository
+	SlotRepository      repositories.InterviewSlotRepository
+	// SheetSync is optional; without it no sheet update is queued
+	SheetSync *SheetSync
+	// Meetings is optional; without it virtual slots keep their own link
+	Meetings *InterviewMeetings
+}
+
+// NewBookSlotService creates a new instance of BookSlotService with provided configuration
+func NewBookSlotService(cfg BookSlotServiceConfig) *BookSlotService {
+	return &BookSlotService{
+		config:              cfg.Config,
+		logger:              cfg.Logger,
+		candidateRepository: cfg.CandidateRepository,
+		slotRepository:      cfg.SlotRepository,
+		sheetSync:           cfg.SheetSync,
+		meetings:            cfg.Meetings,
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute takes a seat of the slot and fills the interview date, time and
+// link of the candidate in one transaction, so two recruiters cannot book
+// past the capacity. Virtual slots without a link get a meeting of their own
+// for the candidate. Booking the slot the candidate already holds succeeds
+// without change.
+// Returns NOT_FOUND if the candidate or slot does not exist, BAD_REQUEST if
+// the slot already started, CONFLICT if it is full or the candidate holds
+// another booking and SERVICE_UNAVAILABLE if the meeting cannot be created
+func (svc *BookSlotService) Execute(ctx context.Context, input *BookSlotServiceInput) (*BookSlotServiceOutput, error) {
+	candidate, err := svc.candidateRepository.GetByID(ctx, input.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil || candidate.Deleted {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Candidate not found", "ERR_CANDIDATE_NOT_FOUND")
+	}
+
+	slot, err := svc.slotRepository.GetByID(ctx, input.SlotID)
+	if err != nil {
+		return nil, err
+	}
+	if slot == nil {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Interview slot not found", "ERR_SLOT_NOT_FOUND")
+	}
+
+	switch {
+	case candidate.InterviewSlotID == slot.ID:
+		return &BookSlotServiceOutput{CandidateID: candidate.ID, Slot: slot, InterviewLink: candidate.InterviewLink}, nil
+	case candidate.InterviewSlotID != "":
+		return nil, errorCustom.NewError(errorCustom.CONFLICT, "Candidate already has an interview booked", "ERR_CANDIDATE_ALREADY_BOOKED")
+	case !slot.Start().After(time.Now()):
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Interview slot already started", "ERR_SLOT_IN_PAST")
+	case slot.Available() == 0:
+		return nil, errorCustom.NewError(errorCustom.CONFLICT, "Interview slot is full", "ERR_SLOT_FULL")
+	}
+
+	meeting, err := svc.meetings.Create(ctx, slot, candidate)
+	if err != nil {
+		return nil, err
+	}
+	link := slot.Link
+	if meeting != nil {
+		link = meeting.URL
+	}
+
+	updatedAt := pkgIUtils.NowDateTime(svc.config.TIME_ZONE)
+	updatedBy := constants.SYSTEM_USER
+	updates := map[string]interface{}{
+		"interviewDate": slot.Date,
+		"interviewTime": slot.StartTime,
+		"interviewLink": link,
+		"updatedAt":     &updatedAt,
+		"updatedBy":     &updatedBy,
+	}
+	if meeting != nil {
+		updates["interviewMeetingId"] = meeting.ID
+	}
+
+	outbox, err := svc.sheetSync.Outbox(candidate, updates)
+	if err != nil {
+		svc.meetings.Discard(ctx, meeting)
+		return nil, err
+	}
+	if err := svc.slotRepository.Book(ctx, slot.ID, candidate.ID, updates, outbox...); err != nil {
+		svc.meetings.Discard(ctx, meeting)
+		return nil, err
+	}
+	slot.Booked++
+
+	svc.logger.Info(map[string]any{
+		"msg":         "Interview slot booked",
+		"candidateId": candidate.ID,
+		"slotId":      slot.ID,
+		"store":       slot.Store,
+	})
+	return &BookSlotServiceOutput{CandidateID: candidate.ID, Slot: slot, InterviewLink: link}, nil
+}
@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// CancelBookingServiceInput identifies the candidate whose booking is cancelled
+type CancelBookingServiceInput struct {
+	CandidateID string `json:"id" validate:"required,notblank"`
+}
+
+// CancelBookingServiceOutput reports the slot whose seat was released
+type CancelBookingServiceOutput struct {
+	CandidateID string `json:"candidateId"`
+	SlotID      string `json:"slotId"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// CancelBookingService handles the cancellation of interview bookings
+type CancelBookingService struct {
+	config              *config.Config
+	logger              logger.Logger
+	candidateRepository repositories.CandidateRepository
+	slotRepository      repositories.InterviewSlotRepository
+	sheetSync           *SheetSync
+	meetings            *InterviewMeetings
+}
+
+// CancelBookingServiceConfig holds the configuration dependencies for CancelBookingService
+type CancelBookingServiceConfig struct {
+	Config              *config.Config
+	Logger              logger.Logger
+	CandidateRepository repositories.CandidateRepository
+	SlotRepository      repositories.InterviewSlotRepository
+	// SheetSync is optional; without it no sheet update is queued
+	SheetSync *SheetSync
+	// Meetings delivers the cancellation of the meeting created at booking
+	Meetings *InterviewMeetings
+}
+
+// NewCancelBookingService creates a new instance of CancelBookingService with provided configuration
+func NewCancelBookingService(cfg CancelBookingServiceConfig) *CancelBookingService {
+	return &CancelBookingService{
+		config:              cfg.Config,
+		logger:              cfg.Logger,
+		candidateRepository: cfg.CandidateRepository,
+		slotRepository:      cfg.SlotRepository,
+		sheetSync:           cfg.SheetSync,
+		meetings:            cfg.Meetings,
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute releases the seat held by the candidate and clears the interview
+// date, time and link in one transaction, queueing the cancellation of the
+// candidate's meeting if one was created
+// Returns NOT_FOUND if the candidate does not exist or holds no booking
+func (svc *CancelBookingService) Execute(ctx context.Context, input *CancelBookingServiceInput) (*CancelBookingServiceOutput, error) {
+	candidate, err := svc.candidateRepository.GetByID(ctx, input.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil || candidate.Deleted {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Candidate not found", "ERR_CANDIDATE_NOT_FOUND")
+	}
+	if candidate.InterviewSlotID == "" {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Candidate has no interview booked", "ERR_BOOKING_NOT_FOUND")
+	}
+
+	updatedAt := pkgIUtils.NowDateTime(svc.config.TIME_ZONE)
+	updatedBy := constants.SYSTEM_USER
+	updates := map[string]interface{}{
+		"interviewDate": "",
+		"interviewTime": "",
+		"interviewLink": "",
+		"updatedAt":     &updatedAt,
+		"updatedBy":     &updatedBy,
+	}
+
+	outbox, err := svc.sheetSync.Outbox(candidate, updates)
+	if err != nil {
+		return nil, err
+	}
+	meetingOutbox, err := svc.meetings.Outbox(candidate)
+	if err != nil {
+		return nil, err
+	}
+	outbox = append(outbox, meetingOutbox...)
+	if err := svc.slotRepository.Cancel(ctx, candidate.InterviewSlotID, candidate.ID, updates, outbox...); err != nil {
+		return nil, err
+	}
+
+	svc.logger.Info(map[string]any{
+		"msg":         "Interview booking cancelled",
+		"candidateId": candidate.ID,
+		"slotId":      candidate.InterviewSlotID,
+	})
+	return &CancelBookingServiceOutput{CandidateID: candidate.ID, SlotID: candidate.InterviewSlotID}, nil
+}
@@ -3,9 +3,12 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Yolto7/api-candidates/internal/domain/config"
 	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
 	"github.com/Yolto7/api-candidates/internal/domain/repositories"
 	"github.com/Yolto7/api-candidates/pkg/domain/constants"
 	"github.com/Yolto7/api-candidates/pkg/domain/logger"
@@ -19,17 +22,30 @@ import (
 // CreateServiceInput represents the input for candidate create operation
 type CreateServiceInput struct {
 	ID                                string `json:"id" validate:"required,notblank"`
-	SheetID                           string `json:"sheetId" validate:"required,notblank"`
-	RowID                             string `json:"rowId" validate:"required,notblank"`
-	ColumnPostulantSuitableId         string `json:"columnPostulantSuitableId"`
-	ColumnSendMessageId               string `json:"columnSendMessageId"`
-	ColumnSendDateTimeId              string `json:"columnSendDateTimeId"`
-	ColumnPostulantResponseId         string `json:"columnPostulantResponseId" validate:"required,notblank"`
-	ColumnPostulantDateTimeResponseId string `json:"columnPostulantDateTimeResponseId" validate:"required,notblank"`
-	ColumnPostulantConfirmedId        string `json:"columnPostulantConfirmedId" validate:"required,notblank"`
-	ColumnInterviewDateId             string `json:"columnInterviewDateId" validate:"required,notblank"`
-	ColumnInterviewTimeId             string `json:"columnInterviewTimeId" validate:"required,notblank"`
-	ColumnInterviewLinkId             string `json:"columnInterviewLinkId" validate:"required,notblank"`
+	SheetID                           string `json:"sheetId" validate:"required,notblank,smartsheet_id"`
+	RowID                             string `json:"rowId" validate:"required,notblank,smartsheet_id"`
+	ColumnPostulantSuitableId         string `json:"columnPostulantSuitableId" validate:"omitempty,smartsheet_id"`
+	ColumnSendMessageId               string `json:"columnSendMessageId" validate:"omitempty,smartsheet_id"`
+	ColumnSendDateTimeId              string `json:"columnSendDateTimeId" validate:"omitempty,smartsheet_id"`
+	ColumnPostulantResponseId         string `json:"columnPostulantResponseId" validate:"required,notblank,smartsheet_id"`
+	ColumnPostulantDateTimeResponseId string `json:"columnPostulantDateTimeResponseId" validate:"required,notblank,smartsheet_id"`
+	ColumnPostulantConfirmedId        string `json:"columnPostulantConfirmedId" validate:"required,notblank,smartsheet_id"`
+	ColumnInterviewDateId             string `json:"columnInterviewDateId" validate:"required,notblank,smartsheet_id"`
+	ColumnInterviewTimeId             string `json:"columnInterviewTimeId" validate:"required,notblank,smartsheet_id"`
+	ColumnInterviewLinkId             string `json:"columnInterviewLinkId" validate:"required,notblank,smartsheet_id"`
+
+	// Profile. Optional, so callers that predate it keep working; the
+	// document type and number go together
+	Names                             string `json:"names" validate:"omitempty,notblank,max=100"`
+	Surnames                          string `json:"surnames" validate:"omitempty,notblank,max=100"`
+	DocumentType                      string `json:"documentType" validate:"omitempty,oneof=DNI CE"`
+	DocumentNumber                    string `json:"documentNumber" validate:"omitempty,notblank"`
+	Phone                             string `json:"phone" validate:"omitempty,pe_phone"`
+	Email                             string `json:"email" validate:"omitempty,email,max=254"`
+	BirthDate                         string `json:"birthDate" validate:"omitempty,lima_date"`
+	District                          string `json:"district" validate:"omitempty,max=100"`
+	AppliedPosition                   string `json:"appliedPosition" validate:"omitempty,max=100"`
+	Store                             string `json:"store" validate:"omitempty,max=100"`
 }
 
 // CreateServiceOutput represents the output of candidate create operation
@@ -45,6 +61,7 @@ type CreateService struct {
 	config              *config.Config
 	logger              logger.Logger
 	candidateRepository repositories.CandidateRepository
+	dispatcher          ports.EventDispatcher
 }
 
 // CreateServiceConfig holds the configuration dependencies for CreateService
@@ -52,6 +69,8 @@ type CreateServiceConfig struct {
 	Config              *config.Config
 	Logger              logger.Logger
 	CandidateRepository repositories.CandidateRepository
+	// Dispatcher is optional; it receives CandidateCreated
+	Dispatcher ports.EventDispatcher
 }
 
 // NewCreateService creates a new instance of CreateService with provided configuration
@@ -60,6 +79,7 @@ func NewCreateService(cfg CreateServiceConfig) *CreateService {
 		config:              cfg.Config,
 		logger:              cfg.Logger,
 		candidateRepository: cfg.CandidateRepository,
+		dispatcher:          cfg.Dispatcher,
 	}
 }
 
@@ -70,6 +90,11 @@ func NewCreateService(cfg CreateServiceConfig) *CreateService {
 // Execute performs an optimized create operation on candidate
 // Returns error if validation fails or repository operations fail
 func (svc *CreateService) Execute(ctx context.Context, input *CreateServiceInput) (*CreateServiceOutput, error) {
+	documentNumber := strings.ToUpper(input.DocumentNumber)
+	documentKey := ""
+	if input.DocumentType != "" && documentNumber != "" {
+		documentKey = entities.BuildDocumentKey(input.DocumentType, documentNumber)
+	}
 
 	candidate := &entities.Candidate{
 		ID:                                input.ID,
@@ -85,6 +110,18 @@ func (svc *CreateService) Execute(ctx context.Context, input *CreateServiceInput
 		ColumnInterviewDateId:             input.ColumnInterviewDateId,
 		ColumnInterviewTimeId:             input.ColumnInterviewTimeId,
 		ColumnInterviewLinkId:             input.ColumnInterviewLinkId,
+		Names:                             input.Names,
+		Surnames:                          input.Surnames,
+		DocumentType:                      input.DocumentType,
+		DocumentNumber:                    documentNumber,
+		DocumentKey:                       documentKey,
+		Phone:                             input.Phone,
+		Email:                             strings.ToLower(input.Email),
+		BirthDate:                         input.BirthDate,
+		District:                          input.District,
+		AppliedPosition:                   input.AppliedPosition,
+		Store:                             input.Store,
+		Status:                            entities.StatusPending,
 		CreatedAt:                         pkgIUtils.NowDateTime("America/Lima"),
 		CreatedBy:                         constants.SYSTEM_USER,
 		Deleted:                           false,
@@ -93,6 +130,10 @@ func (svc *CreateService) Execute(ctx context.Context, input *CreateServiceInput
 	if err := svc.candidateRepository.Create(ctx, candidate); err != nil {
 		return nil, err
 	}
+	dispatch(ctx, svc.dispatcher, &events.CandidateCreated{
+		Metadata: newEventMetadata(candidate),
+		After:    events.NewCandidateImage(candidate),
+	})
 
 	return &CreateServiceOutput{}, nil
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
 	"github.com/Yolto7/api-candidates/internal/domain/repositories"
 	"github.com/Yolto7/api-candidates/pkg/domain/logger"
 )
@@ -30,6 +32,9 @@ type DeleteService struct {
 	config                 *config.Config
 	logger                 logger.Logger
 	candidateRepository repositories.CandidateRepository
+	slotRepository      repositories.InterviewSlotRepository
+	meetings            *InterviewMeetings
+	dispatcher          ports.EventDispatcher
 }
 
 // DeleteServiceConfig holds the configuration dependencies for DeleteService
@@ -37,6 +42,12 @@ type DeleteServiceConfig struct {
 	Config                 *config.Config
 	Logger                 logger.Logger
 	CandidateRepository repositories.CandidateRepository
+	// SlotRepository releases the seat of a booked candidate
+	SlotRepository repositories.InterviewSlotRepository
+	// Meetings delivers the cancellation of the meeting created at booking
+	Meetings *InterviewMeetings
+	// Dispatcher is optional; it receives CandidateDeleted
+	Dispatcher ports.EventDispatcher
 }
 
 // NewDeleteService deletes a new instance of DeleteService with provided configuration
@@ -45,6 +56,9 @@ func NewDeleteService(cfg DeleteServiceConfig) *DeleteService {
 		config:                 cfg.Config,
 		logger:                 cfg.Logger,
 		candidateRepository: cfg.CandidateRepository,
+		slotRepository:      cfg.SlotRepository,
+		meetings:            cfg.Meetings,
+		dispatcher:          cfg.Dispatcher,
 	}
 }
 
@@ -52,13 +66,38 @@ func NewDeleteService(cfg DeleteServiceConfig) *DeleteService {
 // Main Service Logic
 // =====================================================================
 
-// Execute performs an optimized delete operation on candidate
-// Returns error if validation fails or repository operations fail
+// Execute performs an optimized delete operation on candidate. A candidate
+// holding an interview booking is deleted in the same transaction that
+// releases its seat, queueing the cancellation of its meeting if one was
+// created.
+// Returns CONFLICT if the booking changed meanwhile, or error if validation
+// fails or repository operations fail
 func (svc *DeleteService) Execute(ctx context.Context, input *DeleteServiceInput) (*DeleteServiceOutput, error) {
-	if err := svc.candidateRepository.Delete(ctx, input.ID); err != nil {
+	// Read first so CandidateDeleted carries the last state; deleting an
+	// unknown ID succeeds without event
+	candidate, err := svc.candidateRepository.GetByID(ctx, input.ID)
+	if err != nil {
 		return nil, err
 	}
 
+	if candidate != nil && candidate.InterviewSlotID != "" {
+		outbox, err := svc.meetings.Outbox(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if err := svc.slotRepository.DeleteBooked(ctx, candidate.InterviewSlotID, candidate.ID, outbox...); err != nil {
+			return nil, err
+		}
+	} else if err := svc.candidateRepository.Delete(ctx, input.ID); err != nil {
+		return nil, err
+	}
+	if candidate != nil && !candidate.Deleted {
+		dispatch(ctx, svc.dispatcher, &events.CandidateDeleted{
+			Metadata: newEventMetadata(candidate),
+			Before:   events.NewCandidateImage(candidate),
+		})
+	}
+
 	return &DeleteServiceOutput{}, nil
 }
 
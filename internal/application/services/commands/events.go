@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// dispatch hands the events recorded by a service to the dispatcher, once
+// the change is committed. Services take the dispatcher as optional.
+func dispatch(ctx context.Context, dispatcher ports.EventDispatcher, evts ...events.Event) {
+	if dispatcher == nil || len(evts) == 0 {
+		return
+	}
+	dispatcher.Dispatch(ctx, evts...)
+}
+
+func newEventMetadata(candidate *entities.Candidate) events.Metadata {
+	return events.Metadata{
+		ID:          pkgIUtils.GenerateUUID(),
+		AggregateID: candidate.ID,
+		OccurredAt:  time.Now(),
+	}
+}
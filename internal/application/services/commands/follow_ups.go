@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// FollowUps is the no-response policy: After each unanswered invitation or
+// follow-up, a job is scheduled that sends the followup template, up to
+// MaxAttempts times, and then moves the candidate to NO_RESPONSE.
+//
+// Jobs are scheduled through the outbox, written with the message, and run
+// by the follow-up lambda (Target) with ProcessFollowUpService, which checks
+// the candidate again: a reply, or a newer message, cancels the chain.
+type FollowUps struct {
+	logger      logger.Logger
+	scheduler   ports.Scheduler
+	after       time.Duration
+	maxAttempts int
+	targetArn   string
+	roleArn     string
+}
+
+// FollowUpsConfig holds the policy and the target of the scheduled jobs
+type FollowUpsConfig struct {
+	Logger    logger.Logger
+	Scheduler ports.Scheduler
+	// After is the time without response before each follow-up
+	After time.Duration
+	// MaxAttempts is the number of follow-ups sent before NO_RESPONSE
+	MaxAttempts int
+	TargetArn   string
+	RoleArn     string
+}
+
+func NewFollowUps(cfg FollowUpsConfig) *FollowUps {
+	return &FollowUps{
+		logger:      cfg.Logger,
+		scheduler:   cfg.Scheduler,
+		after:       cfg.After,
+		maxAttempts: cfg.MaxAttempts,
+		targetArn:   cfg.TargetArn,
+		roleArn:     cfg.RoleArn,
+	}
+}
+
+// Outbox returns the record that schedules the check of a message sent to
+// the candidate, or none if the policy is off, the candidate already replied
+// or the message neither is an invitation nor a follow-up. attempt is the
+// check to schedule: 1 after an invitation, n+1 after follow-up n.
+func (f *FollowUps) Outbox(candidate *entities.Candidate, template, messageID string, attempt int, sentAt time.Time) ([]*entities.OutboxRecord, error) {
+	if f == nil || !candidate.AwaitingResponse() {
+		return nil, nil
+	}
+	if attempt == 1 && template != TemplateInvitation {
+		return nil, nil
+	}
+
+	record, err := entities.NewOutboxRecord(pkgIUtils.GenerateUUID(), entities.OutboxFollowUpSchedule, candidate.ID, entities.FollowUpPayload{
+		CandidateID: candidate.ID,
+		MessageID:   messageID,
+		Attempt:     attempt,
+		DueAt:       sentAt.Add(f.after).UTC().Format(entities.OutboxTimeFormat),
+	}, time.Now())
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to build follow-up schedule", "OUTBOX_ERROR")
+	}
+	return []*entities.OutboxRecord{record}, nil
+}
+
+// Handle delivers an OutboxFollowUpSchedule record. The schedule is named
+// after the candidate, attempt and message, so a redelivered record finds
+// it already created.
+func (f *FollowUps) Handle(ctx context.Context, record *entities.OutboxRecord) error {
+	var payload entities.FollowUpPayload
+	if err := record.DecodePayload(&payload); err != nil || payload.CandidateID == "" || payload.Attempt < 1 {
+		return errorCustom.NewError(errorCustom.UNPROCESSABLE_ENTITY, "Invalid follow-up schedule payload", "OUTBOX_INVALID_PAYLOAD")
+	}
+
+	// Records delivered late are scheduled shortly after now: one-time
+	// schedules in the past never run
+	dueAt, err := time.Parse(entities.OutboxTimeFormat, payload.DueAt)
+	if err != nil {
+		return errorCustom.NewError(errorCustom.UNPROCESSABLE_ENTITY, "Invalid follow-up due time", "OUTBOX_INVALID_PAYLOAD")
+	}
+	if earliest := time.Now().Add(time.Minute); dueAt.Before(earliest) {
+		dueAt = earliest
+	}
+
+	name := followUpScheduleName(payload)
+	_, err = f.scheduler.Create(ctx, ports.CreateScheduleParams{
+		Name:          name,
+		Description:   fmt.Sprintf("Follow-up %d of candidate %s", payload.Attempt, payload.CandidateID),
+		ScheduledTime: &dueAt,
+		TargetArn:     f.targetArn,
+		RoleArn:       f.roleArn,
+		Input:         payload,
+	})
+
+	var customErr *errorCustom.CustomError
+	if errors.As(err, &customErr) && customErr.ErrorCode == "ERR_SCHEDULE_EXISTS" {
+		return nil
+	}
+	if err != nil {
+		f.logger.Error(pkgIUtils.NewSafeError(err, "Error in FollowUps.Handle: Failed to schedule "+name))
+		return err
+	}
+
+	f.logger.Info(map[string]any{
+		"msg":         "Follow-up scheduled",
+		"candidateId": payload.CandidateID,
+		"attempt":     payload.Attempt,
+		"dueAt":       dueAt.UTC().Format(entities.OutboxTimeFormat),
+	})
+	return nil
+}
+
+// followUpScheduleName fits the 64 characters allowed for schedule names;
+// the message hash tells apart the chains of successive invitations
+func followUpScheduleName(payload entities.FollowUpPayload) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(payload.MessageID))
+	return fmt.Sprintf("followup-%s-%d-%08x", payload.CandidateID, payload.Attempt, hash.Sum32())
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// InterviewMeetings creates the meeting of a virtual interview when it is
+// booked. Cancelling is not done inline: Outbox builds an outbox record that
+// is saved with the cancelled booking, and the processor delivers it with
+// Handle.
+type InterviewMeetings struct {
+	logger   logger.Logger
+	provider ports.MeetingProvider
+}
+
+func NewInterviewMeetings(logger logger.Logger, provider ports.MeetingProvider) *InterviewMeetings {
+	return &InterviewMeetings{
+		logger:   logger,
+		provider: provider,
+	}
+}
+
+// Create creates a meeting for the candidate's seat in a virtual slot that
+// has no link of its own. It returns nil for any other slot, and a nil
+// InterviewMeetings returns nil, so services can take it as optional.
+func (m *InterviewMeetings) Create(ctx context.Context, slot *entities.InterviewSlot, candidate *entities.Candidate) (*ports.Meeting, error) {
+	if m == nil || slot.Modality != entities.SlotVirtual || slot.Link != "" {
+		return nil, nil
+	}
+
+	start := slot.Start()
+	if location, err := time.LoadLocation(slot.TimeZone); err == nil {
+		start = start.In(location)
+	}
+	var attendees []string
+	if candidate.Email != "" {
+		attendees = append(attendees, candidate.Email)
+	}
+
+	meeting, err := m.provider.CreateMeeting(ctx, fmt.Sprintf("Entrevista KFC %s", slot.Store), start, slot.Duration(), attendees)
+	if err != nil {
+		m.logger.Error(pkgIUtils.NewSafeError(err, "Error in InterviewMeetings.Create: Failed to create meeting for slot "+slot.ID))
+		return nil, err
+	}
+	return meeting, nil
+}
+
+// Discard cancels a meeting whose booking was not saved. Failures are only
+// logged: the meeting is left orphaned but nobody was sent its link.
+func (m *InterviewMeetings) Discard(ctx context.Context, meeting *ports.Meeting) {
+	if m == nil || meeting == nil {
+		return
+	}
+	if err := m.provider.CancelMeeting(ctx, meeting.ID); err != nil {
+		m.logger.Error(pkgIUtils.NewSafeError(err, "Error in InterviewMeetings.Discard: Failed to cancel meeting "+meeting.ID))
+	}
+}
+
+// Outbox returns the record that cancels the candidate's meeting, or none if
+// the booking has no meeting. A nil InterviewMeetings still cancels it, so
+// meetings created before the provider was turned off are not left behind.
+func (m *InterviewMeetings) Outbox(candidate *entities.Candidate) ([]*entities.OutboxRecord, error) {
+	if candidate.InterviewMeetingID == "" {
+		return nil, nil
+	}
+
+	record, err := entities.NewOutboxRecord(pkgIUtils.GenerateUUID(), entities.OutboxMeetingCancel, candidate.ID, entities.MeetingCancelPayload{
+		MeetingID: candidate.InterviewMeetingID,
+	}, time.Now())
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to build meeting cancellation", "OUTBOX_ERROR")
+	}
+	return []*entities.OutboxRecord{record}, nil
+}
+
+// Handle delivers an OutboxMeetingCancel record
+func (m *InterviewMeetings) Handle(ctx context.Context, record *entities.OutboxRecord) error {
+	var payload entities.MeetingCancelPayload
+	if err := record.DecodePayload(&payload); err != nil || payload.MeetingID == "" {
+		return errorCustom.NewError(errorCustom.UNPROCESSABLE_ENTITY, "Invalid meeting cancellation payload", "OUTBOX_INVALID_PAYLOAD")
+	}
+
+	if err := m.provider.CancelMeeting(ctx, payload.MeetingID); err != nil {
+		m.logger.Error(pkgIUtils.NewSafeError(err, "Error in InterviewMeetings.Handle: Failed to cancel meeting "+payload.MeetingID))
+		return err
+	}
+	return nil
+}
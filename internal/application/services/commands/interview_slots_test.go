@@ -0,0 +1,187 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/infrastructure/adapters"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+)
+
+type slotFixture struct {
+	candidates *memoryCandidates
+	slots      *memorySlots
+	provider   *adapters.LocalMeetingProvider
+	meetings   *InterviewMeetings
+	book       *BookSlotService
+	cancel     *CancelBookingService
+	delete     *DeleteService
+}
+
+func newSlotFixture(slots ...*entities.InterviewSlot) *slotFixture {
+	cfg := &config.Config{TIME_ZONE: constants.DEFAULT_TIME_ZONE}
+	log := pkgILogger.NewZeroLogLogger()
+	f := &slotFixture{
+		candidates: newMemoryCandidates(
+			&entities.Candidate{ID: "c1", Names: "Ana", Email: "ana@example.com"},
+			&entities.Candidate{ID: "c2", Names: "Luis"},
+		),
+		provider: adapters.NewLocalMeetingProvider(""),
+	}
+	f.slots = newMemorySlots(f.candidates, slots...)
+	f.meetings = NewInterviewMeetings(log, f.provider)
+	f.book = NewBookSlotService(BookSlotServiceConfig{Config: cfg, Logger: log, CandidateRepository: f.candidates, SlotRepository: f.slots, Meetings: f.meetings})
+	f.cancel = NewCancelBookingService(CancelBookingServiceConfig{Config: cfg, Logger: log, CandidateRepository: f.candidates, SlotRepository: f.slots, Meetings: f.meetings})
+	f.delete = NewDeleteService(DeleteServiceConfig{Config: cfg, Logger: log, CandidateRepository: f.candidates, SlotRepository: f.slots, Meetings: f.meetings})
+	return f
+}
+
+func newSlot(modality entities.SlotModality, start time.Time, capacity int) *entities.InterviewSlot {
+	return entities.NewInterviewSlot("Larcomar", start, start.Add(30*time.Minute), modality, capacity)
+}
+
+func assertErrorCode(t *testing.T, err error, code string) {
+	t.Helper()
+	var customErr *errorCustom.CustomError
+	if !errors.As(err, &customErr) || customErr.ErrorCode != code {
+		t.Fatalf("err = %v, want %s", err, code)
+	}
+}
+
+func TestBookSlot(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	slot := newSlot(entities.SlotInPerson, tomorrow, 1)
+	f := newSlotFixture(slot)
+	ctx := context.Background()
+
+	output, err := f.book.Execute(ctx, &BookSlotServiceInput{CandidateID: "c1", SlotID: slot.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.Slot.Booked != 1 || output.InterviewLink != "" {
+		t.Fatalf("output = %+v", output)
+	}
+	candidate, _ := f.candidates.GetByID(ctx, "c1")
+	if candidate.InterviewSlotID != slot.ID || candidate.InterviewDate != slot.Date || candidate.InterviewTime != slot.StartTime {
+		t.Errorf("candidate = %+v, want the slot booked", candidate)
+	}
+
+	// Booking the same slot again succeeds without taking another seat
+	if _, err := f.book.Execute(ctx, &BookSlotServiceInput{CandidateID: "c1", SlotID: slot.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := f.slots.GetByID(ctx, slot.ID); stored.Booked != 1 {
+		t.Errorf("booked = %d, want 1", stored.Booked)
+	}
+
+	_, err = f.book.Execute(ctx, &BookSlotServiceInput{CandidateID: "c2", SlotID: slot.ID})
+	assertErrorCode(t, err, "ERR_SLOT_FULL")
+}
+
+func TestBookSlotRejections(t *testing.T) {
+	tomorrow := time.Now().Add(24 * time.Hour).Truncate(time.Minute)
+	open := newSlot(entities.SlotInPerson, tomorrow, 2)
+	other := newSlot(entities.SlotInPerson, tomorrow.Add(time.Hour), 2)
+	past := newSlot(entities.SlotInPerson, time.Now().Add(-time.Hour), 2)
+	f := newSlotFixture(open, other, past)
+	ctx := context.Background()
+
+	if _, err := f.book.Execute(ctx, &BookSlotServiceInput{CandidateID: "c1", SlotID: open.ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		input *BookSlotServiceInput
+		code  string
+	}{
+		{"unknown candidate", &BookSlotServiceInput{CandidateID: "c9", SlotID: open.ID}, "ERR_CANDIDATE_NOT_FOUND"},
+		{"unknown slot", &BookSlotServiceInput{CandidateID: "c2", SlotID: "s9"}, "ERR_SLOT_NOT_FOUND"},
+		{"slot started", &BookSlotServiceInput{CandidateID: "c2", SlotID: past.ID}, "ERR_SLOT_IN_PAST"},
+		{"another booking held", &BookSlotServiceInput{CandidateID: "c1", SlotID: other.ID}, "ERR_CANDIDATE_ALREADY_BOOKED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.book.Execute(ctx, tt.input)
+			assertErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestVirtualBookingMeetingLifecycle(t *testing.T) {
+	slot := newSlot(entities.SlotVirtual, time.Now().Add(24*time.Hour).Truncate(time.Minute), 2)
+	f := newSlotFixture(slot)
+	ctx := context.Background()
+
+	output, err := f.book.Execute(ctx, &BookSlotServiceInput{CandidateID: "c1", SlotID: slot.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := f.provider.Created()
+	if len(created) != 1 || output.InterviewLink != created[0].URL {
+		t.Fatalf("output = %+v, created = %+v, want the meeting link", output, created)
+	}
+
+	// Cancelling releases the seat and queues the meeting cancellation
+	if _, err := f.cancel.Execute(ctx, &CancelBookingServiceInput{CandidateID: "c1"}); err != nil {
+		t.Fatal(err)
+	}
+	if stored, _ := f.slots.GetByID(ctx, slot.ID); stored.Booked != 0 {
+		t.Errorf("booked = %d, want the seat released", stored.Booked)
+	}
+	candidate, _ := f.candidates.GetByID(ctx, "c1")
+	if candidate.InterviewSlotID != "" || candidate.InterviewLink != "" {
+		t.Errorf("candidate = %+v, want the booking cleared", candidate)
+	}
+	if len(f.provider.Cancelled()) != 0 {
+		t.Fatal("meeting cancelled inline, want it queued")
+	}
+	deliverMeetingCancels(t, f)
+	if cancelled := f.provider.Cancelled(); len(cancelled) != 1 || cancelled[0] != created[0].ID {
+		t.Errorf("cancelled = %v, want %s", cancelled, created[0].ID)
+	}
+
+	_, err = f.cancel.Execute(ctx, &CancelBookingServiceInput{CandidateID: "c1"})
+	assertErrorCode(t, err, "ERR_BOOKING_NOT_FOUND")
+}
+
+func TestDeleteBookedCandidate(t *testing.T) {
+	slot := newSlot(entities.SlotVirtual, time.Now().Add(24*time.Hour).Truncate(time.Minute), 2)
+	f := newSlotFixture(slot)
+	ctx := context.Background()
+
+	if _, err := f.book.Execute(ctx, &BookSlotServiceInput{CandidateID: "c1", SlotID: slot.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.delete.Execute(ctx, &DeleteServiceInput{ID: "c1"}); err != nil {
+		t.Fatal(err)
+	}
+	if candidate, _ := f.candidates.GetByID(ctx, "c1"); candidate != nil {
+		t.Errorf("candidate = %+v, want it deleted", candidate)
+	}
+	if stored, _ := f.slots.GetByID(ctx, slot.ID); stored.Booked != 0 {
+		t.Errorf("booked = %d, want the seat released", stored.Booked)
+	}
+	deliverMeetingCancels(t, f)
+	if len(f.provider.Cancelled()) != 1 {
+		t.Errorf("cancelled = %v, want the meeting of the deleted candidate", f.provider.Cancelled())
+	}
+}
+
+func deliverMeetingCancels(t *testing.T, f *slotFixture) {
+	t.Helper()
+	for _, record := range f.candidates.outbox {
+		if record.Type != entities.OutboxMeetingCancel {
+			continue
+		}
+		if err := f.meetings.Handle(context.Background(), record); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package commands
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+)
+
+// memoryCandidates is a CandidateRepository over a map. beforeWrite, if set,
+// runs before each conditional update, so tests can change the candidate
+// between the check and the write.
+type memoryCandidates struct {
+	mu          sync.Mutex
+	candidates  map[string]*entities.Candidate
+	outbox      []*entities.OutboxRecord
+	beforeWrite func(candidate *entities.Candidate)
+}
+
+func newMemoryCandidates(candidates ...*entities.Candidate) *memoryCandidates {
+	repo := &memoryCandidates{candidates: make(map[string]*entities.Candidate)}
+	for _, candidate := range candidates {
+		repo.candidates[candidate.ID] = candidate
+	}
+	return repo
+}
+
+func (r *memoryCandidates) GetByID(_ context.Context, id string) (*entities.Candidate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	candidate, ok := r.candidates[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *candidate
+	return &copied, nil
+}
+
+func (r *memoryCandidates) FindByPhone(context.Context, string) ([]*entities.Candidate, error) {
+	return nil, nil
+}
+
+func (r *memoryCandidates) FindByDocument(context.Context, string, string) ([]*entities.Candidate, error) {
+	return nil, nil
+}
+
+func (r *memoryCandidates) Create(_ context.Context, candidate *entities.Candidate, outbox ...*entities.OutboxRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.candidates[candidate.ID] = candidate
+	r.outbox = append(r.outbox, outbox...)
+	return nil
+}
+
+func (r *memoryCandidates) Update(ctx context.Context, id string, updates map[string]interface{}, outbox ...*entities.OutboxRecord) error {
+	return r.UpdateIf(ctx, id, repositories.UpdateGuard{}, updates, outbox...)
+}
+
+func (r *memoryCandidates) UpdateIf(_ context.Context, id string, guard repositories.UpdateGuard, updates map[string]interface{}, outbox ...*entities.OutboxRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	candidate, ok := r.candidates[id]
+	if ok && r.beforeWrite != nil {
+		r.beforeWrite(candidate)
+	}
+	if !ok || (guard.NotBooked && candidate.InterviewSlotID != "") || (guard.NotResponded && candidate.ResponseAt != "") {
+		return errorCustom.NewError(errorCustom.CONFLICT, "Candidate changed", "ERR_CANDIDATE_CHANGED")
+	}
+
+	for key, value := range updates {
+		switch key {
+		case "status":
+			candidate.Status = entities.CandidateStatus(value.(string))
+		case "sentAt":
+			candidate.SentAt = value.(string)
+		case "sentMessageId":
+			candidate.SentMessageID = value.(string)
+		case "interviewDate":
+			candidate.InterviewDate = value.(string)
+		case "interviewTime":
+			candidate.InterviewTime = value.(string)
+		case "interviewLink":
+			candidate.InterviewLink = value.(string)
+		case "interviewMeetingId":
+			candidate.InterviewMeetingID = value.(string)
+		case "response":
+			candidate.Response = value.(string)
+		case "responseAt":
+			candidate.ResponseAt = value.(string)
+		case "updatedAt":
+			candidate.UpdatedAt = value.(*string)
+		}
+	}
+	r.outbox = append(r.outbox, outbox...)
+	return nil
+}
+
+func (r *memoryCandidates) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if candidate, ok := r.candidates[id]; ok && candidate.InterviewSlotID != "" {
+		return errorCustom.NewError(errorCustom.CONFLICT, "Booking changed", "ERR_BOOKING_CHANGED")
+	}
+	delete(r.candidates, id)
+	return nil
+}
+
+// memoryMessages is a MessageRepository over a map. Fallback claims are
+// kept in claimed; released counts the claims undone.
+type memoryMessages struct {
+	mu       sync.Mutex
+	messages map[string]*entities.Message
+	claimed  map[string]bool
+	released int
+}
+
+func newMemoryMessages(messages ...*entities.Message) *memoryMessages {
+	repo := &memoryMessages{messages: make(map[string]*entities.Message), claimed: make(map[string]bool)}
+	for _, message := range messages {
+		repo.messages[message.ID] = message
+	}
+	return repo
+}
+
+func (r *memoryMessages) Create(_ context.Context, message *entities.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages[message.ID] = message
+	return nil
+}
+
+func (r *memoryMessages) GetByID(_ context.Context, id string) (*entities.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.messages[id], nil
+}
+
+func (r *memoryMessages) FindByCandidate(context.Context, string, int) ([]*entities.Message, error) {
+	return nil, nil
+}
+
+func (r *memoryMessages) RecordStatus(_ context.Context, id string, entry entities.MessageStatusEntry) (*entities.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	message, ok := r.messages[id]
+	if !ok {
+		return nil, nil
+	}
+	message.Status = entry.Status
+	copied := *message
+	return &copied, nil
+}
+
+func (r *memoryMessages) ClaimFallback(_ context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.claimed[id] {
+		return false, nil
+	}
+	r.claimed[id] = true
+	return true, nil
+}
+
+func (r *memoryMessages) CompleteFallback(_ context.Context, id, fallbackMessageID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages[id].FallbackMessageID = fallbackMessageID
+	return nil
+}
+
+func (r *memoryMessages) ReleaseFallback(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.claimed, id)
+	r.released++
+	return nil
+}
+
+// memorySlots is an InterviewSlotRepository over a map that books on the
+// candidates of memoryCandidates, with the same conditions as the store
+type memorySlots struct {
+	mu         sync.Mutex
+	slots      map[string]*entities.InterviewSlot
+	candidates *memoryCandidates
+}
+
+func newMemorySlots(candidates *memoryCandidates, slots ...*entities.InterviewSlot) *memorySlots {
+	repo := &memorySlots{slots: make(map[string]*entities.InterviewSlot), candidates: candidates}
+	for _, slot := range slots {
+		repo.slots[slot.ID] = slot
+	}
+	return repo
+}
+
+func (r *memorySlots) GetByID(_ context.Context, id string) (*entities.InterviewSlot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	slot, ok := r.slots[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *slot
+	return &copied, nil
+}
+
+func (r *memorySlots) Create(_ context.Context, slot *entities.InterviewSlot) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.slots[slot.ID]; ok {
+		return false, nil
+	}
+	r.slots[slot.ID] = slot
+	return true, nil
+}
+
+func (r *memorySlots) FindByStore(context.Context, string, time.Time, time.Time, int) ([]*entities.InterviewSlot, error) {
+	return nil, nil
+}
+
+func (r *memorySlots) Book(ctx context.Context, slotID, candidateID string, candidateUpdates map[string]interface{}, outbox ...*entities.OutboxRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	slot := r.slots[slotID]
+	if slot.Available() == 0 {
+		return errorCustom.NewError(errorCustom.CONFLICT, "Interview slot is full", "ERR_SLOT_FULL")
+	}
+	if err := r.candidates.UpdateIf(ctx, candidateID, repositories.UpdateGuard{NotBooked: true}, candidateUpdates, outbox...); err != nil {
+		return errorCustom.NewError(errorCustom.CONFLICT, "Candidate already has an interview booked", "ERR_CANDIDATE_ALREADY_BOOKED")
+	}
+	r.candidates.setSlot(candidateID, slotID)
+	slot.Booked++
+	return nil
+}
+
+func (r *memorySlots) Cancel(ctx context.Context, slotID, candidateID string, candidateUpdates map[string]interface{}, outbox ...*entities.OutboxRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.candidates.holds(candidateID, slotID) {
+		return errorCustom.NewError(errorCustom.CONFLICT, "Booking changed", "ERR_BOOKING_CHANGED")
+	}
+	if err := r.candidates.UpdateIf(ctx, candidateID, repositories.UpdateGuard{}, candidateUpdates, outbox...); err != nil {
+		return err
+	}
+	r.candidates.setSlot(candidateID, "")
+	r.slots[slotID].Booked--
+	return nil
+}
+
+func (r *memorySlots) DeleteBooked(ctx context.Context, slotID, candidateID string, outbox ...*entities.OutboxRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.candidates.holds(candidateID, slotID) {
+		return errorCustom.NewError(errorCustom.CONFLICT, "Booking changed", "ERR_BOOKING_CHANGED")
+	}
+	r.candidates.mu.Lock()
+	delete(r.candidates.candidates, candidateID)
+	r.candidates.outbox = append(r.candidates.outbox, outbox...)
+	r.candidates.mu.Unlock()
+	r.slots[slotID].Booked--
+	return nil
+}
+
+func (r *memoryCandidates) holds(id, slotID string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	candidate, ok := r.candidates[id]
+	return ok && candidate.InterviewSlotID == slotID
+}
+
+func (r *memoryCandidates) setSlot(id, slotID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	candidate := r.candidates[id]
+	candidate.InterviewSlotID = slotID
+	if slotID == "" {
+		candidate.InterviewMeetingID = ""
+	}
+}
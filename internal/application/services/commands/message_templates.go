@@ -0,0 +1,154 @@
+package commands
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/templates"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// Message template names
+const (
+	TemplateInvitation = "invitation"
+	TemplateInterview  = "interview"
+	TemplateReminder   = "reminder"
+	TemplateFollowUp   = "followup"
+)
+
+// whatsAppLanguage is the language the WhatsApp templates were approved in
+const whatsAppLanguage = "es"
+
+// MessageTemplate is a message to candidates. Text is a text/template over
+// the variables of MessageVariables; Requires lists the variables that must
+// not be empty. WhatsApp is the name of the approved template with the same
+// text, whose body parameters are rendered from Params in order.
+type MessageTemplate struct {
+	Text     string
+	Requires []string
+	WhatsApp string
+	Params   []string
+}
+
+// interviewParams fill "Hola {{1}}, ... KFC {{2}} ... {{3}} a las {{4}}.
+// Lugar: {{5}}", where the place is the meeting link of virtual interviews
+var interviewParams = []string{
+	"{{.FirstName}}",
+	"{{.Store}}",
+	"{{longDate .InterviewDate}}",
+	"{{.InterviewTime}}",
+	"{{if .InterviewLink}}{{.InterviewLink}}{{else}}tienda KFC {{.Store}}{{end}}",
+}
+
+var MessageTemplates = map[string]MessageTemplate{
+	TemplateInvitation: {
+		Text: "Hola {{.FirstName}}, te escribimos de KFC. Recibimos tu postulación" +
+			"{{if .Position}} para {{.Position}}{{end}} en la tienda {{.Store}}. " +
+			"¿Sigues interesado(a)? Responde SÍ para coordinar tu entrevista o NO si ya no deseas continuar.",
+		Requires: []string{"FirstName", "Store"},
+		WhatsApp: "kfc_invitacion",
+		Params:   []string{"{{.FirstName}}", "{{.Store}}"},
+	},
+	TemplateInterview: {
+		Text: "Hola {{.FirstName}}, tu entrevista en KFC {{.Store}} es el {{longDate .InterviewDate}} a las {{.InterviewTime}}." +
+			"{{if .InterviewLink}} Ingresa aquí: {{.InterviewLink}}{{end}} " +
+			"Responde SÍ para confirmar tu asistencia.",
+		Requires: []string{"FirstName", "Store", "InterviewDate", "InterviewTime"},
+		WhatsApp: "kfc_entrevista",
+		Params:   interviewParams,
+	},
+	TemplateReminder: {
+		Text: "Hola {{.FirstName}}, te recordamos tu entrevista en KFC {{.Store}} el {{longDate .InterviewDate}} " +
+			"a las {{.InterviewTime}}.{{if .InterviewLink}} Ingresa aquí: {{.InterviewLink}}{{end}} ¡Te esperamos!",
+		Requires: []string{"FirstName", "Store", "InterviewDate", "InterviewTime"},
+		WhatsApp: "kfc_recordatorio",
+		Params:   interviewParams,
+	},
+	TemplateFollowUp: {
+		Text: "Hola {{.FirstName}}, te volvemos a escribir de KFC por tu postulación en la tienda {{.Store}}. " +
+			"¿Sigues interesado(a)? Responde SÍ para coordinar tu entrevista o NO si ya no deseas continuar.",
+		Requires: []string{"FirstName", "Store"},
+		WhatsApp: "kfc_seguimiento",
+		Params:   []string{"{{.FirstName}}", "{{.Store}}"},
+	},
+}
+
+// messageEngine holds the text of every template under its name and each
+// WhatsApp parameter under name#index
+var messageEngine = templates.Must(templates.New(messageSources()))
+
+func messageSources() map[string]string {
+	sources := make(map[string]string)
+	for name, tmpl := range MessageTemplates {
+		sources[name] = tmpl.Text
+		for i, param := range tmpl.Params {
+			sources[paramSource(name, i)] = param
+		}
+	}
+	return sources
+}
+
+func paramSource(name string, index int) string {
+	return name + "#" + strconv.Itoa(index)
+}
+
+// MessageTemplateNames returns the names of the templates, sorted
+func MessageTemplateNames() []string {
+	names := make([]string, 0, len(MessageTemplates))
+	for name := range MessageTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// MessageVariables returns the variables of the templates for a candidate.
+// Every variable is present, empty when the candidate has no value.
+func MessageVariables(candidate *entities.Candidate) map[string]any {
+	return map[string]any{
+		"FirstName":     pkgIUtils.GetName(candidate.Names),
+		"Store":         candidate.Store,
+		"Position":      candidate.AppliedPosition,
+		"InterviewDate": candidate.InterviewDate,
+		"InterviewTime": candidate.InterviewTime,
+		"InterviewLink": candidate.InterviewLink,
+	}
+}
+
+// RenderMessage renders the template name for the candidate's phone. It
+// returns the variables that Requires but are empty, if any, instead.
+func RenderMessage(name string, candidate *entities.Candidate) (*ports.OutboundMessage, []string, error) {
+	tmpl := MessageTemplates[name]
+	variables := MessageVariables(candidate)
+
+	var missing []string
+	for _, variable := range tmpl.Requires {
+		if value, _ := variables[variable].(string); value == "" {
+			missing = append(missing, variable)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, missing, nil
+	}
+
+	text, err := messageEngine.Render(name, variables)
+	if err != nil {
+		return nil, nil, err
+	}
+	msg := &ports.OutboundMessage{To: candidate.Phone, Text: text}
+
+	if tmpl.WhatsApp != "" {
+		approved := &ports.ApprovedTemplate{Name: tmpl.WhatsApp, Language: whatsAppLanguage}
+		for i := range tmpl.Params {
+			param, err := messageEngine.Render(paramSource(name, i), variables)
+			if err != nil {
+				return nil, nil, err
+			}
+			approved.Params = append(approved.Params, param)
+		}
+		msg.Template = approved
+	}
+	return msg, nil, nil
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+)
+
+func TestRenderMessage(t *testing.T) {
+	candidate := &entities.Candidate{
+		Names:           "Ana Lucía",
+		Phone:           "+51987654321",
+		Store:           "Larcomar",
+		AppliedPosition: "Cajero",
+		InterviewDate:   "2026-11-02",
+		InterviewTime:   "10:00",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		text     []string
+		params   []string
+	}{
+		{
+			name:     "invitation",
+			template: TemplateInvitation,
+			text:     []string{"Hola Ana,", "para Cajero en la tienda Larcomar."},
+			params:   []string{"Ana", "Larcomar"},
+		},
+		{
+			name:     "interview in store",
+			template: TemplateInterview,
+			text:     []string{"KFC Larcomar es el lunes 2 de noviembre a las 10:00."},
+			params:   []string{"Ana", "Larcomar", "lunes 2 de noviembre", "10:00", "tienda KFC Larcomar"},
+		},
+		{
+			name:     "followup",
+			template: TemplateFollowUp,
+			text:     []string{"te volvemos a escribir", "tienda Larcomar"},
+			params:   []string{"Ana", "Larcomar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, missing, err := RenderMessage(tt.template, candidate)
+			if err != nil || missing != nil {
+				t.Fatalf("RenderMessage() missing = %v, err = %v", missing, err)
+			}
+			if msg.To != candidate.Phone {
+				t.Errorf("To = %q, want %q", msg.To, candidate.Phone)
+			}
+			for _, text := range tt.text {
+				if !strings.Contains(msg.Text, text) {
+					t.Errorf("Text = %q, want it to contain %q", msg.Text, text)
+				}
+			}
+			if msg.Template == nil || msg.Template.Name != MessageTemplates[tt.template].WhatsApp || msg.Template.Language != whatsAppLanguage {
+				t.Fatalf("Template = %+v", msg.Template)
+			}
+			if !reflect.DeepEqual(msg.Template.Params, tt.params) {
+				t.Errorf("Params = %v, want %v", msg.Template.Params, tt.params)
+			}
+		})
+	}
+}
+
+func TestRenderMessageVirtualInterview(t *testing.T) {
+	candidate := &entities.Candidate{
+		Names:         "Ana",
+		Store:         "Larcomar",
+		InterviewDate: "2026-11-02",
+		InterviewTime: "10:00",
+		InterviewLink: "https://meet.example.com/123",
+	}
+
+	msg, _, err := RenderMessage(TemplateReminder, candidate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(msg.Text, "Ingresa aquí: https://meet.example.com/123") {
+		t.Errorf("Text = %q, want the meeting link", msg.Text)
+	}
+	if place := msg.Template.Params[4]; place != candidate.InterviewLink {
+		t.Errorf("place = %q, want the meeting link", place)
+	}
+}
+
+func TestRenderMessageMissingVariables(t *testing.T) {
+	tests := []struct {
+		name      string
+		template  string
+		candidate *entities.Candidate
+		missing   []string
+	}{
+		{"invitation without store", TemplateInvitation, &entities.Candidate{Names: "Ana"}, []string{"Store"}},
+		{"invitation without position", TemplateInvitation, &entities.Candidate{Names: "Ana", Store: "Larcomar"}, nil},
+		{"interview without schedule", TemplateInterview, &entities.Candidate{Names: "Ana", Store: "Larcomar"}, []string{"InterviewDate", "InterviewTime"}},
+		{"reminder without anything", TemplateReminder, &entities.Candidate{}, []string{"FirstName", "Store", "InterviewDate", "InterviewTime"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, missing, err := RenderMessage(tt.template, tt.candidate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(missing, tt.missing) {
+				t.Fatalf("missing = %v, want %v", missing, tt.missing)
+			}
+			if (msg == nil) != (tt.missing != nil) {
+				t.Errorf("msg = %+v, want a message only without missing variables", msg)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// messenger sends template messages to candidates and records them: the
+// message, for its delivery status, and the candidate's last message
+type messenger struct {
+	config              *config.Config
+	logger              logger.Logger
+	candidateRepository repositories.CandidateRepository
+	messageRepository   repositories.MessageRepository
+	sheetSync           *SheetSync
+	followUps           *FollowUps
+}
+
+// sentMessage is a message accepted by the provider and recorded
+type sentMessage struct {
+	ID      string
+	Channel string
+	Text    string
+	SentAt  string
+}
+
+// sendOptions tells what a message is sent for. FallbackOf is the failed
+// message it replaces, if any; FollowUp is the number of the follow-up it is,
+// 0 for other messages.
+type sendOptions struct {
+	FallbackOf string
+	FollowUp   int
+}
+
+// send renders template for candidate and sends it with sender. Invitations
+// and follow-ups schedule the check of the no-response policy; fallbacks are
+// covered by the check of the message they replace.
+func (m *messenger) send(ctx context.Context, sender ports.MessageSender, candidate *entities.Candidate, template string, opts sendOptions) (*sentMessage, error) {
+	if candidate.Phone == "" {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Candidate has no phone", "ERR_CANDIDATE_WITHOUT_PHONE")
+	}
+
+	msg, missing, err := RenderMessage(template, candidate)
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to render message", "ERR_TEMPLATE_RENDER")
+	}
+	if len(missing) > 0 {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Candidate lacks "+strings.Join(missing, ", ")+" for template "+template, "ERR_TEMPLATE_DATA_MISSING",
+			map[string]any{"missing": missing})
+	}
+
+	sent, err := sender.Send(ctx, *msg)
+	if err != nil {
+		return nil, err
+	}
+
+	message := entities.NewMessage(sent.ID, candidate.ID, sent.Channel, template, time.Now())
+	message.FallbackOf = opts.FallbackOf
+
+	sentAt := pkgIUtils.NowDateTime(m.config.TIME_ZONE)
+	updatedBy := constants.SYSTEM_USER
+	updates := map[string]interface{}{
+		"sentAt":        sentAt,
+		"sentMessageId": sent.ID,
+		"updatedAt":     &sentAt,
+		"updatedBy":     &updatedBy,
+	}
+
+	// The message is already out: a failure from here on is logged with its
+	// ID, so it can be matched by hand, and is not retried by sending again
+	err = m.messageRepository.Create(ctx, message)
+	if err == nil {
+		var outbox []*entities.OutboxRecord
+		outbox, err = m.sheetSync.Outbox(candidate, updates)
+		if err == nil && opts.FallbackOf == "" {
+			var followUp []*entities.OutboxRecord
+			followUp, err = m.followUps.Outbox(candidate, template, sent.ID, opts.FollowUp+1, time.Now())
+			outbox = append(outbox, followUp...)
+		}
+		if err == nil {
+			// A candidate deleted meanwhile is not written back as a partial item
+			err = m.candidateRepository.UpdateIf(ctx, candidate.ID, repositories.UpdateGuard{}, updates, outbox...)
+		}
+	}
+	if err != nil {
+		m.logger.Error(map[string]any{
+			"msg":         "Message sent but not recorded",
+			"candidateId": candidate.ID,
+			"messageId":   sent.ID,
+			"error":       err.Error(),
+		})
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Message sent but not recorded", "ERR_MESSAGE_NOT_RECORDED",
+			map[string]any{"messageId": sent.ID})
+	}
+
+	m.logger.Info(map[string]any{
+		"msg":         "Message sent",
+		"candidateId": candidate.ID,
+		"messageId":   sent.ID,
+		"channel":     sent.Channel,
+		"template":    template,
+		"fallbackOf":  opts.FallbackOf,
+		"followUp":    opts.FollowUp,
+	})
+	return &sentMessage{
+		ID:      sent.ID,
+		Channel: sent.Channel,
+		Text:    msg.Text,
+		SentAt:  sentAt,
+	}, nil
+}
@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// FollowUpAction is what a follow-up check did
+type FollowUpAction string
+
+const (
+	FollowUpSent       FollowUpAction = "FOLLOWED_UP"
+	FollowUpNoResponse FollowUpAction = "NO_RESPONSE"
+	FollowUpSkipped    FollowUpAction = "SKIPPED"
+)
+
+// ProcessFollowUpServiceOutput reports the action taken; Reason explains
+// skipped checks
+type ProcessFollowUpServiceOutput struct {
+	CandidateID string         `json:"candidateId"`
+	Attempt     int            `json:"attempt"`
+	Action      FollowUpAction `json:"action"`
+	MessageID   string         `json:"messageId,omitempty"`
+	Reason      string         `json:"reason,omitempty"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// ProcessFollowUpService runs the checks scheduled by FollowUps
+type ProcessFollowUpService struct {
+	logger              logger.Logger
+	candidateRepository repositories.CandidateRepository
+	messageRepository   repositories.MessageRepository
+	sender              ports.MessageSender
+	followUps           *FollowUps
+	sheetSync           *SheetSync
+	dispatcher          ports.EventDispatcher
+	messenger           *messenger
+	timeZone            string
+}
+
+// ProcessFollowUpServiceConfig holds the configuration dependencies for ProcessFollowUpService
+type ProcessFollowUpServiceConfig struct {
+	Config              *config.Config
+	Logger              logger.Logger
+	CandidateRepository repositories.CandidateRepository
+	MessageRepository   repositories.MessageRepository
+	Sender              ports.MessageSender
+	FollowUps           *FollowUps
+	// SheetSync is optional; without it no sheet update is queued
+	SheetSync *SheetSync
+	// Dispatcher is optional; it receives CandidateStatusChanged
+	Dispatcher ports.EventDispatcher
+}
+
+// NewProcessFollowUpService creates a new instance of ProcessFollowUpService with provided configuration
+func NewProcessFollowUpService(cfg ProcessFollowUpServiceConfig) *ProcessFollowUpService {
+	return &ProcessFollowUpService{
+		logger:              cfg.Logger,
+		candidateRepository: cfg.CandidateRepository,
+		messageRepository:   cfg.MessageRepository,
+		sender:              cfg.Sender,
+		followUps:           cfg.FollowUps,
+		sheetSync:           cfg.SheetSync,
+		dispatcher:          cfg.Dispatcher,
+		timeZone:            cfg.Config.TIME_ZONE,
+		messenger: &messenger{
+			config:              cfg.Config,
+			logger:              cfg.Logger,
+			candidateRepository: cfg.CandidateRepository,
+			messageRepository:   cfg.MessageRepository,
+			sheetSync:           cfg.SheetSync,
+			followUps:           cfg.FollowUps,
+		},
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute checks whether the message of the input is still unanswered and
+// the last one sent to the candidate, so a reply or a newer message cancels
+// the chain. It then sends follow-up number Attempt or, once MaxAttempts
+// were sent, moves the candidate to NO_RESPONSE.
+// An error is returned only if the check can be retried: follow-ups that
+// cannot be sent, or were sent but not recorded, are skipped.
+func (svc *ProcessFollowUpService) Execute(ctx context.Context, input *entities.FollowUpPayload) (*ProcessFollowUpServiceOutput, error) {
+	output := &ProcessFollowUpServiceOutput{CandidateID: input.CandidateID, Attempt: input.Attempt, Action: FollowUpSkipped}
+
+	candidate, err := svc.candidateRepository.GetByID(ctx, input.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil || candidate.Deleted {
+		return svc.skip(output, "candidate not found"), nil
+	}
+	if !candidate.AwaitingResponse() {
+		return svc.skip(output, "candidate responded"), nil
+	}
+	current, err := svc.isLastMessage(ctx, candidate, input.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if !current {
+		return svc.skip(output, "newer message sent"), nil
+	}
+
+	if input.Attempt <= svc.followUps.maxAttempts {
+		sent, err := svc.messenger.send(ctx, svc.sender, candidate, TemplateFollowUp, sendOptions{FollowUp: input.Attempt})
+		if err != nil {
+			var customErr *errorCustom.CustomError
+			if isPermanentOutboxError(err) || (errors.As(err, &customErr) && customErr.ErrorCode == "ERR_MESSAGE_NOT_RECORDED") {
+				svc.logger.Error(pkgIUtils.NewSafeError(err, "Error in ProcessFollowUpService.Execute: Follow-up not sent to "+candidate.ID))
+				return svc.skip(output, "follow-up not sent"), nil
+			}
+			return nil, err
+		}
+		output.Action = FollowUpSent
+		output.MessageID = sent.ID
+		return output, nil
+	}
+
+	if candidate.Status == entities.StatusNoResponse {
+		return svc.skip(output, "candidate already marked"), nil
+	}
+	err = svc.markNoResponse(ctx, candidate)
+	var customErr *errorCustom.CustomError
+	if errors.As(err, &customErr) && customErr.ErrorCode == "ERR_CANDIDATE_CHANGED" {
+		return svc.skip(output, "candidate responded or was deleted"), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	output.Action = FollowUpNoResponse
+	return output, nil
+}
+
+// isLastMessage reports whether messageID, or the fallback that replaced it,
+// is the last message sent to the candidate
+func (svc *ProcessFollowUpService) isLastMessage(ctx context.Context, candidate *entities.Candidate, messageID string) (bool, error) {
+	if candidate.SentMessageID == messageID {
+		return true, nil
+	}
+	if candidate.SentMessageID == "" {
+		return false, nil
+	}
+	last, err := svc.messageRepository.GetByID(ctx, candidate.SentMessageID)
+	if err != nil {
+		return false, err
+	}
+	return last != nil && last.FallbackOf == messageID, nil
+}
+
+// markNoResponse moves the candidate to NO_RESPONSE. The write requires the
+// candidate to exist and have no reply, so a reply recorded after the check
+// is kept and fails it with ERR_CANDIDATE_CHANGED.
+func (svc *ProcessFollowUpService) markNoResponse(ctx context.Context, candidate *entities.Candidate) error {
+	updatedAt := pkgIUtils.NowDateTime(svc.timeZone)
+	updatedBy := constants.SYSTEM_USER
+	updates := map[string]interface{}{
+		"status":    string(entities.StatusNoResponse),
+		"updatedAt": &updatedAt,
+		"updatedBy": &updatedBy,
+	}
+
+	outbox, err := svc.sheetSync.Outbox(candidate, updates)
+	if err != nil {
+		return err
+	}
+	guard := repositories.UpdateGuard{NotResponded: true}
+	if err := svc.candidateRepository.UpdateIf(ctx, candidate.ID, guard, updates, outbox...); err != nil {
+		return err
+	}
+
+	before := *candidate
+	after := *candidate
+	after.Status = entities.StatusNoResponse
+	after.UpdatedAt = &updatedAt
+	dispatch(ctx, svc.dispatcher, &events.CandidateStatusChanged{
+		Metadata: newEventMetadata(candidate),
+		From:     before.Status,
+		To:       after.Status,
+		Before:   events.NewCandidateImage(&before),
+		After:    events.NewCandidateImage(&after),
+	})
+
+	svc.logger.Info(map[string]any{
+		"msg":         "Candidate marked as no response",
+		"candidateId": candidate.ID,
+	})
+	return nil
+}
+
+func (svc *ProcessFollowUpService) skip(output *ProcessFollowUpServiceOutput, reason string) *ProcessFollowUpServiceOutput {
+	svc.logger.Info(map[string]any{
+		"msg":         "Skipping follow-up",
+		"candidateId": output.CandidateID,
+		"attempt":     output.Attempt,
+		"reason":      reason,
+	})
+	output.Reason = reason
+	return output
+}
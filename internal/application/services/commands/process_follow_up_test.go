@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/internal/infrastructure/adapters"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+)
+
+type followUpFixture struct {
+	candidates *memoryCandidates
+	messages   *memoryMessages
+	sender     *adapters.MemoryMessageSender
+	scheduler  *adapters.MemoryScheduler
+	followUps  *FollowUps
+	service    *ProcessFollowUpService
+}
+
+func newFollowUpFixture(candidates *memoryCandidates, messages *memoryMessages) *followUpFixture {
+	log := pkgILogger.NewZeroLogLogger()
+	f := &followUpFixture{
+		candidates: candidates,
+		messages:   messages,
+		sender:     adapters.NewMemoryMessageSender(ports.ChannelWhatsApp),
+		scheduler:  adapters.NewMemoryScheduler(),
+	}
+	f.followUps = NewFollowUps(FollowUpsConfig{
+		Logger:      log,
+		Scheduler:   f.scheduler,
+		After:       24 * time.Hour,
+		MaxAttempts: 2,
+		TargetArn:   "arn:target",
+		RoleArn:     "arn:role",
+	})
+	f.service = NewProcessFollowUpService(ProcessFollowUpServiceConfig{
+		Config:              &config.Config{TIME_ZONE: constants.DEFAULT_TIME_ZONE},
+		Logger:              log,
+		CandidateRepository: candidates,
+		MessageRepository:   messages,
+		Sender:              f.sender,
+		FollowUps:           f.followUps,
+	})
+	return f
+}
+
+func pendingCandidate() *entities.Candidate {
+	return &entities.Candidate{
+		ID:            "c1",
+		Names:         "Ana",
+		Phone:         "+51987654321",
+		Store:         "Larcomar",
+		Status:        entities.StatusPending,
+		SentMessageID: "m1",
+	}
+}
+
+func TestProcessFollowUpSkips(t *testing.T) {
+	tests := []struct {
+		name      string
+		candidate func(c *entities.Candidate)
+		messages  []*entities.Message
+		attempt   int
+		reason    string
+	}{
+		{
+			name:      "candidate not found",
+			candidate: nil,
+			attempt:   1,
+			reason:    "candidate not found",
+		},
+		{
+			name:      "candidate deleted",
+			candidate: func(c *entities.Candidate) { c.Deleted = true },
+			attempt:   1,
+			reason:    "candidate not found",
+		},
+		{
+			name:      "candidate responded",
+			candidate: func(c *entities.Candidate) { c.Status = entities.StatusResponded },
+			attempt:   1,
+			reason:    "candidate responded",
+		},
+		{
+			name:      "newer message sent",
+			candidate: func(c *entities.Candidate) { c.SentMessageID = "m2" },
+			messages:  []*entities.Message{{ID: "m2", CandidateID: "c1"}},
+			attempt:   1,
+			reason:    "newer message sent",
+		},
+		{
+			name:      "follow-up cannot be rendered",
+			candidate: func(c *entities.Candidate) { c.Store = "" },
+			attempt:   1,
+			reason:    "follow-up not sent",
+		},
+		{
+			name:      "candidate already marked",
+			candidate: func(c *entities.Candidate) { c.Status = entities.StatusNoResponse },
+			attempt:   3,
+			reason:    "candidate already marked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates := newMemoryCandidates()
+			if tt.candidate != nil {
+				candidate := pendingCandidate()
+				tt.candidate(candidate)
+				candidates = newMemoryCandidates(candidate)
+			}
+			f := newFollowUpFixture(candidates, newMemoryMessages(tt.messages...))
+
+			output, err := f.service.Execute(context.Background(), &entities.FollowUpPayload{CandidateID: "c1", MessageID: "m1", Attempt: tt.attempt})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output.Action != FollowUpSkipped || output.Reason != tt.reason {
+				t.Fatalf("output = %+v, want SKIPPED %q", output, tt.reason)
+			}
+			if sent := f.sender.Sent(); len(sent) != 0 {
+				t.Errorf("sent %d messages, want none", len(sent))
+			}
+		})
+	}
+}
+
+func TestProcessFollowUpSendsAndSchedulesNext(t *testing.T) {
+	f := newFollowUpFixture(newMemoryCandidates(pendingCandidate()), newMemoryMessages())
+
+	output, err := f.service.Execute(context.Background(), &entities.FollowUpPayload{CandidateID: "c1", MessageID: "m1", Attempt: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := f.sender.Sent()
+	if output.Action != FollowUpSent || len(sent) != 1 || output.MessageID != sent[0].ID {
+		t.Fatalf("output = %+v, sent = %+v", output, sent)
+	}
+	if sent[0].Message.Template == nil || sent[0].Message.Template.Name != MessageTemplates[TemplateFollowUp].WhatsApp {
+		t.Errorf("sent template = %+v, want the follow-up", sent[0].Message.Template)
+	}
+
+	candidate, _ := f.candidates.GetByID(context.Background(), "c1")
+	if candidate.SentMessageID != output.MessageID {
+		t.Errorf("SentMessageID = %q, want %q", candidate.SentMessageID, output.MessageID)
+	}
+
+	// The next check is queued with the follow-up and scheduled once
+	if len(f.candidates.outbox) != 1 || f.candidates.outbox[0].Type != entities.OutboxFollowUpSchedule {
+		t.Fatalf("outbox = %+v, want one follow-up schedule", f.candidates.outbox)
+	}
+	for i := 0; i < 2; i++ {
+		if err := f.followUps.Handle(context.Background(), f.candidates.outbox[0]); err != nil {
+			t.Fatalf("Handle() delivery %d: %v", i+1, err)
+		}
+	}
+	due := f.scheduler.Due(time.Now().Add(25 * time.Hour))
+	if len(due) != 1 {
+		t.Fatalf("due = %+v, want one schedule", due)
+	}
+	next := due[0].Input.(entities.FollowUpPayload)
+	if next.Attempt != 2 || next.MessageID != output.MessageID {
+		t.Errorf("next check = %+v, want attempt 2 of %s", next, output.MessageID)
+	}
+}
+
+func TestProcessFollowUpAcceptsFallbackOfMessage(t *testing.T) {
+	candidate := pendingCandidate()
+	candidate.SentMessageID = "sms1"
+	f := newFollowUpFixture(newMemoryCandidates(candidate), newMemoryMessages(&entities.Message{ID: "sms1", CandidateID: "c1", FallbackOf: "m1"}))
+
+	output, err := f.service.Execute(context.Background(), &entities.FollowUpPayload{CandidateID: "c1", MessageID: "m1", Attempt: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.Action != FollowUpSent {
+		t.Fatalf("output = %+v, want FOLLOWED_UP", output)
+	}
+}
+
+func TestProcessFollowUpMarksNoResponse(t *testing.T) {
+	f := newFollowUpFixture(newMemoryCandidates(pendingCandidate()), newMemoryMessages())
+
+	output, err := f.service.Execute(context.Background(), &entities.FollowUpPayload{CandidateID: "c1", MessageID: "m1", Attempt: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.Action != FollowUpNoResponse {
+		t.Fatalf("output = %+v, want NO_RESPONSE", output)
+	}
+	candidate, _ := f.candidates.GetByID(context.Background(), "c1")
+	if candidate.Status != entities.StatusNoResponse {
+		t.Errorf("status = %s, want NO_RESPONSE", candidate.Status)
+	}
+	if sent := f.sender.Sent(); len(sent) != 0 {
+		t.Errorf("sent %d messages, want none", len(sent))
+	}
+}
+
+func TestProcessFollowUpKeepsReplyRecordedDuringCheck(t *testing.T) {
+	candidates := newMemoryCandidates(pendingCandidate())
+	candidates.beforeWrite = func(c *entities.Candidate) {
+		c.Status = entities.StatusResponded
+		c.ResponseAt = "2026-10-19 10:00:00"
+	}
+	f := newFollowUpFixture(candidates, newMemoryMessages())
+
+	output, err := f.service.Execute(context.Background(), &entities.FollowUpPayload{CandidateID: "c1", MessageID: "m1", Attempt: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.Action != FollowUpSkipped || output.Reason != "candidate responded or was deleted" {
+		t.Fatalf("output = %+v, want SKIPPED", output)
+	}
+	candidate, _ := candidates.GetByID(context.Background(), "c1")
+	if candidate.Status != entities.StatusResponded {
+		t.Errorf("status = %s, want the reply kept", candidate.Status)
+	}
+}
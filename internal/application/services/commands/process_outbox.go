@@ -0,0 +1,210 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// ProcessOutboxServiceInput sets the time used to select due records; the
+// zero value means now
+type ProcessOutboxServiceInput struct {
+	Now time.Time
+}
+
+// ProcessOutboxServiceOutput summarizes a run. Failed lists the records that
+// exhausted their attempts in this run.
+type ProcessOutboxServiceOutput struct {
+	Delivered int      `json:"delivered"`
+	Retried   int      `json:"retried"`
+	Skipped   int      `json:"skipped"`
+	Failed    []string `json:"failed"`
+}
+
+// OutboxHandler delivers the records of one type. Errors of type BAD_REQUEST,
+// NOT_FOUND or UNPROCESSABLE_ENTITY are permanent and are not retried.
+type OutboxHandler interface {
+	Handle(ctx context.Context, record *entities.OutboxRecord) error
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+const (
+	defaultOutboxMaxAttempts = 8
+	defaultOutboxBatchSize   = 25
+	defaultOutboxBaseDelay   = 30 * time.Second
+	defaultOutboxMaxDelay    = time.Hour
+	defaultOutboxLease       = 5 * time.Minute
+)
+
+// ProcessOutboxService delivers due outbox records with retries and backoff
+type ProcessOutboxService struct {
+	config           *config.Config
+	logger           logger.Logger
+	outboxRepository repositories.OutboxRepository
+	handlers         map[string]OutboxHandler
+	maxAttempts      int
+	batchSize        int
+	baseDelay        time.Duration
+	maxDelay         time.Duration
+	lease            time.Duration
+}
+
+// ProcessOutboxServiceConfig holds the configuration dependencies for
+// ProcessOutboxService. Zero values take the defaults: 8 attempts, batches
+// of 25, backoff from 30s up to 1h and a 5 minute lease.
+type ProcessOutboxServiceConfig struct {
+	Config           *config.Config
+	Logger           logger.Logger
+	OutboxRepository repositories.OutboxRepository
+	// Handlers are keyed by record type
+	Handlers    map[string]OutboxHandler
+	MaxAttempts int
+	BatchSize   int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Lease hides a claimed record from other runs while it is delivered
+	Lease time.Duration
+}
+
+// NewProcessOutboxService creates a new instance of ProcessOutboxService with provided configuration
+func NewProcessOutboxService(cfg ProcessOutboxServiceConfig) *ProcessOutboxService {
+	svc := &ProcessOutboxService{
+		config:           cfg.Config,
+		logger:           cfg.Logger,
+		outboxRepository: cfg.OutboxRepository,
+		handlers:         cfg.Handlers,
+		maxAttempts:      cfg.MaxAttempts,
+		batchSize:        cfg.BatchSize,
+		baseDelay:        cfg.BaseDelay,
+		maxDelay:         cfg.MaxDelay,
+		lease:            cfg.Lease,
+	}
+	if svc.maxAttempts <= 0 {
+		svc.maxAttempts = defaultOutboxMaxAttempts
+	}
+	if svc.batchSize <= 0 {
+		svc.batchSize = defaultOutboxBatchSize
+	}
+	if svc.baseDelay <= 0 {
+		svc.baseDelay = defaultOutboxBaseDelay
+	}
+	if svc.maxDelay <= 0 {
+		svc.maxDelay = defaultOutboxMaxDelay
+	}
+	if svc.lease <= 0 {
+		svc.lease = defaultOutboxLease
+	}
+	return svc
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute delivers one batch of due records. Each record is claimed before
+// delivery, so concurrent runs do not deliver it twice; a run that dies
+// mid-delivery leaves the record to be retried when the lease expires.
+// Returns error only if the batch cannot be read.
+func (svc *ProcessOutboxService) Execute(ctx context.Context, input ProcessOutboxServiceInput) (*ProcessOutboxServiceOutput, error) {
+	now := input.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	records, err := svc.outboxRepository.FindDue(ctx, now, svc.batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ProcessOutboxServiceOutput{Failed: make([]string, 0)}
+	for _, record := range records {
+		claimed, err := svc.outboxRepository.Claim(ctx, record, now.Add(svc.lease))
+		if err != nil {
+			svc.logger.Error(pkgIUtils.NewSafeError(err, "Error in ProcessOutboxService.Execute: Failed to claim record "+record.ID))
+		}
+		if err != nil || !claimed {
+			output.Skipped++
+			continue
+		}
+
+		deliverErr := svc.deliver(ctx, record)
+		switch {
+		case deliverErr == nil:
+			err = svc.outboxRepository.MarkDelivered(ctx, record.ID, time.Now())
+			output.Delivered++
+
+		case isPermanentOutboxError(deliverErr) || record.Attempts >= svc.maxAttempts:
+			err = svc.outboxRepository.MarkFailed(ctx, record.ID, deliverErr.Error())
+			output.Failed = append(output.Failed, record.ID)
+			svc.logger.Error(map[string]any{
+				"msg":         "Outbox record failed permanently",
+				"outboxId":    record.ID,
+				"type":        record.Type,
+				"aggregateId": record.AggregateID,
+				"attempts":    record.Attempts,
+				"error":       deliverErr.Error(),
+			})
+
+		default:
+			err = svc.outboxRepository.MarkRetry(ctx, record.ID, time.Now().Add(svc.backoff(record.Attempts)), deliverErr.Error())
+			output.Retried++
+		}
+
+		// The lease retries the record if its state could not be saved
+		if err != nil {
+			svc.logger.Error(pkgIUtils.NewSafeError(err, "Error in ProcessOutboxService.Execute: Failed to save state of record "+record.ID))
+		}
+	}
+
+	return output, nil
+}
+
+func (svc *ProcessOutboxService) deliver(ctx context.Context, record *entities.OutboxRecord) error {
+	handler, ok := svc.handlers[record.Type]
+	if !ok {
+		return errorCustom.NewError(errorCustom.UNPROCESSABLE_ENTITY, "No handler for outbox record type "+record.Type, "OUTBOX_UNKNOWN_TYPE")
+	}
+	return handler.Handle(ctx, record)
+}
+
+// backoff doubles the delay on each attempt up to maxDelay, with jitter in
+// the upper half so records failing together do not retry together
+func (svc *ProcessOutboxService) backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := svc.maxDelay
+	if attempts < 32 {
+		if d := svc.baseDelay << (attempts - 1); d > 0 && d < svc.maxDelay {
+			delay = d
+		}
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+func isPermanentOutboxError(err error) bool {
+	var customErr *errorCustom.CustomError
+	if !errors.As(err, &customErr) {
+		return false
+	}
+	switch customErr.ErrorType {
+	case errorCustom.BAD_REQUEST, errorCustom.NOT_FOUND, errorCustom.UNPROCESSABLE_ENTITY:
+		return true
+	}
+	return false
+}
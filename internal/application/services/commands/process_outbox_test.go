@@ -0,0 +1,183 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+)
+
+// memoryOutbox is an OutboxRepository over a map. Records listed in taken
+// were claimed by another processor.
+type memoryOutbox struct {
+	mu      sync.Mutex
+	records map[string]*entities.OutboxRecord
+	taken   map[string]bool
+	retryAt map[string]time.Time
+}
+
+func newMemoryOutbox(records ...*entities.OutboxRecord) *memoryOutbox {
+	repo := &memoryOutbox{records: make(map[string]*entities.OutboxRecord), taken: make(map[string]bool), retryAt: make(map[string]time.Time)}
+	for _, record := range records {
+		repo.records[record.ID] = record
+	}
+	return repo
+}
+
+func (r *memoryOutbox) GetByID(_ context.Context, id string) (*entities.OutboxRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.records[id], nil
+}
+
+func (r *memoryOutbox) FindDue(_ context.Context, now time.Time, limit int) ([]*entities.OutboxRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	due := make([]*entities.OutboxRecord, 0)
+	for _, record := range r.records {
+		if record.Status == entities.OutboxPending {
+			due = append(due, record)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryOutbox) FindFailed(context.Context, int) ([]*entities.OutboxRecord, error) {
+	return nil, nil
+}
+
+func (r *memoryOutbox) Claim(_ context.Context, record *entities.OutboxRecord, _ time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.taken[record.ID] {
+		return false, nil
+	}
+	r.taken[record.ID] = true
+	record.Attempts++
+	return true, nil
+}
+
+func (r *memoryOutbox) MarkDelivered(_ context.Context, id string, _ time.Time) error {
+	return r.setStatus(id, entities.OutboxDelivered, "")
+}
+
+func (r *memoryOutbox) MarkRetry(_ context.Context, id string, nextAttemptAt time.Time, lastError string) error {
+	r.mu.Lock()
+	r.retryAt[id] = nextAttemptAt
+	r.mu.Unlock()
+	return r.setStatus(id, entities.OutboxPending, lastError)
+}
+
+func (r *memoryOutbox) MarkFailed(_ context.Context, id string, lastError string) error {
+	return r.setStatus(id, entities.OutboxFailed, lastError)
+}
+
+func (r *memoryOutbox) Requeue(_ context.Context, id string, _ time.Time) error {
+	return r.setStatus(id, entities.OutboxPending, "")
+}
+
+func (r *memoryOutbox) setStatus(id string, status entities.OutboxStatus, lastError string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[id].Status = status
+	r.records[id].LastError = lastError
+	return nil
+}
+
+// outboxHandlerFunc adapts a function to OutboxHandler
+type outboxHandlerFunc func(ctx context.Context, record *entities.OutboxRecord) error
+
+func (f outboxHandlerFunc) Handle(ctx context.Context, record *entities.OutboxRecord) error {
+	return f(ctx, record)
+}
+
+func newOutboxRecord(t *testing.T, id, recordType string, attempts int) *entities.OutboxRecord {
+	t.Helper()
+	record, err := entities.NewOutboxRecord(id, recordType, "c1", map[string]string{}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	record.Attempts = attempts
+	return record
+}
+
+func TestProcessOutbox(t *testing.T) {
+	outbox := newMemoryOutbox(
+		newOutboxRecord(t, "ok", "test.ok", 0),
+		newOutboxRecord(t, "retry", "test.retry", 0),
+		newOutboxRecord(t, "exhausted", "test.retry", 2),
+		newOutboxRecord(t, "permanent", "test.permanent", 0),
+		newOutboxRecord(t, "unknown", "test.unknown", 0),
+		newOutboxRecord(t, "taken", "test.ok", 0),
+	)
+	outbox.taken["taken"] = true
+
+	svc := NewProcessOutboxService(ProcessOutboxServiceConfig{
+		Logger:           pkgILogger.NewZeroLogLogger(),
+		OutboxRepository: outbox,
+		MaxAttempts:      3,
+		Handlers: map[string]OutboxHandler{
+			"test.ok": outboxHandlerFunc(func(context.Context, *entities.OutboxRecord) error { return nil }),
+			"test.retry": outboxHandlerFunc(func(context.Context, *entities.OutboxRecord) error {
+				return errors.New("sheet unavailable")
+			}),
+			"test.permanent": outboxHandlerFunc(func(context.Context, *entities.OutboxRecord) error {
+				return errorCustom.NewError(errorCustom.NOT_FOUND, "Sheet row not found", "ERR_SHEET_ROW_NOT_FOUND")
+			}),
+		},
+	})
+
+	start := time.Now()
+	output, err := svc.Execute(context.Background(), ProcessOutboxServiceInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.Delivered != 1 || output.Retried != 1 || output.Skipped != 1 || len(output.Failed) != 3 {
+		t.Fatalf("output = %+v", output)
+	}
+
+	want := map[string]entities.OutboxStatus{
+		"ok":        entities.OutboxDelivered,
+		"retry":     entities.OutboxPending,
+		"exhausted": entities.OutboxFailed,
+		"permanent": entities.OutboxFailed,
+		"unknown":   entities.OutboxFailed,
+		"taken":     entities.OutboxPending,
+	}
+	for id, status := range want {
+		if got := outbox.records[id].Status; got != status {
+			t.Errorf("%s: status = %s, want %s", id, got, status)
+		}
+	}
+	if next := outbox.retryAt["retry"]; next.Before(start.Add(defaultOutboxBaseDelay/2)) || next.After(time.Now().Add(defaultOutboxBaseDelay)) {
+		t.Errorf("retry at %v, want within the first backoff", next)
+	}
+}
+
+func TestProcessOutboxBackoff(t *testing.T) {
+	svc := NewProcessOutboxService(ProcessOutboxServiceConfig{BaseDelay: time.Second, MaxDelay: time.Minute})
+
+	tests := []struct {
+		attempts int
+		delay    time.Duration
+	}{
+		{0, time.Second},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{7, time.Minute},
+		{40, time.Minute},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			if got := svc.backoff(tt.attempts); got < tt.delay/2 || got > tt.delay {
+				t.Fatalf("backoff(%d) = %v, want in [%v, %v]", tt.attempts, got, tt.delay/2, tt.delay)
+			}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// CandidateChange is the kind of change of a stream record
+type CandidateChange string
+
+const (
+	CandidateInserted CandidateChange = "INSERT"
+	CandidateModified CandidateChange = "MODIFY"
+	CandidateRemoved  CandidateChange = "REMOVE"
+)
+
+// PublishCandidateChangeServiceInput is a change of the candidates table.
+// ChangeID identifies the change and becomes the ID of the event.
+type PublishCandidateChangeServiceInput struct {
+	ChangeID   string
+	Change     CandidateChange
+	Before     *entities.Candidate
+	After      *entities.Candidate
+	OccurredAt time.Time
+}
+
+// PublishCandidateChangeServiceOutput names the event published, if any
+type PublishCandidateChangeServiceOutput struct {
+	Event string `json:"event,omitempty"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// PublishCandidateChangeService turns changes of the candidates table into
+// domain events for other services
+type PublishCandidateChangeService struct {
+	logger    logger.Logger
+	publisher ports.EventPublisher
+}
+
+// PublishCandidateChangeServiceConfig holds the configuration dependencies for PublishCandidateChangeService
+type PublishCandidateChangeServiceConfig struct {
+	Logger    logger.Logger
+	Publisher ports.EventPublisher
+}
+
+// NewPublishCandidateChangeService creates a new instance of PublishCandidateChangeService with provided configuration
+func NewPublishCandidateChangeService(cfg PublishCandidateChangeServiceConfig) *PublishCandidateChangeService {
+	return &PublishCandidateChangeService{
+		logger:    cfg.Logger,
+		publisher: cfg.Publisher,
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute publishes the event of a change. Changes that other services do
+// not follow, such as profile edits, publish nothing.
+func (svc *PublishCandidateChangeService) Execute(ctx context.Context, input *PublishCandidateChangeServiceInput) (*PublishCandidateChangeServiceOutput, error) {
+	event := CandidateEvent(input)
+	if event == nil {
+		return &PublishCandidateChangeServiceOutput{}, nil
+	}
+
+	if err := svc.publisher.Publish(ctx, event); err != nil {
+		return nil, err
+	}
+	return &PublishCandidateChangeServiceOutput{Event: event.Name()}, nil
+}
+
+// CandidateEvent maps a change to its domain event, or nil:
+//   - INSERT publishes CandidateCreated
+//   - MODIFY publishes CandidateDeleted when the deleted flag is set, else
+//     CandidateStatusChanged when the status changed
+//   - REMOVE publishes CandidateDeleted unless the candidate was already
+//     flagged as deleted
+func CandidateEvent(input *PublishCandidateChangeServiceInput) events.Event {
+	meta := events.Metadata{ID: input.ChangeID, OccurredAt: input.OccurredAt}
+
+	switch input.Change {
+	case CandidateInserted:
+		if input.After == nil {
+			return nil
+		}
+		meta.AggregateID = input.After.ID
+		return &events.CandidateCreated{Metadata: meta, After: events.NewCandidateImage(input.After)}
+
+	case CandidateModified:
+		if input.Before == nil || input.After == nil {
+			return nil
+		}
+		meta.AggregateID = input.After.ID
+		if !input.Before.Deleted && input.After.Deleted {
+			return &events.CandidateDeleted{
+				Metadata: meta,
+				Before:   events.NewCandidateImage(input.Before),
+				After:    events.NewCandidateImage(input.After),
+			}
+		}
+		if input.Before.Status != input.After.Status {
+			return &events.CandidateStatusChanged{
+				Metadata: meta,
+				From:     input.Before.Status,
+				To:       input.After.Status,
+				Before:   events.NewCandidateImage(input.Before),
+				After:    events.NewCandidateImage(input.After),
+			}
+		}
+
+	case CandidateRemoved:
+		if input.Before == nil || input.Before.Deleted {
+			return nil
+		}
+		meta.AggregateID = input.Before.ID
+		return &events.CandidateDeleted{Metadata: meta, Before: events.NewCandidateImage(input.Before)}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+)
+
+func TestCandidateEvent(t *testing.T) {
+	pending := &entities.Candidate{ID: "c1", Status: entities.StatusPending, Names: "Ana"}
+	renamed := &entities.Candidate{ID: "c1", Status: entities.StatusPending, Names: "Ana María"}
+	confirmed := &entities.Candidate{ID: "c1", Status: entities.StatusConfirmed, Names: "Ana"}
+	deleted := &entities.Candidate{ID: "c1", Status: entities.StatusPending, Names: "Ana", Deleted: true}
+
+	tests := []struct {
+		name   string
+		change CandidateChange
+		before *entities.Candidate
+		after  *entities.Candidate
+		want   string
+	}{
+		{"insert", CandidateInserted, nil, pending, events.CandidateCreatedName},
+		{"modify flagged as deleted", CandidateModified, pending, deleted, events.CandidateDeletedName},
+		{"modify status", CandidateModified, pending, confirmed, events.CandidateStatusChangedName},
+		{"modify profile only", CandidateModified, pending, renamed, ""},
+		{"modify already deleted", CandidateModified, deleted, deleted, ""},
+		{"remove", CandidateRemoved, pending, nil, events.CandidateDeletedName},
+		{"remove already deleted", CandidateRemoved, deleted, nil, ""},
+	}
+
+	occurredAt := time.Date(2025, 3, 10, 15, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := CandidateEvent(&PublishCandidateChangeServiceInput{
+				ChangeID:   "change-1",
+				Change:     tt.change,
+				Before:     tt.before,
+				After:      tt.after,
+				OccurredAt: occurredAt,
+			})
+
+			if tt.want == "" {
+				if event != nil {
+					t.Fatalf("CandidateEvent() = %s, want nil", event.Name())
+				}
+				return
+			}
+			if event == nil {
+				t.Fatalf("CandidateEvent() = nil, want %s", tt.want)
+			}
+			if event.Name() != tt.want {
+				t.Fatalf("CandidateEvent() = %s, want %s", event.Name(), tt.want)
+			}
+
+			meta := event.Meta()
+			if meta.ID != "change-1" || meta.AggregateID != "c1" || !meta.OccurredAt.Equal(occurredAt) {
+				t.Errorf("metadata = %+v", meta)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// PublishSlotsServiceInput publishes the interview slots of a store. Dates
+// and times are local to TimeZone, the zone of the store, which defaults to
+// the zone of the service.
+type PublishSlotsServiceInput struct {
+	Store    string             `json:"store" validate:"required,notblank,max=100"`
+	TimeZone string             `json:"timeZone,omitempty" validate:"omitempty,timezone"`
+	Slots    []PublishSlotInput `json:"slots" validate:"required,min=1,max=50,dive"`
+}
+
+// PublishSlotInput is one slot; endTime must be after startTime on the same day
+type PublishSlotInput struct {
+	Date      string `json:"date" validate:"required,lima_date"`
+	StartTime string `json:"startTime" validate:"required,hhmm"`
+	EndTime   string `json:"endTime" validate:"required,hhmm"`
+	Capacity  int    `json:"capacity" validate:"required,min=1,max=50"`
+	Modality  string `json:"modality" validate:"required,oneof=IN_PERSON VIRTUAL"`
+	Link      string `json:"link,omitempty" validate:"omitempty,url,max=500"`
+	Location  string `json:"location,omitempty" validate:"omitempty,max=200"`
+}
+
+// PublishSlotsServiceOutput lists the slots created. Existing holds the IDs
+// of the slots that were already published, which are left as they are.
+type PublishSlotsServiceOutput struct {
+	Published []*entities.InterviewSlot `json:"published"`
+	Existing  []string                  `json:"existing"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// PublishSlotsService handles the publication of interview slots
+type PublishSlotsService struct {
+	config         *config.Config
+	logger         logger.Logger
+	slotRepository repositories.InterviewSlotRepository
+}
+
+// PublishSlotsServiceConfig holds the configuration dependencies for PublishSlotsService
+type PublishSlotsServiceConfig struct {
+	Config         *config.Config
+	Logger         logger.Logger
+	SlotRepository repositories.InterviewSlotRepository
+}
+
+// NewPublishSlotsService creates a new instance of PublishSlotsService with provided configuration
+func NewPublishSlotsService(cfg PublishSlotsServiceConfig) *PublishSlotsService {
+	return &PublishSlotsService{
+		config:         cfg.Config,
+		logger:         cfg.Logger,
+		slotRepository: cfg.SlotRepository,
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute creates the slots that were not published yet. Publishing is
+// idempotent: a slot is identified by its store, start and modality, so a
+// request that failed halfway can be sent again.
+// Returns BAD_REQUEST if a slot starts in the past
+func (svc *PublishSlotsService) Execute(ctx context.Context, input *PublishSlotsServiceInput) (*PublishSlotsServiceOutput, error) {
+	timeZone := input.TimeZone
+	if timeZone == "" {
+		timeZone = svc.config.TIME_ZONE
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.BAD_REQUEST, "Invalid time zone "+timeZone, "ERR_INVALID_TIME_ZONE")
+	}
+
+	now := time.Now()
+	slots := make([]*entities.InterviewSlot, 0, len(input.Slots))
+	for i, in := range input.Slots {
+		start, errStart := time.ParseInLocation("2006-01-02 15:04", in.Date+" "+in.StartTime, location)
+		end, errEnd := time.ParseInLocation("2006-01-02 15:04", in.Date+" "+in.EndTime, location)
+		if errStart != nil || errEnd != nil || !end.After(start) {
+			return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid slot times", "ERR_INVALID_SLOT_TIMES", map[string]any{"index": i})
+		}
+		if !start.After(now) {
+			return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Slot starts in the past", "ERR_SLOT_IN_PAST", map[string]any{"index": i})
+		}
+
+		slot := entities.NewInterviewSlot(input.Store, start, end, entities.SlotModality(in.Modality), in.Capacity)
+		slot.Link = in.Link
+		slot.Location = in.Location
+		slot.CreatedAt = pkgIUtils.NowDateTime(svc.config.TIME_ZONE)
+		slot.CreatedBy = constants.SYSTEM_USER
+		slots = append(slots, slot)
+	}
+
+	output := &PublishSlotsServiceOutput{
+		Published: make([]*entities.InterviewSlot, 0, len(slots)),
+		Existing:  make([]string, 0),
+	}
+	for _, slot := range slots {
+		created, err := svc.slotRepository.Create(ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			output.Published = append(output.Published, slot)
+		} else {
+			output.Existing = append(output.Existing, slot.ID)
+		}
+	}
+
+	svc.logger.Info(map[string]any{
+		"msg":       "Interview slots published",
+		"store":     input.Store,
+		"published": len(output.Published),
+		"existing":  len(output.Existing),
+	})
+	return output, nil
+}
@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// RecordMessageStatusServiceInput is a delivery status reported by the
+// provider for an outbound message
+type RecordMessageStatusServiceInput struct {
+	MessageID  string                 `json:"messageId"`
+	Status     entities.MessageStatus `json:"status"`
+	At         time.Time              `json:"at"`
+	ErrorCode  string                 `json:"errorCode,omitempty"`
+	ErrorTitle string                 `json:"errorTitle,omitempty"`
+}
+
+// RecordMessageStatusServiceOutput reports the current status of the message.
+// Matched is false when the message was not sent by the service.
+type RecordMessageStatusServiceOutput struct {
+	Matched           bool                   `json:"matched"`
+	MessageID         string                 `json:"messageId"`
+	Status            entities.MessageStatus `json:"status,omitempty"`
+	FallbackMessageID string                 `json:"fallbackMessageId,omitempty"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// RecordMessageStatusService records delivery statuses of outbound messages
+// and resends failed WhatsApp messages over the fallback channel
+type RecordMessageStatusService struct {
+	logger              logger.Logger
+	candidateRepository repositories.CandidateRepository
+	messageRepository   repositories.MessageRepository
+	fallback            ports.MessageSender
+	messenger           *messenger
+}
+
+// RecordMessageStatusServiceConfig holds the configuration dependencies for RecordMessageStatusService
+type RecordMessageStatusServiceConfig struct {
+	Config              *config.Config
+	Logger              logger.Logger
+	CandidateRepository repositories.CandidateRepository
+	MessageRepository   repositories.MessageRepository
+	// Fallback is optional; without it failed messages are only recorded
+	Fallback ports.MessageSender
+	// SheetSync is optional; without it no sheet update is queued
+	SheetSync *SheetSync
+}
+
+// NewRecordMessageStatusService creates a new instance of RecordMessageStatusService with provided configuration
+func NewRecordMessageStatusService(cfg RecordMessageStatusServiceConfig) *RecordMessageStatusService {
+	return &RecordMessageStatusService{
+		logger:              cfg.Logger,
+		candidateRepository: cfg.CandidateRepository,
+		messageRepository:   cfg.MessageRepository,
+		fallback:            cfg.Fallback,
+		messenger: &messenger{
+			config:              cfg.Config,
+			logger:              cfg.Logger,
+			candidateRepository: cfg.CandidateRepository,
+			messageRepository:   cfg.MessageRepository,
+			sheetSync:           cfg.SheetSync,
+		},
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute adds the status to the history of the message. A status repeated
+// or lower than the current one is kept in the history only.
+// When a WhatsApp message fails and a fallback sender is configured, the
+// same template is sent over the fallback channel, once per message. An
+// error is returned only if the fallback can be retried, so the provider
+// redelivers the callback.
+func (svc *RecordMessageStatusService) Execute(ctx context.Context, input *RecordMessageStatusServiceInput) (*RecordMessageStatusServiceOutput, error) {
+	at := input.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	message, err := svc.messageRepository.RecordStatus(ctx, input.MessageID, entities.MessageStatusEntry{
+		Status:     input.Status,
+		At:         at.UTC().Format(entities.MessageTimeFormat),
+		ErrorCode:  input.ErrorCode,
+		ErrorTitle: input.ErrorTitle,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if message == nil {
+		// Callbacks of messages sent before tracking started, or by other apps
+		// sharing the number, have no record
+		svc.logger.Info(map[string]any{
+			"msg":       "Ignoring status of unknown message",
+			"messageId": input.MessageID,
+			"status":    input.Status,
+		})
+		return &RecordMessageStatusServiceOutput{Matched: false, MessageID: input.MessageID}, nil
+	}
+
+	output := &RecordMessageStatusServiceOutput{
+		Matched:           true,
+		MessageID:         message.ID,
+		Status:            message.Status,
+		FallbackMessageID: message.FallbackMessageID,
+	}
+	if !svc.needsFallback(message, input.Status) {
+		return output, nil
+	}
+
+	fallbackID, err := svc.sendFallback(ctx, message)
+	if err != nil {
+		return nil, err
+	}
+	output.FallbackMessageID = fallbackID
+	return output, nil
+}
+
+func (svc *RecordMessageStatusService) needsFallback(message *entities.Message, status entities.MessageStatus) bool {
+	if svc.fallback == nil || status != entities.MessageFailed {
+		return false
+	}
+	if message.Channel != ports.ChannelWhatsApp || message.FallbackOf != "" || message.FallbackMessageID != "" {
+		return false
+	}
+	_, ok := MessageTemplates[message.Template]
+	return ok
+}
+
+// sendFallback claims the fallback of message and sends it. The claim is
+// released when sending fails with a retryable error; it is kept when the
+// candidate cannot be reached over the fallback channel either, or when the
+// message went out but could not be recorded, so it is never sent twice.
+func (svc *RecordMessageStatusService) sendFallback(ctx context.Context, message *entities.Message) (string, error) {
+	claimed, err := svc.messageRepository.ClaimFallback(ctx, message.ID)
+	if err != nil {
+		return "", err
+	}
+	if !claimed {
+		return "", nil
+	}
+
+	candidate, err := svc.candidateRepository.GetByID(ctx, message.CandidateID)
+	if err != nil {
+		svc.releaseFallback(ctx, message.ID)
+		return "", err
+	}
+	if candidate == nil || candidate.Deleted {
+		svc.logger.Info(map[string]any{
+			"msg":         "Skipping fallback of deleted candidate",
+			"messageId":   message.ID,
+			"candidateId": message.CandidateID,
+		})
+		return "", nil
+	}
+
+	sent, err := svc.messenger.send(ctx, svc.fallback, candidate, message.Template, sendOptions{FallbackOf: message.ID})
+	if err != nil {
+		var customErr *errorCustom.CustomError
+		if errors.As(err, &customErr) && customErr.ErrorCode == "ERR_MESSAGE_NOT_RECORDED" {
+			return "", nil
+		}
+		if isPermanentOutboxError(err) {
+			svc.logger.Error(map[string]any{
+				"msg":         "Fallback message refused",
+				"messageId":   message.ID,
+				"candidateId": candidate.ID,
+				"error":       err.Error(),
+			})
+			return "", nil
+		}
+		svc.releaseFallback(ctx, message.ID)
+		return "", err
+	}
+
+	if err := svc.messageRepository.CompleteFallback(ctx, message.ID, sent.ID); err != nil {
+		// The fallback message is recorded on its own; only the link from the
+		// failed one is missing, and the claim keeps it from being resent
+		svc.logger.Error(utils.NewSafeError(err, "Error in RecordMessageStatusService.Execute: Failed to link fallback message"))
+	}
+	return sent.ID, nil
+}
+
+func (svc *RecordMessageStatusService) releaseFallback(ctx context.Context, id string) {
+	if err := svc.messageRepository.ReleaseFallback(ctx, id); err != nil {
+		svc.logger.Error(utils.NewSafeError(err, "Error in RecordMessageStatusService.Execute: Failed to release fallback"))
+	}
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/internal/infrastructure/adapters"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+)
+
+// failingSender fails every message with a retryable error
+type failingSender struct{}
+
+func (failingSender) Send(context.Context, ports.OutboundMessage) (*ports.SentMessage, error) {
+	return nil, errors.New("provider unavailable")
+}
+
+func newRecordStatusService(candidates *memoryCandidates, messages *memoryMessages, fallback ports.MessageSender) *RecordMessageStatusService {
+	return NewRecordMessageStatusService(RecordMessageStatusServiceConfig{
+		Config:              &config.Config{TIME_ZONE: constants.DEFAULT_TIME_ZONE},
+		Logger:              pkgILogger.NewZeroLogLogger(),
+		CandidateRepository: candidates,
+		MessageRepository:   messages,
+		Fallback:            fallback,
+	})
+}
+
+func failedWhatsApp() *entities.Message {
+	return &entities.Message{ID: "m1", CandidateID: "c1", Channel: ports.ChannelWhatsApp, Template: TemplateInvitation}
+}
+
+func TestRecordMessageStatusSendsFallbackOnce(t *testing.T) {
+	candidates := newMemoryCandidates(pendingCandidate())
+	messages := newMemoryMessages(failedWhatsApp())
+	sms := adapters.NewMemoryMessageSender(ports.ChannelSMS)
+	svc := newRecordStatusService(candidates, messages, sms)
+
+	input := &RecordMessageStatusServiceInput{MessageID: "m1", Status: entities.MessageFailed}
+	output, err := svc.Execute(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := sms.Sent()
+	if len(sent) != 1 || output.FallbackMessageID != sent[0].ID {
+		t.Fatalf("output = %+v, sent = %+v", output, sent)
+	}
+	if fallback, _ := messages.GetByID(context.Background(), sent[0].ID); fallback == nil || fallback.FallbackOf != "m1" {
+		t.Errorf("fallback message = %+v, want it recorded as fallback of m1", fallback)
+	}
+	candidate, _ := candidates.GetByID(context.Background(), "c1")
+	if candidate.SentMessageID != sent[0].ID {
+		t.Errorf("SentMessageID = %q, want the fallback", candidate.SentMessageID)
+	}
+
+	// A redelivered callback finds the fallback claimed
+	if _, err := svc.Execute(context.Background(), input); err != nil {
+		t.Fatal(err)
+	}
+	if len(sms.Sent()) != 1 {
+		t.Errorf("sent %d fallbacks, want 1", len(sms.Sent()))
+	}
+}
+
+func TestRecordMessageStatusReleasesRetryableFallback(t *testing.T) {
+	messages := newMemoryMessages(failedWhatsApp())
+	svc := newRecordStatusService(newMemoryCandidates(pendingCandidate()), messages, failingSender{})
+
+	_, err := svc.Execute(context.Background(), &RecordMessageStatusServiceInput{MessageID: "m1", Status: entities.MessageFailed})
+	if err == nil {
+		t.Fatal("expected an error, so the provider redelivers the callback")
+	}
+	if messages.released != 1 || messages.claimed["m1"] {
+		t.Errorf("released = %d, claimed = %t, want the claim undone", messages.released, messages.claimed["m1"])
+	}
+}
+
+func TestRecordMessageStatusKeepsClaimOfRefusedFallback(t *testing.T) {
+	candidate := pendingCandidate()
+	candidate.Phone = ""
+	messages := newMemoryMessages(failedWhatsApp())
+	sms := adapters.NewMemoryMessageSender(ports.ChannelSMS)
+	svc := newRecordStatusService(newMemoryCandidates(candidate), messages, sms)
+
+	output, err := svc.Execute(context.Background(), &RecordMessageStatusServiceInput{MessageID: "m1", Status: entities.MessageFailed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output.FallbackMessageID != "" || len(sms.Sent()) != 0 {
+		t.Fatalf("output = %+v, want no fallback", output)
+	}
+	if messages.released != 0 || !messages.claimed["m1"] {
+		t.Errorf("released = %d, claimed = %t, want the claim kept", messages.released, messages.claimed["m1"])
+	}
+}
+
+func TestRecordMessageStatusWithoutFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		message *entities.Message
+		status  entities.MessageStatus
+		matched bool
+	}{
+		{"unknown message", nil, entities.MessageFailed, false},
+		{"delivered", failedWhatsApp(), entities.MessageDelivered, true},
+		{"failed SMS", &entities.Message{ID: "m1", CandidateID: "c1", Channel: ports.ChannelSMS, Template: TemplateInvitation}, entities.MessageFailed, true},
+		{"failed fallback", &entities.Message{ID: "m1", CandidateID: "c1", Channel: ports.ChannelWhatsApp, Template: TemplateInvitation, FallbackOf: "m0"}, entities.MessageFailed, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := newMemoryMessages()
+			if tt.message != nil {
+				messages = newMemoryMessages(tt.message)
+			}
+			sms := adapters.NewMemoryMessageSender(ports.ChannelSMS)
+			svc := newRecordStatusService(newMemoryCandidates(pendingCandidate()), messages, sms)
+
+			output, err := svc.Execute(context.Background(), &RecordMessageStatusServiceInput{MessageID: "m1", Status: tt.status})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output.Matched != tt.matched || len(sms.Sent()) != 0 || len(messages.claimed) != 0 {
+				t.Errorf("output = %+v, sent = %d, want no fallback", output, len(sms.Sent()))
+			}
+		})
+	}
+}
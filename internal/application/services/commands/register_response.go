@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// RegisterResponseServiceInput is an inbound reply of a candidate
+type RegisterResponseServiceInput struct {
+	Phone      string    `json:"phone" validate:"required,pe_phone"`
+	MessageID  string    `json:"messageId" validate:"required,notblank"`
+	Message    string    `json:"message" validate:"required,notblank"`
+	ReceivedAt time.Time `json:"receivedAt"`
+}
+
+// RegisterResponseServiceOutput reports how the reply was applied. Matched is
+// false when no active candidate has the phone.
+type RegisterResponseServiceOutput struct {
+	Matched     bool                     `json:"matched"`
+	CandidateID string                   `json:"candidateId,omitempty"`
+	Intent      ports.CandidateIntent    `json:"intent,omitempty"`
+	Status      entities.CandidateStatus `json:"status,omitempty"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// RegisterResponseService records the reply of a candidate and its analysis
+type RegisterResponseService struct {
+	config              *config.Config
+	logger              logger.Logger
+	candidateRepository repositories.CandidateRepository
+	aiService           ports.AIService
+	sheetSync           *SheetSync
+	dispatcher          ports.EventDispatcher
+}
+
+// RegisterResponseServiceConfig holds the configuration dependencies for RegisterResponseService
+type RegisterResponseServiceConfig struct {
+	Config              *config.Config
+	Logger              logger.Logger
+	CandidateRepository repositories.CandidateRepository
+	AIService           ports.AIService
+	// SheetSync is optional; without it no sheet update is queued
+	SheetSync *SheetSync
+	// Dispatcher is optional; it receives CandidateStatusChanged
+	Dispatcher ports.EventDispatcher
+}
+
+// NewRegisterResponseService creates a new instance of RegisterResponseService with provided configuration
+func NewRegisterResponseService(cfg RegisterResponseServiceConfig) *RegisterResponseService {
+	return &RegisterResponseService{
+		config:              cfg.Config,
+		logger:              cfg.Logger,
+		candidateRepository: cfg.CandidateRepository,
+		aiService:           cfg.AIService,
+		sheetSync:           cfg.SheetSync,
+		dispatcher:          cfg.Dispatcher,
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute resolves the candidate by phone (most recent active candidacy),
+// classifies the reply and stores response, response time and confirmation.
+// Replies older than the one already stored are ignored, since providers do
+// not guarantee delivery order.
+func (svc *RegisterResponseService) Execute(ctx context.Context, input *RegisterResponseServiceInput) (*RegisterResponseServiceOutput, error) {
+	candidates, err := svc.candidateRepository.FindByPhone(ctx, input.Phone)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return &RegisterResponseServiceOutput{Matched: false}, nil
+	}
+	candidate := candidates[0]
+
+	receivedAt := input.ReceivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+	responseAt := receivedAt.In(svc.location()).Format("2006-01-02 15:04:05")
+	if candidate.ResponseAt != "" && candidate.ResponseAt > responseAt {
+		svc.logger.Info(map[string]any{
+			"msg":         "Ignoring reply older than the stored one",
+			"candidateId": candidate.ID,
+			"messageId":   input.MessageID,
+		})
+		return &RegisterResponseServiceOutput{Matched: true, CandidateID: candidate.ID, Status: candidate.Status}, nil
+	}
+
+	analysis, err := svc.aiService.AnalyzeCandidate(ctx, ports.AnalyzeCandidateRequest{
+		// Only the first name is shared with the model
+		Candidate:       firstName(candidate.Names),
+		Message:         input.Message,
+		CurrentDateTime: pkgIUtils.NowDateTime(svc.config.TIME_ZONE),
+		TimeZone:        svc.config.TIME_ZONE,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	status := entities.StatusResponded
+	switch analysis.Intent {
+	case ports.IntentConfirm:
+		status = entities.StatusConfirmed
+	case ports.IntentDecline:
+		status = entities.StatusDeclined
+	}
+
+	updatedAt := pkgIUtils.NowDateTime(svc.config.TIME_ZONE)
+	updatedBy := constants.SYSTEM_USER
+	updates := map[string]interface{}{
+		"response":          input.Message,
+		"responseAt":        responseAt,
+		"responseMessageId": input.MessageID,
+		"responseIntent":    string(analysis.Intent),
+		"status":            string(status),
+		"updatedAt":         &updatedAt,
+		"updatedBy":         &updatedBy,
+	}
+	if analysis.Confirmed != nil {
+		updates["confirmed"] = *analysis.Confirmed
+	}
+
+	outbox, err := svc.sheetSync.Outbox(candidate, updates)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.candidateRepository.Update(ctx, candidate.ID, updates, outbox...); err != nil {
+		return nil, err
+	}
+	if status != candidate.Status {
+		before := *candidate
+		after := *candidate
+		after.Status = status
+		after.ResponseIntent = string(analysis.Intent)
+		after.ResponseAt = responseAt
+		if analysis.Confirmed != nil {
+			after.Confirmed = analysis.Confirmed
+		}
+		after.UpdatedAt = &updatedAt
+		dispatch(ctx, svc.dispatcher, &events.CandidateStatusChanged{
+			Metadata: newEventMetadata(candidate),
+			From:     before.Status,
+			To:       status,
+			Before:   events.NewCandidateImage(&before),
+			After:    events.NewCandidateImage(&after),
+		})
+	}
+
+	return &RegisterResponseServiceOutput{
+		Matched:     true,
+		CandidateID: candidate.ID,
+		Intent:      analysis.Intent,
+		Status:      status,
+	}, nil
+}
+
+func (svc *RegisterResponseService) location() *time.Location {
+	loc, err := time.LoadLocation(svc.config.TIME_ZONE)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func firstName(names string) string {
+	if fields := strings.Fields(names); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// RetryOutboxServiceInput identifies a failed outbox record
+type RetryOutboxServiceInput struct {
+	ID string `json:"id" validate:"required,notblank"`
+}
+
+// RetryOutboxServiceOutput reports the record queued again
+type RetryOutboxServiceOutput struct {
+	ID     string                `json:"id"`
+	Status entities.OutboxStatus `json:"status"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// RetryOutboxService queues a failed outbox record for delivery again
+type RetryOutboxService struct {
+	config           *config.Config
+	logger           logger.Logger
+	outboxRepository repositories.OutboxRepository
+}
+
+// RetryOutboxServiceConfig holds the configuration dependencies for RetryOutboxService
+type RetryOutboxServiceConfig struct {
+	Config           *config.Config
+	Logger           logger.Logger
+	OutboxRepository repositories.OutboxRepository
+}
+
+// NewRetryOutboxService creates a new instance of RetryOutboxService with provided configuration
+func NewRetryOutboxService(cfg RetryOutboxServiceConfig) *RetryOutboxService {
+	return &RetryOutboxService{
+		config:           cfg.Config,
+		logger:           cfg.Logger,
+		outboxRepository: cfg.OutboxRepository,
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute resets the attempts of a failed record and makes it due now
+// Returns NOT_FOUND if the record does not exist and CONFLICT if it is not failed
+func (svc *RetryOutboxService) Execute(ctx context.Context, input *RetryOutboxServiceInput) (*RetryOutboxServiceOutput, error) {
+	record, err := svc.outboxRepository.GetByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Outbox record not found", "ERR_OUTBOX_NOT_FOUND")
+	}
+	if record.Status != entities.OutboxFailed {
+		return nil, errorCustom.NewError(errorCustom.CONFLICT, "Only failed outbox records can be retried", "ERR_OUTBOX_NOT_FAILED")
+	}
+
+	if err := svc.outboxRepository.Requeue(ctx, record.ID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	svc.logger.Info(map[string]any{
+		"msg":      "Outbox record queued for retry",
+		"outboxId": record.ID,
+		"type":     record.Type,
+	})
+	return &RetryOutboxServiceOutput{ID: record.ID, Status: entities.OutboxPending}, nil
+}
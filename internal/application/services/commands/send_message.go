@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// SendMessageServiceInput sends the template named Template to a candidate
+type SendMessageServiceInput struct {
+	CandidateID string `json:"-" validate:"required,notblank"`
+	Template    string `json:"template" validate:"required,notblank"`
+}
+
+// SendMessageServiceOutput reports the message accepted by the provider
+type SendMessageServiceOutput struct {
+	CandidateID string `json:"candidateId"`
+	MessageID   string `json:"messageId"`
+	Channel     string `json:"channel"`
+	Template    string `json:"template"`
+	Text        string `json:"text"`
+	SentAt      string `json:"sentAt"`
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// SendMessageService sends template messages to candidates
+type SendMessageService struct {
+	candidateRepository repositories.CandidateRepository
+	sender              ports.MessageSender
+	messenger           *messenger
+}
+
+// SendMessageServiceConfig holds the configuration dependencies for SendMessageService
+type SendMessageServiceConfig struct {
+	Config              *config.Config
+	Logger              logger.Logger
+	CandidateRepository repositories.CandidateRepository
+	MessageRepository   repositories.MessageRepository
+	Sender              ports.MessageSender
+	// SheetSync is optional; without it no sheet update is queued
+	SheetSync *SheetSync
+	// FollowUps is optional; without it invitations are not followed up
+	FollowUps *FollowUps
+}
+
+// NewSendMessageService creates a new instance of SendMessageService with provided configuration
+func NewSendMessageService(cfg SendMessageServiceConfig) *SendMessageService {
+	return &SendMessageService{
+		candidateRepository: cfg.CandidateRepository,
+		sender:              cfg.Sender,
+		messenger: &messenger{
+			config:              cfg.Config,
+			logger:              cfg.Logger,
+			candidateRepository: cfg.CandidateRepository,
+			messageRepository:   cfg.MessageRepository,
+			sheetSync:           cfg.SheetSync,
+			followUps:           cfg.FollowUps,
+		},
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute renders the template for the candidate, sends it and records the
+// message, for its delivery status, and the candidate's last message ID and
+// send time (mirrored to the sheet)
+// Returns NOT_FOUND if the candidate does not exist, BAD_REQUEST if the
+// template is unknown, the candidate lacks a variable it requires or the
+// provider refuses the recipient
+func (svc *SendMessageService) Execute(ctx context.Context, input *SendMessageServiceInput) (*SendMessageServiceOutput, error) {
+	if _, ok := MessageTemplates[input.Template]; !ok {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Unknown message template "+input.Template, "ERR_TEMPLATE_NOT_FOUND",
+			map[string]any{"templates": MessageTemplateNames()})
+	}
+
+	candidate, err := svc.candidateRepository.GetByID(ctx, input.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil || candidate.Deleted {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Candidate not found", "ERR_CANDIDATE_NOT_FOUND")
+	}
+
+	sent, err := svc.messenger.send(ctx, svc.sender, candidate, input.Template, sendOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SendMessageServiceOutput{
+		CandidateID: candidate.ID,
+		MessageID:   sent.ID,
+		Channel:     sent.Channel,
+		Template:    input.Template,
+		Text:        sent.Text,
+		SentAt:      sent.SentAt,
+	}, nil
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// SheetSync writes the changes of a candidate back to the recruiters' sheet.
+// The write is not done inline: Outbox builds an outbox record that is saved
+// with the candidate change, and the outbox processor delivers it with Handle.
+//
+// Records of one candidate may be delivered out of order, e.g. when a failed
+// record is retried after a newer one. Handle therefore writes the current
+// values of the candidate in the columns of the record, not the values it was
+// queued with, so a late record never overwrites newer cells.
+type SheetSync struct {
+	logger              logger.Logger
+	client              ports.SheetClient
+	candidateRepository repositories.CandidateRepository
+}
+
+func NewSheetSync(logger logger.Logger, client ports.SheetClient, candidateRepository repositories.CandidateRepository) *SheetSync {
+	return &SheetSync{
+		logger:              logger,
+		client:              client,
+		candidateRepository: candidateRepository,
+	}
+}
+
+// Outbox returns the record that writes the mirrored attributes present in
+// updates to their columns, or none if no mirrored attribute changed.
+// A nil SheetSync returns none, so services can take it as optional.
+func (s *SheetSync) Outbox(candidate *entities.Candidate, updates map[string]interface{}) ([]*entities.OutboxRecord, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	cells := SheetCells(candidate, updates)
+	if len(cells) == 0 {
+		return nil, nil
+	}
+
+	version := ""
+	if updatedAt, ok := updates["updatedAt"].(*string); ok && updatedAt != nil {
+		version = *updatedAt
+	}
+	record, err := entities.NewOutboxRecord(pkgIUtils.GenerateUUID(), entities.OutboxSheetUpdate, candidate.ID, entities.SheetUpdatePayload{
+		SheetID: candidate.SheetID,
+		RowID:   candidate.RowID,
+		Cells:   cells,
+		Version: version,
+	}, time.Now())
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to build sheet update", "OUTBOX_ERROR")
+	}
+	return []*entities.OutboxRecord{record}, nil
+}
+
+// Handle delivers an OutboxSheetUpdate record with the current values of the
+// candidate. Records of deleted candidates are dropped.
+func (s *SheetSync) Handle(ctx context.Context, record *entities.OutboxRecord) error {
+	var payload entities.SheetUpdatePayload
+	if err := record.DecodePayload(&payload); err != nil {
+		return errorCustom.Wrap(err, errorCustom.UNPROCESSABLE_ENTITY, "Invalid sheet update payload", "OUTBOX_INVALID_PAYLOAD")
+	}
+
+	candidate, err := s.candidateRepository.GetByID(ctx, record.AggregateID)
+	if err != nil {
+		return err
+	}
+	if candidate == nil || candidate.Deleted {
+		s.logger.Info(map[string]any{
+			"msg":         "Skipping sheet update of deleted candidate",
+			"outboxId":    record.ID,
+			"candidateId": record.AggregateID,
+		})
+		return nil
+	}
+
+	cells := currentCells(candidate, payload.Cells)
+	if candidate.UpdatedAt == nil || *candidate.UpdatedAt != payload.Version {
+		s.logger.Info(map[string]any{
+			"msg":         "Sheet update superseded, writing current values",
+			"outboxId":    record.ID,
+			"candidateId": candidate.ID,
+			"version":     payload.Version,
+		})
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+
+	if err := s.client.UpdateCells(ctx, payload.SheetID, payload.RowID, cells); err != nil {
+		s.logger.Error(pkgIUtils.NewSafeError(err, "Error in SheetSync.Handle: Failed to update sheet row "+payload.RowID))
+		return err
+	}
+	return nil
+}
+
+// currentCells returns the current values of the candidate for the columns of
+// cells
+func currentCells(candidate *entities.Candidate, cells map[string]any) map[string]any {
+	values := candidate.SheetValues()
+	for attribute, columnID := range candidate.SheetColumns() {
+		if _, ok := cells[columnID]; !ok {
+			delete(values, attribute)
+		}
+	}
+	return SheetCells(candidate, values)
+}
+
+// SheetCells returns the cell values, keyed by column ID, of the mirrored
+// attributes present in updates
+func SheetCells(candidate *entities.Candidate, updates map[string]interface{}) map[string]any {
+	cells := make(map[string]any)
+	for attribute, columnID := range candidate.SheetColumns() {
+		value, ok := updates[attribute]
+		if !ok {
+			continue
+		}
+		if ptr, isPtr := value.(*string); isPtr {
+			if ptr == nil {
+				value = ""
+			} else {
+				value = *ptr
+			}
+		}
+		cells[columnID] = value
+	}
+	return cells
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/infrastructure/adapters"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+)
+
+func sheetCandidate() *entities.Candidate {
+	return &entities.Candidate{
+		ID:                                "c1",
+		SheetID:                           "s1",
+		RowID:                             "r1",
+		ColumnSendDateTimeId:              "colSent",
+		ColumnPostulantResponseId:         "colResponse",
+		ColumnPostulantDateTimeResponseId: "colResponseAt",
+	}
+}
+
+// change applies updates to the candidate and returns the record queued
+func change(t *testing.T, sync *SheetSync, candidates *memoryCandidates, updates map[string]interface{}) *entities.OutboxRecord {
+	t.Helper()
+	candidate, _ := candidates.GetByID(context.Background(), "c1")
+	outbox, err := sync.Outbox(candidate, updates)
+	if err != nil || len(outbox) != 1 {
+		t.Fatalf("Outbox() = %v, %v", outbox, err)
+	}
+	if err := candidates.Update(context.Background(), "c1", updates, outbox...); err != nil {
+		t.Fatal(err)
+	}
+	return outbox[0]
+}
+
+func TestSheetSyncRetriedRecordKeepsNewerCells(t *testing.T) {
+	candidates := newMemoryCandidates(sheetCandidate())
+	client := adapters.NewMemorySheetClient()
+	sync := NewSheetSync(pkgILogger.NewZeroLogLogger(), client, candidates)
+	ctx := context.Background()
+
+	first, second := "2026-10-19 10:00:00", "2026-10-19 10:05:00"
+	stale := change(t, sync, candidates, map[string]interface{}{"sentAt": "2026-10-19 10:00:00", "response": "", "updatedAt": &first})
+	newer := change(t, sync, candidates, map[string]interface{}{"response": "SÍ", "responseAt": "2026-10-19 10:05:00", "updatedAt": &second})
+
+	var payload entities.SheetUpdatePayload
+	if err := stale.DecodePayload(&payload); err != nil || payload.Version != first {
+		t.Fatalf("payload = %+v, want stamped with %s", payload, first)
+	}
+
+	// The newer record is delivered first and the stale one is retried later
+	if err := sync.Handle(ctx, newer); err != nil {
+		t.Fatal(err)
+	}
+	if err := sync.Handle(ctx, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := client.GetRow(ctx, "s1", "r1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]any)
+	for columnID, cell := range row.Cells {
+		got[columnID] = cell.Value
+	}
+	want := map[string]any{"colSent": "2026-10-19 10:00:00", "colResponse": "SÍ", "colResponseAt": "2026-10-19 10:05:00"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("row = %v, want %v", got, want)
+	}
+
+	// The stale record only writes its own columns
+	writes := client.Writes()
+	if last := writes[len(writes)-1].Values; !reflect.DeepEqual(last, map[string]any{"colSent": "2026-10-19 10:00:00", "colResponse": "SÍ"}) {
+		t.Errorf("stale write = %v", last)
+	}
+}
+
+func TestSheetSyncSkipsDeletedCandidate(t *testing.T) {
+	candidates := newMemoryCandidates(sheetCandidate())
+	client := adapters.NewMemorySheetClient()
+	sync := NewSheetSync(pkgILogger.NewZeroLogLogger(), client, candidates)
+	ctx := context.Background()
+
+	updatedAt := "2026-10-19 10:00:00"
+	record := change(t, sync, candidates, map[string]interface{}{"sentAt": updatedAt, "updatedAt": &updatedAt})
+	if err := candidates.Delete(ctx, "c1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sync.Handle(ctx, record); err != nil {
+		t.Fatal(err)
+	}
+	if writes := client.Writes(); len(writes) != 0 {
+		t.Errorf("writes = %v, want none", writes)
+	}
+}
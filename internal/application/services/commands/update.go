@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIUtils "github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// =====================================================================
+// DTOs and Input/Output types
+// =====================================================================
+
+// UpdateServiceInput represents the input for candidate update operation.
+// Only the fields sent are updated; documentType and documentNumber go together.
+// The interview fields of a candidate holding a slot booking are owned by the
+// booking and cannot be updated.
+type UpdateServiceInput struct {
+	ID              string  `json:"-" validate:"required,notblank"`
+	Names           *string `json:"names,omitempty" validate:"omitnil,notblank,max=100"`
+	Surnames        *string `json:"surnames,omitempty" validate:"omitnil,notblank,max=100"`
+	DocumentType    *string `json:"documentType,omitempty" validate:"omitnil,oneof=DNI CE"`
+	DocumentNumber  *string `json:"documentNumber,omitempty" validate:"omitnil,notblank"`
+	Phone           *string `json:"phone,omitempty" validate:"omitnil,pe_phone"`
+	Email           *string `json:"email,omitempty" validate:"omitnil,email,max=254"`
+	BirthDate       *string `json:"birthDate,omitempty" validate:"omitnil,lima_date"`
+	District        *string `json:"district,omitempty" validate:"omitnil,max=100"`
+	AppliedPosition *string `json:"appliedPosition,omitempty" validate:"omitnil,max=100"`
+	Store           *string `json:"store,omitempty" validate:"omitnil,max=100"`
+	InterviewDate   *string `json:"interviewDate,omitempty" validate:"omitnil,lima_date"`
+	InterviewTime   *string `json:"interviewTime,omitempty" validate:"omitnil,hhmm"`
+	InterviewLink   *string `json:"interviewLink,omitempty" validate:"omitnil,url,max=500"`
+}
+
+// UpdateServiceOutput represents the output of candidate update operation
+type UpdateServiceOutput struct {
+}
+
+// =====================================================================
+// Service Configuration
+// =====================================================================
+
+// UpdateService handles candidate update operations
+type UpdateService struct {
+	config              *config.Config
+	logger              logger.Logger
+	candidateRepository repositories.CandidateRepository
+	sheetSync           *SheetSync
+}
+
+// UpdateServiceConfig holds the configuration dependencies for UpdateService
+type UpdateServiceConfig struct {
+	Config              *config.Config
+	Logger              logger.Logger
+	CandidateRepository repositories.CandidateRepository
+	// SheetSync is optional; without it no sheet update is queued
+	SheetSync *SheetSync
+}
+
+// NewUpdateService creates a new instance of UpdateService with provided configuration
+func NewUpdateService(cfg UpdateServiceConfig) *UpdateService {
+	return &UpdateService{
+		config:              cfg.Config,
+		logger:              cfg.Logger,
+		candidateRepository: cfg.CandidateRepository,
+		sheetSync:           cfg.SheetSync,
+	}
+}
+
+// =====================================================================
+// Main Service Logic
+// =====================================================================
+
+// Execute applies a partial update to the profile or interview of a candidate
+// and queues the write-back of the interview columns to the sheet
+// Returns NOT_FOUND if the candidate does not exist and CONFLICT if the
+// interview is changed while the candidate holds a slot booking
+func (svc *UpdateService) Execute(ctx context.Context, input *UpdateServiceInput) (*UpdateServiceOutput, error) {
+	candidate, err := svc.candidateRepository.GetByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil || candidate.Deleted {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Candidate not found", "ERR_CANDIDATE_NOT_FOUND")
+	}
+
+	updates := buildProfileUpdates(input)
+	if len(updates) == 0 {
+		return &UpdateServiceOutput{}, nil
+	}
+
+	// The guard repeats the check when writing, so a booking made meanwhile
+	// is not overwritten either
+	guard := repositories.UpdateGuard{NotBooked: input.changesInterview()}
+	if guard.NotBooked && candidate.InterviewSlotID != "" {
+		return nil, errorCustom.NewError(errorCustom.CONFLICT, "Interview is managed by the slot booking; cancel it to change the interview", "ERR_CANDIDATE_BOOKED",
+			map[string]any{"slotId": candidate.InterviewSlotID})
+	}
+
+	updatedAt := pkgIUtils.NowDateTime("America/Lima")
+	updatedBy := constants.SYSTEM_USER
+	updates["updatedAt"] = &updatedAt
+	updates["updatedBy"] = &updatedBy
+
+	outbox, err := svc.sheetSync.Outbox(candidate, updates)
+	if err != nil {
+		return nil, err
+	}
+	if err := svc.candidateRepository.UpdateIf(ctx, input.ID, guard, updates, outbox...); err != nil {
+		return nil, err
+	}
+
+	return &UpdateServiceOutput{}, nil
+}
+
+// changesInterview reports whether the input sets an interview field
+func (input *UpdateServiceInput) changesInterview() bool {
+	return input.InterviewDate != nil || input.InterviewTime != nil || input.InterviewLink != nil
+}
+
+// buildProfileUpdates maps the fields sent to their dynamodbav attribute names
+func buildProfileUpdates(input *UpdateServiceInput) map[string]interface{} {
+	updates := make(map[string]interface{})
+
+	set := func(attribute string, value *string) {
+		if value != nil {
+			updates[attribute] = *value
+		}
+	}
+	set("names", input.Names)
+	set("surnames", input.Surnames)
+	set("phone", input.Phone)
+	set("birthDate", input.BirthDate)
+	set("district", input.District)
+	set("appliedPosition", input.AppliedPosition)
+	set("store", input.Store)
+	set("interviewDate", input.InterviewDate)
+	set("interviewTime", input.InterviewTime)
+	set("interviewLink", input.InterviewLink)
+
+	if input.Email != nil {
+		updates["email"] = strings.ToLower(*input.Email)
+	}
+	if input.DocumentType != nil && input.DocumentNumber != nil {
+		number := strings.ToUpper(*input.DocumentNumber)
+		updates["documentType"] = *input.DocumentType
+		updates["documentNumber"] = number
+		updates["documentKey"] = entities.BuildDocumentKey(*input.DocumentType, number)
+	}
+
+	return updates
+}
@@ -0,0 +1,47 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+)
+
+type FindByDocumentServiceInput struct {
+	DocumentType   string `json:"documentType" validate:"required,oneof=DNI CE"`
+	DocumentNumber string `json:"documentNumber" validate:"required,notblank"`
+}
+
+// FindByDocumentServiceOutput lists the active candidacies of a document.
+// Candidates is empty when the document is unknown.
+type FindByDocumentServiceOutput struct {
+	Candidates []*GetByIDServiceOutput `json:"candidates"`
+}
+
+type FindByDocumentService struct {
+	candidateRepository repositories.CandidateRepository
+}
+
+type FindByDocumentServiceConfig struct {
+	CandidateRepository repositories.CandidateRepository
+}
+
+func NewFindByDocumentService(cfg FindByDocumentServiceConfig) *FindByDocumentService {
+	return &FindByDocumentService{
+		candidateRepository: cfg.CandidateRepository,
+	}
+}
+
+func (svc *FindByDocumentService) Execute(ctx context.Context, input FindByDocumentServiceInput) (*FindByDocumentServiceOutput, error) {
+	candidates, err := svc.candidateRepository.FindByDocument(ctx, input.DocumentType, input.DocumentNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &FindByDocumentServiceOutput{
+		Candidates: make([]*GetByIDServiceOutput, 0, len(candidates)),
+	}
+	for _, candidate := range candidates {
+		output.Candidates = append(output.Candidates, newCandidateOutput(candidate))
+	}
+	return output, nil
+}
@@ -0,0 +1,46 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+)
+
+type FindByPhoneServiceInput struct {
+	Phone string `json:"phone" validate:"required,pe_phone"`
+}
+
+// FindByPhoneServiceOutput lists the active candidacies of a phone, most
+// recent first. Candidates is empty when the phone is unknown.
+type FindByPhoneServiceOutput struct {
+	Candidates []*GetByIDServiceOutput `json:"candidates"`
+}
+
+type FindByPhoneService struct {
+	candidateRepository repositories.CandidateRepository
+}
+
+type FindByPhoneServiceConfig struct {
+	CandidateRepository repositories.CandidateRepository
+}
+
+func NewFindByPhoneService(cfg FindByPhoneServiceConfig) *FindByPhoneService {
+	return &FindByPhoneService{
+		candidateRepository: cfg.CandidateRepository,
+	}
+}
+
+func (svc *FindByPhoneService) Execute(ctx context.Context, input FindByPhoneServiceInput) (*FindByPhoneServiceOutput, error) {
+	candidates, err := svc.candidateRepository.FindByPhone(ctx, input.Phone)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &FindByPhoneServiceOutput{
+		Candidates: make([]*GetByIDServiceOutput, 0, len(candidates)),
+	}
+	for _, candidate := range candidates {
+		output.Candidates = append(output.Candidates, newCandidateOutput(candidate))
+	}
+	return output, nil
+}
@@ -3,6 +3,7 @@ package queries
 import (
 	"context"
 
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
 	"github.com/Yolto7/api-candidates/internal/domain/repositories"
 	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
 )
@@ -24,19 +25,47 @@ type GetByIDServiceOutput struct {
 	ColumnInterviewDateId             string `json:"columnInterviewDateId"`
 	ColumnInterviewTimeId             string `json:"columnInterviewTimeId"`
 	ColumnInterviewLinkId             string `json:"columnInterviewLinkId"`
+	Names                             string `json:"names"`
+	Surnames                          string `json:"surnames"`
+	DocumentType                      string `json:"documentType"`
+	DocumentNumber                    string `json:"documentNumber"`
+	Phone                             string `json:"phone"`
+	Email                             string `json:"email"`
+	BirthDate                         string `json:"birthDate"`
+	District                          string `json:"district"`
+	AppliedPosition                   string `json:"appliedPosition"`
+	Store                             string `json:"store"`
+	Status                            string `json:"status"`
+	Response                          string `json:"response,omitempty"`
+	ResponseAt                        string `json:"responseAt,omitempty"`
+	Confirmed                         *bool  `json:"confirmed,omitempty"`
+	SentAt                            string `json:"sentAt,omitempty"`
+	SentMessageID                     string `json:"sentMessageId,omitempty"`
+	SentMessageStatus                 string `json:"sentMessageStatus,omitempty"`
+	SentMessageStatusAt               string `json:"sentMessageStatusAt,omitempty"`
+	InterviewDate                     string `json:"interviewDate,omitempty"`
+	InterviewTime                     string `json:"interviewTime,omitempty"`
+	InterviewLink                     string `json:"interviewLink,omitempty"`
+	InterviewSlotID                   string `json:"interviewSlotId,omitempty"`
+	CreatedAt                         string `json:"createdAt"`
 }
 
 type GetByIDService struct {
 	candidateRepository repositories.CandidateRepository
+	messageRepository   repositories.MessageRepository
 }
 
 type GetByIDServiceConfig struct {
 	CandidateRepository repositories.CandidateRepository
+	// MessageRepository is optional; without it the delivery status of the
+	// last message is not returned
+	MessageRepository repositories.MessageRepository
 }
 
 func NewGetByIDService(cfg GetByIDServiceConfig) *GetByIDService {
 	return &GetByIDService{
 		candidateRepository: cfg.CandidateRepository,
+		messageRepository:   cfg.MessageRepository,
 	}
 }
 
@@ -46,9 +75,25 @@ func (svc *GetByIDService) Execute(ctx context.Context, input GetByIDServiceInpu
 		return nil, err
 	}
 	if candidate == nil {
-		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Candidate not found", "ERR_CANDIDATE_NOT_FOUND")
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Candidate not found", "ERR_CANDIDATE_NOT_FOUND")
 	}
 
+	output := newCandidateOutput(candidate)
+	if svc.messageRepository != nil && candidate.SentMessageID != "" {
+		message, err := svc.messageRepository.GetByID(ctx, candidate.SentMessageID)
+		if err != nil {
+			return nil, err
+		}
+		if message != nil {
+			output.SentMessageStatus = string(message.Status)
+			output.SentMessageStatusAt = message.StatusAt
+		}
+	}
+	return output, nil
+}
+
+// newCandidateOutput maps a candidate to the shape returned by the queries
+func newCandidateOutput(candidate *entities.Candidate) *GetByIDServiceOutput {
 	return &GetByIDServiceOutput{
 		ID:                                candidate.ID,
 		SheetID:                           candidate.SheetID,
@@ -62,5 +107,26 @@ func (svc *GetByIDService) Execute(ctx context.Context, input GetByIDServiceInpu
 		ColumnInterviewDateId:             candidate.ColumnInterviewDateId,
 		ColumnInterviewTimeId:             candidate.ColumnInterviewTimeId,
 		ColumnInterviewLinkId:             candidate.ColumnInterviewLinkId,
-	}, nil
+		Names:                             candidate.Names,
+		Surnames:                          candidate.Surnames,
+		DocumentType:                      candidate.DocumentType,
+		DocumentNumber:                    candidate.DocumentNumber,
+		Phone:                             candidate.Phone,
+		Email:                             candidate.Email,
+		BirthDate:                         candidate.BirthDate,
+		District:                          candidate.District,
+		AppliedPosition:                   candidate.AppliedPosition,
+		Store:                             candidate.Store,
+		Status:                            string(candidate.Status),
+		Response:                          candidate.Response,
+		ResponseAt:                        candidate.ResponseAt,
+		Confirmed:                         candidate.Confirmed,
+		SentAt:                            candidate.SentAt,
+		SentMessageID:                     candidate.SentMessageID,
+		InterviewDate:                     candidate.InterviewDate,
+		InterviewTime:                     candidate.InterviewTime,
+		InterviewLink:                     candidate.InterviewLink,
+		InterviewSlotID:                   candidate.InterviewSlotID,
+		CreatedAt:                         candidate.CreatedAt,
+	}
 }
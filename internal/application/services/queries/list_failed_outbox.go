@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+)
+
+// maxFailedOutbox bounds the listing; failed records are expected to be few
+// and retried or discarded promptly
+const maxFailedOutbox = 100
+
+type ListFailedOutboxServiceInput struct {
+}
+
+// OutboxRecordOutput describes an outbox record without its payload, which
+// may carry candidate data
+type OutboxRecordOutput struct {
+	ID            string                `json:"id"`
+	Type          string                `json:"type"`
+	AggregateID   string                `json:"aggregateId"`
+	Status        entities.OutboxStatus `json:"status"`
+	Attempts      int                   `json:"attempts"`
+	NextAttemptAt string                `json:"nextAttemptAt"`
+	LastError     string                `json:"lastError,omitempty"`
+	CreatedAt     string                `json:"createdAt"`
+}
+
+// ListFailedOutboxServiceOutput lists the records that exhausted their
+// attempts, oldest first
+type ListFailedOutboxServiceOutput struct {
+	Records []*OutboxRecordOutput `json:"records"`
+}
+
+type ListFailedOutboxService struct {
+	outboxRepository repositories.OutboxRepository
+}
+
+type ListFailedOutboxServiceConfig struct {
+	OutboxRepository repositories.OutboxRepository
+}
+
+func NewListFailedOutboxService(cfg ListFailedOutboxServiceConfig) *ListFailedOutboxService {
+	return &ListFailedOutboxService{
+		outboxRepository: cfg.OutboxRepository,
+	}
+}
+
+func (svc *ListFailedOutboxService) Execute(ctx context.Context, input ListFailedOutboxServiceInput) (*ListFailedOutboxServiceOutput, error) {
+	records, err := svc.outboxRepository.FindFailed(ctx, maxFailedOutbox)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListFailedOutboxServiceOutput{
+		Records: make([]*OutboxRecordOutput, 0, len(records)),
+	}
+	for _, record := range records {
+		output.Records = append(output.Records, &OutboxRecordOutput{
+			ID:            record.ID,
+			Type:          record.Type,
+			AggregateID:   record.AggregateID,
+			Status:        record.Status,
+			Attempts:      record.Attempts,
+			NextAttemptAt: record.NextAttemptAt,
+			LastError:     record.LastError,
+			CreatedAt:     record.CreatedAt,
+		})
+	}
+	return output, nil
+}
@@ -0,0 +1,69 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+)
+
+// maxMessages bounds the listing of a candidate
+const maxMessages = 50
+
+type ListMessagesServiceInput struct {
+	CandidateID string `json:"id" validate:"required,notblank"`
+}
+
+// MessageOutput is a message with its status history, oldest first
+type MessageOutput struct {
+	*entities.Message
+	History []entities.MessageStatusEntry `json:"history"`
+}
+
+// ListMessagesServiceOutput lists the messages newest first
+type ListMessagesServiceOutput struct {
+	CandidateID string           `json:"candidateId"`
+	Messages    []*MessageOutput `json:"messages"`
+}
+
+type ListMessagesService struct {
+	candidateRepository repositories.CandidateRepository
+	messageRepository   repositories.MessageRepository
+}
+
+type ListMessagesServiceConfig struct {
+	CandidateRepository repositories.CandidateRepository
+	MessageRepository   repositories.MessageRepository
+}
+
+func NewListMessagesService(cfg ListMessagesServiceConfig) *ListMessagesService {
+	return &ListMessagesService{
+		candidateRepository: cfg.CandidateRepository,
+		messageRepository:   cfg.MessageRepository,
+	}
+}
+
+func (svc *ListMessagesService) Execute(ctx context.Context, input ListMessagesServiceInput) (*ListMessagesServiceOutput, error) {
+	candidate, err := svc.candidateRepository.GetByID(ctx, input.CandidateID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate == nil {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Candidate not found", "ERR_CANDIDATE_NOT_FOUND")
+	}
+
+	messages, err := svc.messageRepository.FindByCandidate(ctx, candidate.ID, maxMessages)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListMessagesServiceOutput{
+		CandidateID: candidate.ID,
+		Messages:    make([]*MessageOutput, 0, len(messages)),
+	}
+	for _, message := range messages {
+		output.Messages = append(output.Messages, &MessageOutput{Message: message, History: message.History()})
+	}
+	return output, nil
+}
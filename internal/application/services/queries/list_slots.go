@@ -0,0 +1,76 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+)
+
+const (
+	// maxSlots bounds the listing of a store
+	maxSlots = 200
+	// defaultSlotDays is the range listed when to is not sent
+	defaultSlotDays = 14
+)
+
+// ListSlotsServiceInput selects the slots of a store starting between the
+// days from and to, both included. From defaults to today and to to two
+// weeks after from.
+type ListSlotsServiceInput struct {
+	Store string `json:"store" validate:"required,notblank"`
+	From  string `json:"from,omitempty" validate:"omitempty,lima_date"`
+	To    string `json:"to,omitempty" validate:"omitempty,lima_date"`
+}
+
+// ListSlotsServiceOutput lists the slots earliest first
+type ListSlotsServiceOutput struct {
+	Slots []*entities.InterviewSlot `json:"slots"`
+}
+
+type ListSlotsService struct {
+	config         *config.Config
+	slotRepository repositories.InterviewSlotRepository
+}
+
+type ListSlotsServiceConfig struct {
+	Config         *config.Config
+	SlotRepository repositories.InterviewSlotRepository
+}
+
+func NewListSlotsService(cfg ListSlotsServiceConfig) *ListSlotsService {
+	return &ListSlotsService{
+		config:         cfg.Config,
+		slotRepository: cfg.SlotRepository,
+	}
+}
+
+func (svc *ListSlotsService) Execute(ctx context.Context, input ListSlotsServiceInput) (*ListSlotsServiceOutput, error) {
+	location, err := time.LoadLocation(svc.config.TIME_ZONE)
+	if err != nil {
+		location = time.UTC
+	}
+
+	now := time.Now().In(location)
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	if input.From != "" {
+		from, _ = time.ParseInLocation("2006-01-02", input.From, location)
+	}
+	to := from.AddDate(0, 0, defaultSlotDays)
+	if input.To != "" {
+		to, _ = time.ParseInLocation("2006-01-02", input.To, location)
+		to = to.AddDate(0, 0, 1)
+	}
+	if !to.After(from) {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "to must not be before from", "ERR_INVALID_DATE_RANGE")
+	}
+
+	slots, err := svc.slotRepository.FindByStore(ctx, input.Store, from, to, maxSlots)
+	if err != nil {
+		return nil, err
+	}
+	return &ListSlotsServiceOutput{Slots: slots}, nil
+}
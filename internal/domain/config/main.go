@@ -1,6 +1,75 @@
 package config
 
+import "time"
+
 type Config struct {
   // Dynamo
   CANDIDATES_TABLE_NAME         string
-}
\ No newline at end of file
+  CANDIDATES_PHONE_INDEX        string
+  CANDIDATES_DOCUMENT_INDEX     string
+  IDEMPOTENCY_TABLE_NAME        string
+  OUTBOX_TABLE_NAME             string
+  OUTBOX_STATUS_INDEX           string
+  SLOTS_TABLE_NAME              string
+  SLOTS_STORE_INDEX             string
+  MESSAGES_TABLE_NAME           string
+  MESSAGES_CANDIDATE_INDEX      string
+
+  // Outbox
+  OUTBOX_MAX_ATTEMPTS           int
+
+  // Locale
+  TIME_ZONE                     string
+
+  // Errors
+  PROBLEM_TYPE_BASE_URL         string
+
+  // Encryption
+  ENCRYPTION_PROVIDER           string
+  ENCRYPTION_KMS_KEY_ID         string
+  ENCRYPTION_STATIC_KEY         string
+  BLIND_INDEX_SECRET_NAME       string
+
+  // Webhooks
+  WEBHOOK_SECRET_PREFIX         string
+  WEBHOOK_REPLAY_WINDOW         time.Duration
+  WHATSAPP_SECRET_NAME          string
+
+  // AI
+  AI_API_URL                    string
+  AI_MODEL                      string
+  AI_API_KEY_SECRET_NAME        string
+
+  // Sheets
+  SHEETS_PROVIDER               string
+  SMARTSHEET_API_URL            string
+  SMARTSHEET_TOKEN_SECRET_NAME  string
+
+  // Meetings
+  MEETINGS_PROVIDER             string
+  MEETINGS_LOCAL_URL            string
+  ZOOM_API_URL                  string
+  ZOOM_SECRET_NAME              string
+
+  // Messaging
+  MESSAGING_PROVIDER            string
+  WHATSAPP_API_URL              string
+  WHATSAPP_PHONE_NUMBER_ID      string
+  TWILIO_API_URL                string
+  TWILIO_SECRET_NAME            string
+  TWILIO_FROM                   string
+  TWILIO_STATUS_CALLBACK_URL    string
+  MESSAGING_FALLBACK_PROVIDER   string
+
+  // Events
+  EVENTS_PROVIDER               string
+  EVENT_BUS_NAME                string
+
+  // Follow-ups
+  FOLLOWUP_AFTER                time.Duration
+  FOLLOWUP_MAX_ATTEMPTS         int
+  FOLLOWUP_TARGET_ARN           string
+  SCHEDULER_PROVIDER            string
+  SCHEDULER_GROUP_NAME          string
+  SCHEDULER_ROLE_ARN            string
+}
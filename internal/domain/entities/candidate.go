@@ -1,5 +1,25 @@
 package entities
 
+import "fmt"
+
+const (
+	DocumentTypeDNI = "DNI"
+	DocumentTypeCE  = "CE"
+)
+
+// CandidateStatus tracks where the candidate is in the messaging flow
+type CandidateStatus string
+
+const (
+	StatusPending   CandidateStatus = "PENDING"
+	StatusResponded CandidateStatus = "RESPONDED"
+	StatusConfirmed CandidateStatus = "CONFIRMED"
+	StatusDeclined  CandidateStatus = "DECLINED"
+	// StatusNoResponse is set when the invitation and its follow-ups went
+	// unanswered; a later reply moves the candidate on as usual
+	StatusNoResponse CandidateStatus = "NO_RESPONSE"
+)
+
 type Candidate struct {
 	ID                                string `json:"id" dynamodbav:"id"`
 	CompositeKey                      string `json:"compositeKey" dynamodbav:"compositeKey"`
@@ -15,6 +35,42 @@ type Candidate struct {
 	ColumnInterviewTimeId             string `json:"columnInterviewTimeId" dynamodbav:"columnInterviewTimeId"`
 	ColumnInterviewLinkId             string `json:"columnInterviewLinkId" dynamodbav:"columnInterviewLinkId"`
 
+	// Profile. Fields tagged pii are encrypted at rest by the repository;
+	// documentKey is stored as a blind index and phone also gets phoneHash.
+	Names           string `json:"names" dynamodbav:"names" pii:"encrypt"`
+	Surnames        string `json:"surnames" dynamodbav:"surnames" pii:"encrypt"`
+	DocumentType    string `json:"documentType" dynamodbav:"documentType"`
+	DocumentNumber  string `json:"documentNumber" dynamodbav:"documentNumber" pii:"encrypt"`
+	DocumentKey     string `json:"documentKey" dynamodbav:"documentKey,omitempty" pii:"hash"`
+	Phone           string `json:"phone" dynamodbav:"phone,omitempty" pii:"encrypt,index=phoneHash"`
+	Email           string `json:"email,omitempty" dynamodbav:"email,omitempty" pii:"encrypt"`
+	BirthDate       string `json:"birthDate,omitempty" dynamodbav:"birthDate,omitempty" pii:"encrypt"`
+	District        string `json:"district,omitempty" dynamodbav:"district,omitempty"`
+	AppliedPosition string `json:"appliedPosition,omitempty" dynamodbav:"appliedPosition,omitempty"`
+	Store           string `json:"store,omitempty" dynamodbav:"store,omitempty"`
+
+	// Response of the candidate, mirrored to ColumnPostulantResponseId,
+	// ColumnPostulantDateTimeResponseId and ColumnPostulantConfirmedId
+	Status            CandidateStatus `json:"status" dynamodbav:"status,omitempty"`
+	Response          string          `json:"response,omitempty" dynamodbav:"response,omitempty" pii:"encrypt"`
+	ResponseAt        string          `json:"responseAt,omitempty" dynamodbav:"responseAt,omitempty"`
+	ResponseMessageID string          `json:"responseMessageId,omitempty" dynamodbav:"responseMessageId,omitempty"`
+	ResponseIntent    string          `json:"responseIntent,omitempty" dynamodbav:"responseIntent,omitempty"`
+	Confirmed         *bool           `json:"confirmed,omitempty" dynamodbav:"confirmed,omitempty"`
+
+	// Invitation and interview, mirrored to ColumnSendDateTimeId and
+	// ColumnInterview*Id. InterviewSlotID is set while the candidate holds a
+	// seat in a slot, whose date, time and link fill the interview fields.
+	// InterviewMeetingID is the meeting created for the link, if any.
+	// SentMessageID is the provider ID of the last message sent.
+	SentAt             string `json:"sentAt,omitempty" dynamodbav:"sentAt,omitempty"`
+	SentMessageID      string `json:"sentMessageId,omitempty" dynamodbav:"sentMessageId,omitempty"`
+	InterviewDate      string `json:"interviewDate,omitempty" dynamodbav:"interviewDate,omitempty"`
+	InterviewTime      string `json:"interviewTime,omitempty" dynamodbav:"interviewTime,omitempty"`
+	InterviewLink      string `json:"interviewLink,omitempty" dynamodbav:"interviewLink,omitempty"`
+	InterviewSlotID    string `json:"interviewSlotId,omitempty" dynamodbav:"interviewSlotId,omitempty"`
+	InterviewMeetingID string `json:"interviewMeetingId,omitempty" dynamodbav:"interviewMeetingId,omitempty"`
+
 	CreatedAt string  `json:"createdAt" dynamodbav:"createdAt"`
 	CreatedBy string  `json:"createdBy" dynamodbav:"createdBy"`
 	UpdatedAt *string `json:"updatedAt,omitempty" dynamodbav:"updatedAt,omitempty"`
@@ -23,3 +79,52 @@ type Candidate struct {
 	DeletedBy *string `json:"deletedBy,omitempty" dynamodbav:"deletedBy,omitempty"`
 	Deleted   bool    `json:"deleted" dynamodbav:"deleted"`
 }
+
+// AwaitingResponse reports whether the candidate has not replied to the
+// messages sent. Candidates created before statuses existed have none.
+func (c *Candidate) AwaitingResponse() bool {
+	return c.Status == "" || c.Status == StatusPending || c.Status == StatusNoResponse
+}
+
+// BuildDocumentKey is the partition key of the document GSI, e.g. "DNI#45678912"
+func BuildDocumentKey(documentType, documentNumber string) string {
+	return fmt.Sprintf("%s#%s", documentType, documentNumber)
+}
+
+// SheetColumns maps the attributes mirrored to the recruiters' sheet to the
+// column IDs of this candidate. Columns without ID are left out.
+func (c *Candidate) SheetColumns() map[string]string {
+	columns := map[string]string{
+		"sentAt":        c.ColumnSendDateTimeId,
+		"response":      c.ColumnPostulantResponseId,
+		"responseAt":    c.ColumnPostulantDateTimeResponseId,
+		"confirmed":     c.ColumnPostulantConfirmedId,
+		"interviewDate": c.ColumnInterviewDateId,
+		"interviewTime": c.ColumnInterviewTimeId,
+		"interviewLink": c.ColumnInterviewLinkId,
+	}
+	for attribute, columnID := range columns {
+		if columnID == "" {
+			delete(columns, attribute)
+		}
+	}
+	return columns
+}
+
+// SheetValues returns the current values of the attributes of SheetColumns,
+// as written to their columns
+func (c *Candidate) SheetValues() map[string]interface{} {
+	values := map[string]interface{}{
+		"sentAt":        c.SentAt,
+		"response":      c.Response,
+		"responseAt":    c.ResponseAt,
+		"confirmed":     "",
+		"interviewDate": c.InterviewDate,
+		"interviewTime": c.InterviewTime,
+		"interviewLink": c.InterviewLink,
+	}
+	if c.Confirmed != nil {
+		values["confirmed"] = *c.Confirmed
+	}
+	return values
+}
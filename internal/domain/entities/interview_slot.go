@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// SlotModality is how the interview of a slot takes place
+type SlotModality string
+
+const (
+	SlotInPerson SlotModality = "IN_PERSON"
+	SlotVirtual  SlotModality = "VIRTUAL"
+)
+
+// SlotTimeFormat is used for startAt: UTC and fixed width, so the store
+// index sorts slots by start time whatever the zone of the store
+const SlotTimeFormat = "2006-01-02T15:04:05Z"
+
+// InterviewSlot is a period in which a store interviews up to Capacity
+// candidates. Date, StartTime and EndTime are local to TimeZone, the zone of
+// the store; StartAt is the same start in UTC.
+type InterviewSlot struct {
+	ID        string       `json:"id" dynamodbav:"id"`
+	Store     string       `json:"store" dynamodbav:"store"`
+	TimeZone  string       `json:"timeZone" dynamodbav:"timeZone"`
+	Date      string       `json:"date" dynamodbav:"date"`
+	StartTime string       `json:"startTime" dynamodbav:"startTime"`
+	EndTime   string       `json:"endTime" dynamodbav:"endTime"`
+	StartAt   string       `json:"startAt" dynamodbav:"startAt"`
+	Modality  SlotModality `json:"modality" dynamodbav:"modality"`
+	// Link is the meeting of virtual slots and Location the address of
+	// in-person ones
+	Link     string `json:"link,omitempty" dynamodbav:"link,omitempty"`
+	Location string `json:"location,omitempty" dynamodbav:"location,omitempty"`
+
+	// Booked never exceeds Capacity: bookings are conditional writes
+	Capacity int `json:"capacity" dynamodbav:"capacity"`
+	Booked   int `json:"booked" dynamodbav:"booked"`
+
+	CreatedAt string `json:"createdAt" dynamodbav:"createdAt"`
+	CreatedBy string `json:"createdBy" dynamodbav:"createdBy"`
+}
+
+// NewInterviewSlot builds an empty slot of a store. The ID is derived from
+// the store, start and modality, so publishing the same slot twice is
+// detected instead of doubling its seats.
+func NewInterviewSlot(store string, start, end time.Time, modality SlotModality, capacity int) *InterviewSlot {
+	startAt := start.UTC().Format(SlotTimeFormat)
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s", store, startAt, modality)))
+
+	return &InterviewSlot{
+		ID:        hex.EncodeToString(sum[:16]),
+		Store:     store,
+		TimeZone:  start.Location().String(),
+		Date:      start.Format("2006-01-02"),
+		StartTime: start.Format("15:04"),
+		EndTime:   end.Format("15:04"),
+		StartAt:   startAt,
+		Modality:  modality,
+		Capacity:  capacity,
+	}
+}
+
+// Available returns the seats left
+func (s *InterviewSlot) Available() int {
+	if s.Booked >= s.Capacity {
+		return 0
+	}
+	return s.Capacity - s.Booked
+}
+
+// Start returns the start of the slot, or the zero time if StartAt is invalid
+func (s *InterviewSlot) Start() time.Time {
+	start, err := time.Parse(SlotTimeFormat, s.StartAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return start
+}
+
+// Duration returns the length of the slot, from StartTime to EndTime
+func (s *InterviewSlot) Duration() time.Duration {
+	start, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return 0
+	}
+	end, err := time.Parse("15:04", s.EndTime)
+	if err != nil || !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// MessageStatus is the delivery status of an outbound message
+type MessageStatus string
+
+const (
+	// MessageAccepted is the status of a message the provider accepted, before
+	// any callback
+	MessageAccepted  MessageStatus = "ACCEPTED"
+	MessageSent      MessageStatus = "SENT"
+	MessageDelivered MessageStatus = "DELIVERED"
+	MessageRead      MessageStatus = "READ"
+	MessageFailed    MessageStatus = "FAILED"
+)
+
+// Rank orders the statuses: a status only replaces a lower one, so callbacks
+// arriving out of order do not move a message back
+func (s MessageStatus) Rank() int {
+	switch s {
+	case MessageAccepted:
+		return 1
+	case MessageSent:
+		return 2
+	case MessageDelivered:
+		return 3
+	case MessageRead:
+		return 4
+	case MessageFailed:
+		return 5
+	}
+	return 0
+}
+
+// MessageTimeFormat is used for the times of messages: UTC and fixed width,
+// so the candidate index sorts messages by send time
+const MessageTimeFormat = "2006-01-02T15:04:05Z"
+
+// MessageStatusEntry is a status reported for a message, with the provider
+// error of failures
+type MessageStatusEntry struct {
+	Status     MessageStatus `json:"status" dynamodbav:"status"`
+	At         string        `json:"at" dynamodbav:"at"`
+	ErrorCode  string        `json:"errorCode,omitempty" dynamodbav:"errorCode,omitempty"`
+	ErrorTitle string        `json:"errorTitle,omitempty" dynamodbav:"errorTitle,omitempty"`
+}
+
+// Message is an outbound message to a candidate, keyed by the provider's
+// message ID. Statuses holds one entry per status reported, keyed by status,
+// so a callback delivered twice is recorded once; Status is the highest.
+type Message struct {
+	ID          string        `json:"id" dynamodbav:"id"`
+	CandidateID string        `json:"candidateId" dynamodbav:"candidateId"`
+	Channel     string        `json:"channel" dynamodbav:"channel"`
+	Template    string        `json:"template" dynamodbav:"template"`
+	SentAt      string        `json:"sentAt" dynamodbav:"sentAt"`
+	Status      MessageStatus `json:"status" dynamodbav:"status"`
+	StatusAt    string        `json:"statusAt" dynamodbav:"statusAt"`
+	StatusRank  int           `json:"-" dynamodbav:"statusRank"`
+
+	Statuses map[MessageStatus]MessageStatusEntry `json:"-" dynamodbav:"statuses"`
+
+	// FallbackOf is the failed message this one replaces, and
+	// FallbackMessageID the message sent over the fallback channel when this
+	// one failed. FallbackAt claims the fallback, so it is sent once.
+	FallbackOf        string `json:"fallbackOf,omitempty" dynamodbav:"fallbackOf,omitempty"`
+	FallbackMessageID string `json:"fallbackMessageId,omitempty" dynamodbav:"fallbackMessageId,omitempty"`
+	FallbackAt        string `json:"-" dynamodbav:"fallbackAt,omitempty"`
+}
+
+// NewMessage builds the record of a message accepted by the provider at now
+func NewMessage(id, candidateID, channel, template string, now time.Time) *Message {
+	at := now.UTC().Format(MessageTimeFormat)
+	return &Message{
+		ID:          id,
+		CandidateID: candidateID,
+		Channel:     channel,
+		Template:    template,
+		SentAt:      at,
+		Status:      MessageAccepted,
+		StatusAt:    at,
+		StatusRank:  MessageAccepted.Rank(),
+		Statuses: map[MessageStatus]MessageStatusEntry{
+			MessageAccepted: {Status: MessageAccepted, At: at},
+		},
+	}
+}
+
+// History returns the statuses reported, oldest first
+func (m *Message) History() []MessageStatusEntry {
+	history := make([]MessageStatusEntry, 0, len(m.Statuses))
+	for _, entry := range m.Statuses {
+		history = append(history, entry)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].At != history[j].At {
+			return history[i].At < history[j].At
+		}
+		return history[i].Status.Rank() < history[j].Status.Rank()
+	})
+	return history
+}
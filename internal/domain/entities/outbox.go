@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// OutboxStatus tracks the delivery of an outbox record
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "PENDING"
+	OutboxDelivered OutboxStatus = "DELIVERED"
+	// OutboxFailed records exhausted their attempts and wait for a manual retry
+	OutboxFailed OutboxStatus = "FAILED"
+)
+
+// Outbox record types, one per side effect
+const (
+	OutboxSheetUpdate      = "sheet.update"
+	OutboxMeetingCancel    = "meeting.cancel"
+	OutboxFollowUpSchedule = "followup.schedule"
+)
+
+// OutboxTimeFormat is used for nextAttemptAt: UTC and fixed width, so the
+// status index sorts records by due time
+const OutboxTimeFormat = "2006-01-02T15:04:05Z"
+
+// OutboxRecord is a side effect written in the same transaction as the
+// entity change that causes it, and delivered afterwards by the processor
+type OutboxRecord struct {
+	ID          string `json:"id" dynamodbav:"id"`
+	Type        string `json:"type" dynamodbav:"type"`
+	AggregateID string `json:"aggregateId" dynamodbav:"aggregateId"`
+	// Payload is the JSON body of the side effect. It may carry candidate
+	// data, so it is encrypted at rest like the candidate itself.
+	Payload string `json:"payload" dynamodbav:"payload" pii:"encrypt"`
+
+	Status        OutboxStatus `json:"status" dynamodbav:"status"`
+	Attempts      int          `json:"attempts" dynamodbav:"attempts"`
+	NextAttemptAt string       `json:"nextAttemptAt" dynamodbav:"nextAttemptAt"`
+	LastError     string       `json:"lastError,omitempty" dynamodbav:"lastError,omitempty"`
+	DeliveredAt   string       `json:"deliveredAt,omitempty" dynamodbav:"deliveredAt,omitempty"`
+	// ExpiresAt is the TTL of delivered records, in Unix seconds
+	ExpiresAt int64 `json:"-" dynamodbav:"expiresAt,omitempty"`
+
+	CreatedAt string `json:"createdAt" dynamodbav:"createdAt"`
+}
+
+// NewOutboxRecord builds a pending record due now with payload as JSON
+func NewOutboxRecord(id, recordType, aggregateID string, payload any, now time.Time) (*OutboxRecord, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("outbox payload of %s: %w", recordType, err)
+	}
+
+	return &OutboxRecord{
+		ID:            id,
+		Type:          recordType,
+		AggregateID:   aggregateID,
+		Payload:       string(body),
+		Status:        OutboxPending,
+		NextAttemptAt: now.UTC().Format(OutboxTimeFormat),
+		CreatedAt:     now.UTC().Format(OutboxTimeFormat),
+	}, nil
+}
+
+// DecodePayload unmarshals the payload into v
+func (r *OutboxRecord) DecodePayload(v any) error {
+	return json.Unmarshal([]byte(r.Payload), v)
+}
+
+// SheetUpdatePayload is the payload of OutboxSheetUpdate records. Version is
+// the updatedAt of the candidate change that queued it.
+type SheetUpdatePayload struct {
+	SheetID string         `json:"sheetId"`
+	RowID   string         `json:"rowId"`
+	Cells   map[string]any `json:"cells"`
+	Version string         `json:"version,omitempty"`
+}
+
+// MeetingCancelPayload is the payload of OutboxMeetingCancel records
+type MeetingCancelPayload struct {
+	MeetingID string `json:"meetingId"`
+}
+
+// FollowUpPayload is the payload of OutboxFollowUpSchedule records and the
+// input of the follow-up job they schedule at DueAt: check whether MessageID,
+// sent to the candidate, is still unanswered. Attempt counts the checks,
+// starting at 1.
+type FollowUpPayload struct {
+	CandidateID string `json:"candidateId"`
+	MessageID   string `json:"messageId"`
+	Attempt     int    `json:"attempt"`
+	DueAt       string `json:"dueAt"`
+}
@@ -0,0 +1,90 @@
+package events
+
+import "github.com/Yolto7/api-candidates/internal/domain/entities"
+
+const (
+	CandidateCreatedName       = "CandidateCreated"
+	CandidateStatusChangedName = "CandidateStatusChanged"
+	CandidateDeletedName       = "CandidateDeleted"
+)
+
+// CandidateImage is the state of a candidate carried by events. Personal
+// data is left out on purpose: consumers that need it read the candidate
+// from the API, which applies access control and decryption.
+type CandidateImage struct {
+	ID              string                   `json:"id"`
+	SheetID         string                   `json:"sheetId"`
+	RowID           string                   `json:"rowId"`
+	DocumentType    string                   `json:"documentType,omitempty"`
+	District        string                   `json:"district,omitempty"`
+	AppliedPosition string                   `json:"appliedPosition,omitempty"`
+	Store           string                   `json:"store,omitempty"`
+	Status          entities.CandidateStatus `json:"status,omitempty"`
+	ResponseIntent  string                   `json:"responseIntent,omitempty"`
+	ResponseAt      string                   `json:"responseAt,omitempty"`
+	Confirmed       *bool                    `json:"confirmed,omitempty"`
+	SentAt          string                   `json:"sentAt,omitempty"`
+	InterviewDate   string                   `json:"interviewDate,omitempty"`
+	InterviewTime   string                   `json:"interviewTime,omitempty"`
+	InterviewLink   string                   `json:"interviewLink,omitempty"`
+	Deleted         bool                     `json:"deleted"`
+	CreatedAt       string                   `json:"createdAt"`
+	UpdatedAt       *string                  `json:"updatedAt,omitempty"`
+}
+
+// NewCandidateImage copies the non-personal attributes of a candidate
+func NewCandidateImage(c *entities.Candidate) *CandidateImage {
+	if c == nil {
+		return nil
+	}
+	return &CandidateImage{
+		ID:              c.ID,
+		SheetID:         c.SheetID,
+		RowID:           c.RowID,
+		DocumentType:    c.DocumentType,
+		District:        c.District,
+		AppliedPosition: c.AppliedPosition,
+		Store:           c.Store,
+		Status:          c.Status,
+		ResponseIntent:  c.ResponseIntent,
+		ResponseAt:      c.ResponseAt,
+		Confirmed:       c.Confirmed,
+		SentAt:          c.SentAt,
+		InterviewDate:   c.InterviewDate,
+		InterviewTime:   c.InterviewTime,
+		InterviewLink:   c.InterviewLink,
+		Deleted:         c.Deleted,
+		CreatedAt:       c.CreatedAt,
+		UpdatedAt:       c.UpdatedAt,
+	}
+}
+
+// CandidateCreated is published when a candidate is stored
+type CandidateCreated struct {
+	Metadata
+	After *CandidateImage `json:"after"`
+}
+
+func (CandidateCreated) Name() string { return CandidateCreatedName }
+
+// CandidateStatusChanged is published when the status of a candidate
+// changes, e.g. from PENDING to CONFIRMED after a reply
+type CandidateStatusChanged struct {
+	Metadata
+	From   entities.CandidateStatus `json:"from"`
+	To     entities.CandidateStatus `json:"to"`
+	Before *CandidateImage          `json:"before"`
+	After  *CandidateImage          `json:"after"`
+}
+
+func (CandidateStatusChanged) Name() string { return CandidateStatusChangedName }
+
+// CandidateDeleted is published when a candidate is removed or flagged as
+// deleted. After is nil when the item was removed.
+type CandidateDeleted struct {
+	Metadata
+	Before *CandidateImage `json:"before"`
+	After  *CandidateImage `json:"after,omitempty"`
+}
+
+func (CandidateDeleted) Name() string { return CandidateDeletedName }
@@ -0,0 +1,20 @@
+package events
+
+import "time"
+
+// Event is a fact about an aggregate, published for other services
+type Event interface {
+	// Name is the event type, e.g. "CandidateCreated"
+	Name() string
+	Meta() Metadata
+}
+
+// Metadata carries the fields shared by every event. ID is unique per
+// occurrence and stable across redeliveries, so consumers can deduplicate.
+type Metadata struct {
+	ID          string    `json:"id"`
+	AggregateID string    `json:"aggregateId"`
+	OccurredAt  time.Time `json:"occurredAt"`
+}
+
+func (m Metadata) Meta() Metadata { return m }
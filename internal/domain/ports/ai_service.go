@@ -2,8 +2,36 @@ package ports
 
 import "context"
 
+// CandidateIntent is what the candidate means with a reply
+type CandidateIntent string
+
+const (
+	IntentConfirm    CandidateIntent = "CONFIRM"
+	IntentDecline    CandidateIntent = "DECLINE"
+	IntentReschedule CandidateIntent = "RESCHEDULE"
+	IntentQuestion   CandidateIntent = "QUESTION"
+	IntentUnknown    CandidateIntent = "UNKNOWN"
+)
+
+// Confirmed maps the intent to the confirmation column: true for CONFIRM,
+// false for DECLINE and nil otherwise
+func (i CandidateIntent) Confirmed() *bool {
+	var confirmed bool
+	switch i {
+	case IntentConfirm:
+		confirmed = true
+	case IntentDecline:
+		confirmed = false
+	default:
+		return nil
+	}
+	return &confirmed
+}
+
 type AnalyzeCandidateRequest struct {
 	Candidate 			string
+	// Message is the reply of the candidate to be analyzed
+	Message           string
 	CurrentDateTime   string
 	TimeZone          string
 }
@@ -16,6 +44,10 @@ type DetectedVisit struct {
 
 type AnalyzeCandidateResponse struct {
 	Visits []DetectedVisit
+	Intent    CandidateIntent
+	// Confirmed is nil when the reply neither confirms nor declines
+	Confirmed *bool
+	Summary   string
 }
 
 type AIService interface {
@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+)
+
+// EventDispatcher runs the in-process reactions to domain events. Services
+// call it after their changes are committed; it never fails the caller.
+type EventDispatcher interface {
+	Dispatch(ctx context.Context, events ...events.Event)
+}
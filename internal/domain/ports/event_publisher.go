@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+)
+
+// EventPublisher delivers domain events to other services. Publish fails if
+// any event could not be delivered; delivery is at least once.
+type EventPublisher interface {
+	Publish(ctx context.Context, events ...events.Event) error
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"time"
+)
+
+// Meeting is a video meeting created by a MeetingProvider. ID is the
+// provider's identifier, used to cancel it; URL is the link for attendees.
+type Meeting struct {
+	ID  string
+	URL string
+}
+
+// MeetingProvider creates the video meetings of virtual interviews
+type MeetingProvider interface {
+	// CreateMeeting schedules a meeting; attendees are e-mail addresses and
+	// may be empty
+	CreateMeeting(ctx context.Context, title string, start time.Time, duration time.Duration, attendees []string) (*Meeting, error)
+	// CancelMeeting deletes a meeting. Cancelling a meeting that no longer
+	// exists succeeds.
+	CancelMeeting(ctx context.Context, meetingID string) error
+}
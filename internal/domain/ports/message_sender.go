@@ -0,0 +1,41 @@
+package ports
+
+import "context"
+
+// Message channels
+const (
+	ChannelWhatsApp = "WHATSAPP"
+	ChannelSMS      = "SMS"
+)
+
+// ApprovedTemplate is a WhatsApp template approved by Meta, with its body
+// parameters in order. Business-initiated WhatsApp messages are only
+// delivered as approved templates; plain text is accepted only within 24
+// hours of the last message of the candidate.
+type ApprovedTemplate struct {
+	Name     string
+	Language string
+	Params   []string
+}
+
+// OutboundMessage is a message to a candidate. Text is always set; Template,
+// if any, is the same content as a WhatsApp approved template and is
+// ignored by channels other than WhatsApp.
+type OutboundMessage struct {
+	// To is an E.164 phone number
+	To       string
+	Text     string
+	Template *ApprovedTemplate
+}
+
+// SentMessage is a message accepted by the provider. ID is the provider's
+// message ID, reported back in delivery status callbacks.
+type SentMessage struct {
+	ID      string
+	Channel string
+}
+
+// MessageSender delivers messages to candidates over one channel
+type MessageSender interface {
+	Send(ctx context.Context, msg OutboundMessage) (*SentMessage, error)
+}
@@ -0,0 +1,26 @@
+package ports
+
+import "context"
+
+// SheetCell is a cell of a sheet row. Value is the raw value (string, number
+// or bool); DisplayValue is the text shown to the recruiters.
+type SheetCell struct {
+	ColumnID     string
+	Value        any
+	DisplayValue string
+}
+
+// SheetRow is a row of a recruiters' sheet, with its cells keyed by column ID
+type SheetRow struct {
+	ID      string
+	SheetID string
+	Cells   map[string]SheetCell
+}
+
+// SheetClient reads and writes the rows of the recruiters' sheet. IDs are the
+// ones stored on the candidate (SheetID, RowID and Column*Id).
+type SheetClient interface {
+	// UpdateCells writes values, keyed by column ID, to a row
+	UpdateCells(ctx context.Context, sheetID, rowID string, values map[string]any) error
+	GetRow(ctx context.Context, sheetID, rowID string) (*SheetRow, error)
+}
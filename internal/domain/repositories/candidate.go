@@ -8,7 +8,29 @@ import (
 
 type CandidateRepository interface {
 	GetByID(ctx context.Context, id string) (*entities.Candidate, error)
-	Create(ctx context.Context, candidate *entities.Candidate) error
-	Update(ctx context.Context, id string, updates map[string]interface{}) error
+	// FindByPhone returns the non-deleted candidates with an E.164 phone,
+	// most recent first
+	FindByPhone(ctx context.Context, phone string) ([]*entities.Candidate, error)
+	// FindByDocument returns the non-deleted candidates with a document, in
+	// no particular order
+	FindByDocument(ctx context.Context, documentType, number string) ([]*entities.Candidate, error)
+	// Create and Update write the outbox records, if any, in the same
+	// transaction as the candidate
+	Create(ctx context.Context, candidate *entities.Candidate, outbox ...*entities.OutboxRecord) error
+	Update(ctx context.Context, id string, updates map[string]interface{}, outbox ...*entities.OutboxRecord) error
+	// UpdateIf applies updates like Update only if the candidate exists and
+	// meets guard; otherwise it fails with CONFLICT ERR_CANDIDATE_CHANGED
+	UpdateIf(ctx context.Context, id string, guard UpdateGuard, updates map[string]interface{}, outbox ...*entities.OutboxRecord) error
+	// Delete fails with ERR_BOOKING_CHANGED when the candidate holds an
+	// interview booking; see InterviewSlotRepository.DeleteBooked
 	Delete(ctx context.Context, id string) error
 }
+
+// UpdateGuard lists what a conditional update requires of the candidate, as
+// checked when it is written
+type UpdateGuard struct {
+	// NotBooked requires the candidate to hold no interview booking
+	NotBooked bool
+	// NotResponded requires no reply to be recorded (responseAt)
+	NotResponded bool
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+)
+
+type InterviewSlotRepository interface {
+	GetByID(ctx context.Context, id string) (*entities.InterviewSlot, error)
+	// Create returns false, without error, if the slot was already published
+	Create(ctx context.Context, slot *entities.InterviewSlot) (bool, error)
+	// FindByStore returns the slots of a store starting in [from, to),
+	// earliest first
+	FindByStore(ctx context.Context, store string, from, to time.Time, limit int) ([]*entities.InterviewSlot, error)
+	// Book takes a seat of the slot and applies candidateUpdates to the
+	// candidate in one transaction, with the outbox records if any. It fails
+	// with ERR_SLOT_FULL when no seat is left and with
+	// ERR_CANDIDATE_ALREADY_BOOKED when the candidate holds a booking.
+	Book(ctx context.Context, slotID, candidateID string, candidateUpdates map[string]interface{}, outbox ...*entities.OutboxRecord) error
+	// Cancel releases the seat the candidate holds in the slot, removes its
+	// interviewSlotId and interviewMeetingId and applies candidateUpdates in
+	// one transaction. It fails with ERR_BOOKING_CHANGED when the candidate no
+	// longer holds that seat.
+	Cancel(ctx context.Context, slotID, candidateID string, candidateUpdates map[string]interface{}, outbox ...*entities.OutboxRecord) error
+	// DeleteBooked deletes the candidate and releases the seat it holds in
+	// the slot in one transaction, with the outbox records if any. It fails
+	// with ERR_BOOKING_CHANGED when the candidate no longer holds that seat.
+	DeleteBooked(ctx context.Context, slotID, candidateID string, outbox ...*entities.OutboxRecord) error
+}
@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+)
+
+type MessageRepository interface {
+	Create(ctx context.Context, message *entities.Message) error
+	GetByID(ctx context.Context, id string) (*entities.Message, error)
+	// FindByCandidate returns the messages of a candidate, newest first
+	FindByCandidate(ctx context.Context, candidateID string, limit int) ([]*entities.Message, error)
+	// RecordStatus adds entry to the history of the message, unless its status
+	// was already recorded, and makes it the current status if it ranks
+	// higher. It returns the message updated, or nil if it is unknown.
+	RecordStatus(ctx context.Context, id string, entry entities.MessageStatusEntry) (*entities.Message, error)
+	// ClaimFallback marks the fallback of a failed message as in progress. It
+	// returns false, without error, if it was already claimed.
+	ClaimFallback(ctx context.Context, id string) (bool, error)
+	// CompleteFallback records the message sent over the fallback channel
+	CompleteFallback(ctx context.Context, id, fallbackMessageID string) error
+	// ReleaseFallback undoes a claim whose fallback could not be sent
+	ReleaseFallback(ctx context.Context, id string) error
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+)
+
+// OutboxRepository reads and updates the delivery state of outbox records.
+// Records are created by the repositories of the entities, in the same
+// transaction as the change that causes them.
+type OutboxRepository interface {
+	GetByID(ctx context.Context, id string) (*entities.OutboxRecord, error)
+	// FindDue returns pending records whose next attempt is due at now,
+	// oldest first
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxRecord, error)
+	// FindFailed returns the records that exhausted their attempts
+	FindFailed(ctx context.Context, limit int) ([]*entities.OutboxRecord, error)
+	// Claim counts an attempt and hides the record from FindDue until
+	// leaseUntil. It returns false if another processor claimed it first.
+	Claim(ctx context.Context, record *entities.OutboxRecord, leaseUntil time.Time) (bool, error)
+	MarkDelivered(ctx context.Context, id string, at time.Time) error
+	MarkRetry(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id string, lastError string) error
+	// Requeue moves a failed record back to pending with its attempts reset
+	Requeue(ctx context.Context, id string, now time.Time) error
+}
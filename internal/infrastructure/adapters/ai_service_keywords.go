@@ -0,0 +1,86 @@
+package adapters
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+)
+
+// keywordRules are checked in order; the first rule with a matching phrase
+// wins. Decline and reschedule come first so that "no puedo, ¿otro día?" is
+// not read as a confirmation because it contains "puedo".
+var keywordRules = []struct {
+	intent  ports.CandidateIntent
+	phrases []string
+}{
+	{ports.IntentReschedule, []string{"otro dia", "otra fecha", "otra hora", "otro horario", "reprogramar", "cambiar la hora", "cambiar la fecha", "mas tarde", "manana no"}},
+	{ports.IntentDecline, []string{"no puedo", "no podre", "no asistire", "no ire", "no me interesa", "ya no", "no gracias", "cancelar"}},
+	{ports.IntentConfirm, []string{"si", "confirmo", "confirmado", "ok", "okay", "de acuerdo", "claro", "perfecto", "ahi estare", "asistire", "alli estare", "listo", "dale", "yes"}},
+	// A bare "no" only declines when nothing above matched, e.g. not in
+	// "si, no hay problema"
+	{ports.IntentDecline, []string{"no"}},
+	{ports.IntentQuestion, []string{"?", "donde", "cual", "como", "que hora", "cuando", "direccion"}},
+}
+
+// KeywordAIService classifies replies with Spanish keyword rules. It is the
+// fallback when no language model is configured or the model fails.
+type KeywordAIService struct{}
+
+func NewKeywordAIService() *KeywordAIService {
+	return &KeywordAIService{}
+}
+
+func (s *KeywordAIService) AnalyzeCandidate(_ context.Context, req ports.AnalyzeCandidateRequest) (*ports.AnalyzeCandidateResponse, error) {
+	text := normalizeReply(req.Message)
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	padded := " " + strings.Join(words, " ") + " "
+
+	intent := ports.IntentUnknown
+	for _, rule := range keywordRules {
+		if matchesAny(padded, text, rule.phrases) {
+			intent = rule.intent
+			break
+		}
+	}
+
+	return &ports.AnalyzeCandidateResponse{
+		Intent:    intent,
+		Confirmed: intent.Confirmed(),
+		Summary:   "keyword match",
+	}, nil
+}
+
+func matchesAny(padded, raw string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if phrase == "?" {
+			if strings.Contains(raw, "?") {
+				return true
+			}
+			continue
+		}
+		if strings.Contains(padded, " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeReply(message string) string {
+	// transform.Chain keeps state, so a new one is built per call
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(stripAccents, strings.ToLower(message))
+	if err != nil {
+		normalized = strings.ToLower(message)
+	}
+	return strings.TrimSpace(normalized)
+}
+
+var _ ports.AIService = (*KeywordAIService)(nil)
@@ -0,0 +1,170 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+)
+
+const (
+	defaultAITimeout = 8 * time.Second
+	maxAIResponse    = 64 << 10
+)
+
+const analyzeCandidatePrompt = `Eres un asistente de reclutamiento de KFC Perú. Un postulante respondió por WhatsApp a la invitación a una entrevista.
+Clasifica su respuesta y contesta solo con un JSON de la forma:
+{"intent": "CONFIRM" | "DECLINE" | "RESCHEDULE" | "QUESTION" | "UNKNOWN", "summary": "<resumen en una oración>"}
+CONFIRM: acepta asistir. DECLINE: no asistirá o no le interesa. RESCHEDULE: pide otra fecha u hora. QUESTION: hace una consulta sin decidir.`
+
+// OpenAIServiceConfig configures an OpenAI-compatible chat completions API
+type OpenAIServiceConfig struct {
+	BaseURL string
+	Model   string
+	// APIKey resolves the bearer token, usually from Secrets Manager
+	APIKey     func(ctx context.Context) (string, error)
+	HTTPClient *http.Client
+	// Fallback answers when the model cannot be reached or replies with an
+	// unexpected format
+	Fallback ports.AIService
+}
+
+type OpenAIService struct {
+	logger   logger.Logger
+	baseURL  string
+	model    string
+	apiKey   func(ctx context.Context) (string, error)
+	client   *http.Client
+	fallback ports.AIService
+}
+
+func NewOpenAIService(logger logger.Logger, cfg OpenAIServiceConfig) *OpenAIService {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultAITimeout}
+	}
+	fallback := cfg.Fallback
+	if fallback == nil {
+		fallback = NewKeywordAIService()
+	}
+
+	return &OpenAIService{
+		logger:   logger,
+		baseURL:  strings.TrimSuffix(cfg.BaseURL, "/"),
+		model:    cfg.Model,
+		apiKey:   cfg.APIKey,
+		client:   client,
+		fallback: fallback,
+	}
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string            `json:"model"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+type analysis struct {
+	Intent  ports.CandidateIntent `json:"intent"`
+	Summary string                `json:"summary"`
+}
+
+func (s *OpenAIService) AnalyzeCandidate(ctx context.Context, req ports.AnalyzeCandidateRequest) (*ports.AnalyzeCandidateResponse, error) {
+	result, err := s.complete(ctx, req)
+	if err != nil {
+		s.logger.Warn(map[string]any{
+			"msg":   "AI analysis failed, using keyword fallback",
+			"error": err.Error(),
+		})
+		return s.fallback.AnalyzeCandidate(ctx, req)
+	}
+
+	return &ports.AnalyzeCandidateResponse{
+		Intent:    result.Intent,
+		Confirmed: result.Intent.Confirmed(),
+		Summary:   result.Summary,
+	}, nil
+}
+
+func (s *OpenAIService) complete(ctx context.Context, req ports.AnalyzeCandidateRequest) (*analysis, error) {
+	apiKey, err := s.apiKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve API key: %w", err)
+	}
+
+	user := fmt.Sprintf("Fecha y hora actual: %s (%s)\nPostulante: %s\nRespuesta: %s",
+		req.CurrentDateTime, req.TimeZone, req.Candidate, req.Message)
+	body, err := json.Marshal(chatRequest{
+		Model: s.model,
+		Messages: []chatMessage{
+			{Role: "system", Content: analyzeCandidatePrompt},
+			{Role: "user", Content: user},
+		},
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+
+	res, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxAIResponse))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("chat completions returned status %d", res.StatusCode)
+	}
+
+	var completion chatResponse
+	if err := json.Unmarshal(raw, &completion); err != nil {
+		return nil, fmt.Errorf("invalid chat completions response: %w", err)
+	}
+	if len(completion.Choices) == 0 {
+		return nil, fmt.Errorf("chat completions returned no choices")
+	}
+
+	var result analysis
+	if err := json.Unmarshal([]byte(completion.Choices[0].Message.Content), &result); err != nil {
+		return nil, fmt.Errorf("model did not return JSON: %w", err)
+	}
+	switch result.Intent {
+	case ports.IntentConfirm, ports.IntentDecline, ports.IntentReschedule, ports.IntentQuestion, ports.IntentUnknown:
+	default:
+		return nil, fmt.Errorf("model returned unknown intent %q", result.Intent)
+	}
+
+	return &result, nil
+}
+
+var _ ports.AIService = (*OpenAIService)(nil)
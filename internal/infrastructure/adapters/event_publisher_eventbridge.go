@@ -0,0 +1,95 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// PutEvents accepts up to 10 entries per call
+const maxEventBridgeEntries = 10
+
+// EventBridgePublisher implements ports.EventPublisher with EventBridge.
+// Each event becomes an entry with the event name as detail-type and the
+// event as detail.
+type EventBridgePublisher struct {
+	logger  logger.Logger
+	client  *eventbridge.Client
+	busName string
+	source  string
+}
+
+func NewEventBridgePublisher(logger logger.Logger, client *eventbridge.Client, busName, source string) *EventBridgePublisher {
+	return &EventBridgePublisher{
+		logger:  logger,
+		client:  client,
+		busName: busName,
+		source:  source,
+	}
+}
+
+func (p *EventBridgePublisher) Publish(ctx context.Context, evts ...events.Event) error {
+	for start := 0; start < len(evts); start += maxEventBridgeEntries {
+		end := min(start+maxEventBridgeEntries, len(evts))
+		if err := p.put(ctx, evts[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *EventBridgePublisher) put(ctx context.Context, evts []events.Event) error {
+	entries := make([]types.PutEventsRequestEntry, 0, len(evts))
+	for _, event := range evts {
+		detail, err := json.Marshal(event)
+		if err != nil {
+			return errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encode event "+event.Name(), "EVENTS_ERROR")
+		}
+		entries = append(entries, types.PutEventsRequestEntry{
+			EventBusName: aws.String(p.busName),
+			Source:       aws.String(p.source),
+			DetailType:   aws.String(event.Name()),
+			Detail:       aws.String(string(detail)),
+			Time:         aws.Time(event.Meta().OccurredAt),
+		})
+	}
+
+	res, err := p.client.PutEvents(ctx, &eventbridge.PutEventsInput{Entries: entries})
+	if err != nil {
+		p.logger.Error(utils.NewSafeError(err, "Error in EventBridgePublisher.Publish: PutEvents failed"))
+		return errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Failed to publish events", "EVENTS_UNAVAILABLE")
+	}
+
+	// PutEvents is not atomic: some entries may fail while others succeed.
+	// The whole call is reported as failed so the caller retries; consumers
+	// discard the duplicates by event ID.
+	if res.FailedEntryCount > 0 {
+		for i, entry := range res.Entries {
+			if entry.ErrorCode == nil {
+				continue
+			}
+			p.logger.Error(map[string]any{
+				"msg":       "Event rejected by EventBridge",
+				"eventId":   evts[i].Meta().ID,
+				"name":      evts[i].Name(),
+				"errorCode": aws.ToString(entry.ErrorCode),
+				"error":     aws.ToString(entry.ErrorMessage),
+			})
+		}
+		cause := fmt.Errorf("%d of %d events rejected", res.FailedEntryCount, len(entries))
+		return errorCustom.Wrap(cause, errorCustom.SERVICE_UNAVAILABLE, "Failed to publish events", "EVENTS_UNAVAILABLE")
+	}
+	return nil
+}
+
+var _ ports.EventPublisher = (*EventBridgePublisher)(nil)
@@ -0,0 +1,36 @@
+package adapters
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+)
+
+// MemoryEventPublisher keeps published events in memory. It is meant for
+// local runs and tests.
+type MemoryEventPublisher struct {
+	mu     sync.Mutex
+	events []events.Event
+}
+
+func NewMemoryEventPublisher() *MemoryEventPublisher {
+	return &MemoryEventPublisher{}
+}
+
+func (p *MemoryEventPublisher) Publish(_ context.Context, evts ...events.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, evts...)
+	return nil
+}
+
+// Events returns the published events in order
+func (p *MemoryEventPublisher) Events() []events.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]events.Event(nil), p.events...)
+}
+
+var _ ports.EventPublisher = (*MemoryEventPublisher)(nil)
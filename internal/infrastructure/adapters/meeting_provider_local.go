@@ -0,0 +1,69 @@
+package adapters
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+)
+
+const DefaultLocalMeetingURL = "https://meet.local.kfc-rec.test"
+
+// LocalMeetingProvider generates fake meetings for local runs and tests. The
+// ID is derived from the title, start and attendees, so the same interview
+// always gets the same URL, and every call is recorded.
+type LocalMeetingProvider struct {
+	baseURL string
+
+	mu        sync.Mutex
+	created   []*ports.Meeting
+	cancelled []string
+}
+
+func NewLocalMeetingProvider(baseURL string) *LocalMeetingProvider {
+	if baseURL == "" {
+		baseURL = DefaultLocalMeetingURL
+	}
+	return &LocalMeetingProvider{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (p *LocalMeetingProvider) CreateMeeting(_ context.Context, title string, start time.Time, _ time.Duration, attendees []string) (*ports.Meeting, error) {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s", title, start.UTC().Format(time.RFC3339), strings.Join(attendees, ","))))
+	id := hex.EncodeToString(sum[:8])
+	meeting := &ports.Meeting{ID: id, URL: p.baseURL + "/" + id}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.created = append(p.created, meeting)
+	return meeting, nil
+}
+
+func (p *LocalMeetingProvider) CancelMeeting(_ context.Context, meetingID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cancelled = append(p.cancelled, meetingID)
+	return nil
+}
+
+// Created returns the meetings created, in order
+func (p *LocalMeetingProvider) Created() []*ports.Meeting {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*ports.Meeting(nil), p.created...)
+}
+
+// Cancelled returns the IDs of the meetings cancelled, in order
+func (p *LocalMeetingProvider) Cancelled() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.cancelled...)
+}
+
+var _ ports.MeetingProvider = (*LocalMeetingProvider)(nil)
@@ -0,0 +1,254 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+const (
+	DefaultZoomURL      = "https://api.zoom.us/v2"
+	DefaultZoomOAuthURL = "https://zoom.us/oauth/token"
+	maxZoomBody         = 1 << 20
+	// zoomTokenMargin renews the access token before it expires
+	zoomTokenMargin = time.Minute
+)
+
+// ZoomCredentials are the Server-to-Server OAuth credentials of the Zoom app
+type ZoomCredentials struct {
+	AccountID    string `json:"accountId"`
+	ClientID     string `json:"clientId"`
+	ClientSecret string `json:"clientSecret"`
+}
+
+// ZoomMeetingProvider implements ports.MeetingProvider with the Zoom REST
+// API. Meetings are created for the user that owns the app.
+type ZoomMeetingProvider struct {
+	logger      logger.Logger
+	client      *http.Client
+	baseURL     string
+	oauthURL    string
+	credentials func(ctx context.Context) (*ZoomCredentials, error)
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewZoomMeetingProvider builds the adapter on a client from
+// adapters.NewHTTPClient. credentials resolves the OAuth app credentials,
+// usually from Secrets Manager; access tokens are requested and cached here.
+func NewZoomMeetingProvider(logger logger.Logger, client *http.Client, baseURL string, credentials func(ctx context.Context) (*ZoomCredentials, error)) *ZoomMeetingProvider {
+	if baseURL == "" {
+		baseURL = DefaultZoomURL
+	}
+	return &ZoomMeetingProvider{
+		logger:      logger,
+		client:      client,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		oauthURL:    DefaultZoomOAuthURL,
+		credentials: credentials,
+	}
+}
+
+type zoomInvitee struct {
+	Email string `json:"email"`
+}
+
+type zoomSettings struct {
+	WaitingRoom     bool          `json:"waiting_room"`
+	MeetingInvitees []zoomInvitee `json:"meeting_invitees,omitempty"`
+}
+
+type zoomMeetingRequest struct {
+	Topic     string       `json:"topic"`
+	Type      int          `json:"type"`
+	StartTime string       `json:"start_time"`
+	Duration  int          `json:"duration"`
+	Timezone  string       `json:"timezone"`
+	Settings  zoomSettings `json:"settings"`
+}
+
+type zoomMeeting struct {
+	ID      int64  `json:"id"`
+	JoinURL string `json:"join_url"`
+}
+
+type zoomError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type zoomToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+func (p *ZoomMeetingProvider) CreateMeeting(ctx context.Context, title string, start time.Time, duration time.Duration, attendees []string) (*ports.Meeting, error) {
+	request := zoomMeetingRequest{
+		Topic: title,
+		// 2 is a scheduled meeting
+		Type:      2,
+		StartTime: start.UTC().Format("2006-01-02T15:04:05Z"),
+		Duration:  int(math.Ceil(duration.Minutes())),
+		Timezone:  start.Location().String(),
+		Settings:  zoomSettings{WaitingRoom: true},
+	}
+	for _, email := range attendees {
+		request.Settings.MeetingInvitees = append(request.Settings.MeetingInvitees, zoomInvitee{Email: email})
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encode meeting", "MEETING_ERROR")
+	}
+
+	var meeting zoomMeeting
+	if err := p.do(ctx, http.MethodPost, "/users/me/meetings", body, &meeting); err != nil {
+		return nil, err
+	}
+	return &ports.Meeting{ID: strconv.FormatInt(meeting.ID, 10), URL: meeting.JoinURL}, nil
+}
+
+func (p *ZoomMeetingProvider) CancelMeeting(ctx context.Context, meetingID string) error {
+	err := p.do(ctx, http.MethodDelete, "/meetings/"+url.PathEscape(meetingID), nil, nil)
+	var customErr *errorCustom.CustomError
+	if errors.As(err, &customErr) && customErr.ErrorType == errorCustom.NOT_FOUND {
+		return nil
+	}
+	return err
+}
+
+func (p *ZoomMeetingProvider) do(ctx context.Context, method, path string, body []byte, out any) error {
+	token, err := p.accessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, reader)
+	if err != nil {
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to build meeting request", "MEETING_ERROR")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	raw, status, err := p.send(req)
+	if err != nil {
+		return err
+	}
+
+	if status >= http.StatusBadRequest {
+		if status == http.StatusUnauthorized {
+			p.resetToken()
+		}
+		var apiErr zoomError
+		_ = json.Unmarshal(raw, &apiErr)
+		cause := fmt.Errorf("zoom %s %s: status %d, code %d: %s", method, path, status, apiErr.Code, apiErr.Message)
+		p.logger.Error(utils.NewSafeError(cause, "Error in ZoomMeetingProvider: Request rejected"))
+		return classifyMeetingStatus(status, cause)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Invalid meeting response", "MEETING_ERROR")
+	}
+	return nil
+}
+
+// accessToken returns the cached token, requesting a new one with the
+// account credentials grant when it is about to expire
+func (p *ZoomMeetingProvider) accessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && time.Now().Before(p.expiresAt) {
+		return p.token, nil
+	}
+
+	credentials, err := p.credentials(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{"grant_type": {"account_credentials"}, "account_id": {credentials.AccountID}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.oauthURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to build meeting token request", "MEETING_ERROR")
+	}
+	req.SetBasicAuth(credentials.ClientID, credentials.ClientSecret)
+
+	raw, status, err := p.send(req)
+	if err != nil {
+		return "", err
+	}
+	if status >= http.StatusBadRequest {
+		cause := fmt.Errorf("zoom oauth: status %d", status)
+		p.logger.Error(utils.NewSafeError(cause, "Error in ZoomMeetingProvider: Token request rejected"))
+		return "", classifyMeetingStatus(status, cause)
+	}
+
+	var token zoomToken
+	if err := json.Unmarshal(raw, &token); err != nil || token.AccessToken == "" {
+		return "", errorCustom.NewError(errorCustom.INTERNAL, "Invalid meeting token response", "MEETING_ERROR")
+	}
+	p.token = token.AccessToken
+	p.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - zoomTokenMargin)
+	return p.token, nil
+}
+
+func (p *ZoomMeetingProvider) resetToken() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.token = ""
+}
+
+func (p *ZoomMeetingProvider) send(req *http.Request) ([]byte, int, error) {
+	res, err := p.client.Do(req)
+	if err != nil {
+		p.logger.Error(utils.NewSafeError(err, "Error in ZoomMeetingProvider: Request failed"))
+		return nil, 0, errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Meeting service unavailable", "MEETING_UNAVAILABLE")
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxZoomBody))
+	if err != nil {
+		return nil, 0, errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Failed to read meeting response", "MEETING_UNAVAILABLE")
+	}
+	return raw, res.StatusCode, nil
+}
+
+func classifyMeetingStatus(status int, cause error) error {
+	switch {
+	case status == http.StatusNotFound:
+		return errorCustom.Wrap(cause, errorCustom.NOT_FOUND, "Meeting not found", "ERR_MEETING_NOT_FOUND")
+	case status == http.StatusTooManyRequests || status >= http.StatusInternalServerError:
+		return errorCustom.Wrap(cause, errorCustom.SERVICE_UNAVAILABLE, "Meeting service unavailable", "MEETING_UNAVAILABLE")
+	default:
+		return errorCustom.Wrap(cause, errorCustom.INTERNAL, "Meeting request rejected", "MEETING_ERROR")
+	}
+}
+
+var _ ports.MeetingProvider = (*ZoomMeetingProvider)(nil)
@@ -0,0 +1,57 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+)
+
+// MemoryMessageSender records messages instead of sending them. It is meant
+// for local runs and tests; IDs are sequential, prefixed with the channel and
+// the time the sender was built, since sent messages are stored by ID.
+type MemoryMessageSender struct {
+	channel string
+	prefix  string
+
+	mu   sync.Mutex
+	sent []SentRecord
+}
+
+// SentRecord is a message recorded by MemoryMessageSender
+type SentRecord struct {
+	ID      string
+	Message ports.OutboundMessage
+}
+
+// NewMemoryMessageSender records messages as sent over channel
+func NewMemoryMessageSender(channel string) *MemoryMessageSender {
+	if channel == "" {
+		channel = ports.ChannelWhatsApp
+	}
+	return &MemoryMessageSender{
+		channel: channel,
+		prefix:  fmt.Sprintf("memory-%s-%d", strings.ToLower(channel), time.Now().UnixNano()),
+	}
+}
+
+func (s *MemoryMessageSender) Send(_ context.Context, msg ports.OutboundMessage) (*ports.SentMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := fmt.Sprintf("%s-%d", s.prefix, len(s.sent)+1)
+	s.sent = append(s.sent, SentRecord{ID: id, Message: msg})
+	return &ports.SentMessage{ID: id, Channel: s.channel}, nil
+}
+
+// Sent returns the messages recorded, in order
+func (s *MemoryMessageSender) Sent() []SentRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SentRecord(nil), s.sent...)
+}
+
+var _ ports.MessageSender = (*MemoryMessageSender)(nil)
@@ -0,0 +1,140 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+const (
+	DefaultTwilioURL = "https://api.twilio.com/2010-04-01"
+	maxTwilioBody    = 1 << 20
+)
+
+// Twilio error codes handled by the sender
+const (
+	twilioInvalidTo       = 21211
+	twilioUnreachableTo   = 21614
+	twilioBlacklisted     = 21610
+	twilioTooManyRequests = 20429
+)
+
+// TwilioCredentials are the account SID and auth token of the Twilio account
+type TwilioCredentials struct {
+	AccountSID string `json:"accountSid"`
+	AuthToken  string `json:"authToken"`
+}
+
+// TwilioSMSSender implements ports.MessageSender with the Twilio Messaging
+// API. Only the text of the message is sent.
+type TwilioSMSSender struct {
+	logger         logger.Logger
+	client         *http.Client
+	baseURL        string
+	from           string
+	statusCallback string
+	credentials    func(ctx context.Context) (*TwilioCredentials, error)
+}
+
+// NewTwilioSMSSender builds the adapter on a client from adapters.NewHTTPClient.
+// from is the sender number in E.164 or a messaging service SID (MG...);
+// statusCallback, if set, is the URL Twilio posts delivery statuses to;
+// credentials resolves the account credentials, usually from Secrets Manager.
+func NewTwilioSMSSender(logger logger.Logger, client *http.Client, baseURL, from, statusCallback string, credentials func(ctx context.Context) (*TwilioCredentials, error)) *TwilioSMSSender {
+	if baseURL == "" {
+		baseURL = DefaultTwilioURL
+	}
+	return &TwilioSMSSender{
+		logger:         logger,
+		client:         client,
+		baseURL:        strings.TrimSuffix(baseURL, "/"),
+		from:           from,
+		statusCallback: statusCallback,
+		credentials:    credentials,
+	}
+}
+
+type twilioMessage struct {
+	SID string `json:"sid"`
+}
+
+type twilioError struct {
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	MoreInfo string `json:"more_info"`
+}
+
+func (s *TwilioSMSSender) Send(ctx context.Context, msg ports.OutboundMessage) (*ports.SentMessage, error) {
+	credentials, err := s.credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{"To": {msg.To}, "Body": {msg.Text}}
+	if strings.HasPrefix(s.from, "MG") {
+		form.Set("MessagingServiceSid", s.from)
+	} else {
+		form.Set("From", s.from)
+	}
+	if s.statusCallback != "" {
+		form.Set("StatusCallback", s.statusCallback)
+	}
+
+	endpoint := fmt.Sprintf("%s/Accounts/%s/Messages.json", s.baseURL, url.PathEscape(credentials.AccountSID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to build message request", "MESSAGING_ERROR")
+	}
+	req.SetBasicAuth(credentials.AccountSID, credentials.AuthToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Error(utils.NewSafeError(err, "Error in TwilioSMSSender: Request failed"))
+		return nil, errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Messaging service unavailable", "MESSAGING_UNAVAILABLE")
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxTwilioBody))
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Failed to read messaging response", "MESSAGING_UNAVAILABLE")
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var apiErr twilioError
+		_ = json.Unmarshal(raw, &apiErr)
+		cause := fmt.Errorf("twilio send: status %d, code %d: %s (%s)", res.StatusCode, apiErr.Code, apiErr.Message, apiErr.MoreInfo)
+		s.logger.Error(utils.NewSafeError(cause, "Error in TwilioSMSSender: Request rejected"))
+		return nil, classifyTwilioError(res.StatusCode, apiErr.Code, cause)
+	}
+
+	var sent twilioMessage
+	if err := json.Unmarshal(raw, &sent); err != nil || sent.SID == "" {
+		return nil, errorCustom.NewError(errorCustom.INTERNAL, "Invalid messaging response", "MESSAGING_ERROR")
+	}
+	return &ports.SentMessage{ID: sent.SID, Channel: ports.ChannelSMS}, nil
+}
+
+func classifyTwilioError(status, code int, cause error) error {
+	switch {
+	case code == twilioInvalidTo || code == twilioUnreachableTo || code == twilioBlacklisted:
+		return errorCustom.Wrap(cause, errorCustom.BAD_REQUEST, "Recipient cannot receive SMS", "ERR_RECIPIENT_UNREACHABLE")
+	case status == http.StatusTooManyRequests || code == twilioTooManyRequests:
+		return errorCustom.Wrap(cause, errorCustom.TOO_MANY_REQUESTS, "Messaging rate limit exceeded", "MESSAGING_THROTTLED")
+	case status >= http.StatusInternalServerError:
+		return errorCustom.Wrap(cause, errorCustom.SERVICE_UNAVAILABLE, "Messaging service unavailable", "MESSAGING_UNAVAILABLE")
+	default:
+		return errorCustom.Wrap(cause, errorCustom.INTERNAL, "Message rejected", "MESSAGING_ERROR")
+	}
+}
+
+var _ ports.MessageSender = (*TwilioSMSSender)(nil)
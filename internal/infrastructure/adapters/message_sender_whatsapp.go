@@ -0,0 +1,135 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/whatsapp"
+)
+
+const (
+	DefaultWhatsAppURL = "https://graph.facebook.com/v20.0"
+	maxWhatsAppBody    = 1 << 20
+)
+
+// WhatsAppSender implements ports.MessageSender with the WhatsApp Cloud API.
+// Messages with an approved template are sent as that template, others as
+// plain text.
+type WhatsAppSender struct {
+	logger        logger.Logger
+	client        *http.Client
+	baseURL       string
+	phoneNumberID string
+	token         func(ctx context.Context) (string, error)
+}
+
+// NewWhatsAppSender builds the adapter on a client from adapters.NewHTTPClient.
+// phoneNumberID is the business number messages are sent from; token
+// resolves the access token, usually from Secrets Manager.
+func NewWhatsAppSender(logger logger.Logger, client *http.Client, baseURL, phoneNumberID string, token func(ctx context.Context) (string, error)) *WhatsAppSender {
+	if baseURL == "" {
+		baseURL = DefaultWhatsAppURL
+	}
+	return &WhatsAppSender{
+		logger:        logger,
+		client:        client,
+		baseURL:       strings.TrimSuffix(baseURL, "/"),
+		phoneNumberID: phoneNumberID,
+		token:         token,
+	}
+}
+
+func (s *WhatsAppSender) Send(ctx context.Context, msg ports.OutboundMessage) (*ports.SentMessage, error) {
+	request := whatsapp.SendRequest{
+		MessagingProduct: whatsapp.MESSAGING_PRODUCT,
+		RecipientType:    "individual",
+		To:               strings.TrimPrefix(msg.To, "+"),
+	}
+	if msg.Template != nil {
+		request.Type = whatsapp.MESSAGE_TYPE_TEMPLATE
+		request.Template = &whatsapp.TemplateBody{
+			Name:     msg.Template.Name,
+			Language: whatsapp.TemplateLanguage{Code: msg.Template.Language},
+		}
+		if len(msg.Template.Params) > 0 {
+			body := whatsapp.TemplateComponent{Type: "body"}
+			for _, param := range msg.Template.Params {
+				body.Parameters = append(body.Parameters, whatsapp.TemplateParameter{Type: "text", Text: param})
+			}
+			request.Template.Components = []whatsapp.TemplateComponent{body}
+		}
+	} else {
+		request.Type = whatsapp.MESSAGE_TYPE_TEXT
+		request.Text = &whatsapp.TextBody{PreviewURL: true, Body: msg.Text}
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encode message", "MESSAGING_ERROR")
+	}
+
+	token, err := s.token(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/messages", s.baseURL, s.phoneNumberID), bytes.NewReader(body))
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to build message request", "MESSAGING_ERROR")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		s.logger.Error(utils.NewSafeError(err, "Error in WhatsAppSender: Request failed"))
+		return nil, errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Messaging service unavailable", "MESSAGING_UNAVAILABLE")
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxWhatsAppBody))
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Failed to read messaging response", "MESSAGING_UNAVAILABLE")
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var apiErr whatsapp.ErrorResponse
+		_ = json.Unmarshal(raw, &apiErr)
+		cause := fmt.Errorf("whatsapp send: status %d, code %d: %s (fbtrace_id %s)",
+			res.StatusCode, apiErr.Error.Code, apiErr.Error.Message, apiErr.Error.FBTraceID)
+		s.logger.Error(utils.NewSafeError(cause, "Error in WhatsAppSender: Request rejected"))
+		return nil, classifyWhatsAppError(res.StatusCode, apiErr.Error.Code, cause)
+	}
+
+	var sent whatsapp.SendResponse
+	if err := json.Unmarshal(raw, &sent); err != nil || len(sent.Messages) == 0 || sent.Messages[0].ID == "" {
+		return nil, errorCustom.NewError(errorCustom.INTERNAL, "Invalid messaging response", "MESSAGING_ERROR")
+	}
+	return &ports.SentMessage{ID: sent.Messages[0].ID, Channel: ports.ChannelWhatsApp}, nil
+}
+
+func classifyWhatsAppError(status, code int, cause error) error {
+	switch {
+	case code == whatsapp.ERROR_REENGAGEMENT:
+		return errorCustom.Wrap(cause, errorCustom.BAD_REQUEST, "WhatsApp only accepts approved templates 24 hours after the last message of the candidate", "ERR_MESSAGE_WINDOW_CLOSED")
+	case code == whatsapp.ERROR_RECIPIENT_INVALID || code == whatsapp.ERROR_RECIPIENT_NOT_ALLOWED:
+		return errorCustom.Wrap(cause, errorCustom.BAD_REQUEST, "Recipient cannot receive WhatsApp messages", "ERR_RECIPIENT_UNREACHABLE")
+	case status == http.StatusTooManyRequests || code == whatsapp.ERROR_RATE_LIMIT || code == whatsapp.ERROR_SPAM_RATE_LIMIT ||
+		code == whatsapp.ERROR_PAIR_RATE_LIMIT || code == whatsapp.ERROR_THROUGHPUT_LIMIT:
+		return errorCustom.Wrap(cause, errorCustom.TOO_MANY_REQUESTS, "Messaging rate limit exceeded", "MESSAGING_THROTTLED")
+	case status >= http.StatusInternalServerError:
+		return errorCustom.Wrap(cause, errorCustom.SERVICE_UNAVAILABLE, "Messaging service unavailable", "MESSAGING_UNAVAILABLE")
+	default:
+		return errorCustom.Wrap(cause, errorCustom.INTERNAL, "Message rejected", "MESSAGING_ERROR")
+	}
+}
+
+var _ ports.MessageSender = (*WhatsAppSender)(nil)
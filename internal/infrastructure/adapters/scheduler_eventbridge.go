@@ -0,0 +1,196 @@
+package adapters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler/types"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// atLayout is the time format of one-time schedule expressions, at(...)
+const atLayout = "2006-01-02T15:04:05"
+
+// EventBridgeScheduler implements ports.Scheduler with EventBridge Scheduler.
+// One-time schedules (ScheduledTime) are deleted by the service once they
+// run, so their names can be reused.
+type EventBridgeScheduler struct {
+	logger    logger.Logger
+	client    *scheduler.Client
+	groupName string
+}
+
+// NewEventBridgeScheduler manages the schedules of groupName, "default" when
+// empty
+func NewEventBridgeScheduler(logger logger.Logger, client *scheduler.Client, groupName string) *EventBridgeScheduler {
+	if groupName == "" {
+		groupName = "default"
+	}
+	return &EventBridgeScheduler{
+		logger:    logger,
+		client:    client,
+		groupName: groupName,
+	}
+}
+
+func (s *EventBridgeScheduler) Get(ctx context.Context, name string) (*ports.ScheduleInfo, error) {
+	res, err := s.client.GetSchedule(ctx, &scheduler.GetScheduleInput{
+		Name:      aws.String(name),
+		GroupName: aws.String(s.groupName),
+	})
+	var notFoundErr *types.ResourceNotFoundException
+	if errors.As(err, &notFoundErr) {
+		return nil, nil
+	}
+	if err != nil {
+		s.logger.Error(utils.NewSafeError(err, "Error in EventBridgeScheduler.Get: GetSchedule failed"))
+		return nil, classifySchedulerError(err, "Failed to get schedule")
+	}
+
+	info := &ports.ScheduleInfo{
+		Arn:                aws.ToString(res.Arn),
+		Name:               aws.ToString(res.Name),
+		Description:        aws.ToString(res.Description),
+		State:              ports.ScheduleState(res.State),
+		ScheduleExpression: aws.ToString(res.ScheduleExpression),
+		CreatedAt:          aws.ToTime(res.CreationDate),
+		LastModifiedAt:     aws.ToTime(res.LastModificationDate),
+		NextExecutionTime:  parseAtExpression(aws.ToString(res.ScheduleExpression), aws.ToString(res.ScheduleExpressionTimezone)),
+	}
+	if res.Target != nil {
+		info.TargetArn = aws.ToString(res.Target.Arn)
+	}
+	return info, nil
+}
+
+// Create creates the schedule. A schedule with the same name fails with
+// CONFLICT ERR_SCHEDULE_EXISTS, so callers can use names as idempotency keys.
+func (s *EventBridgeScheduler) Create(ctx context.Context, params ports.CreateScheduleParams) (*ports.ScheduleResult, error) {
+	input, err := scheduleInput(params.Input)
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encode schedule input", "SCHEDULER_ERROR")
+	}
+
+	timeZone := params.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+
+	req := &scheduler.CreateScheduleInput{
+		Name:                       aws.String(params.Name),
+		GroupName:                  aws.String(s.groupName),
+		ScheduleExpression:         aws.String(params.ScheduleExpression),
+		ScheduleExpressionTimezone: aws.String(timeZone),
+		FlexibleTimeWindow:         &types.FlexibleTimeWindow{Mode: types.FlexibleTimeWindowModeOff},
+		StartDate:                  params.StartDate,
+		EndDate:                    params.EndDate,
+		State:                      types.ScheduleStateEnabled,
+		Target: &types.Target{
+			Arn:     aws.String(params.TargetArn),
+			RoleArn: aws.String(params.RoleArn),
+			Input:   input,
+		},
+	}
+	if params.Description != "" {
+		req.Description = aws.String(params.Description)
+	}
+	if params.ScheduledTime != nil {
+		location, err := time.LoadLocation(timeZone)
+		if err != nil {
+			return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Invalid schedule time zone "+timeZone, "SCHEDULER_ERROR")
+		}
+		req.ScheduleExpression = aws.String("at(" + params.ScheduledTime.In(location).Format(atLayout) + ")")
+		req.ActionAfterCompletion = types.ActionAfterCompletionDelete
+	}
+
+	res, err := s.client.CreateSchedule(ctx, req)
+	if err != nil {
+		s.logger.Error(utils.NewSafeError(err, "Error in EventBridgeScheduler.Create: CreateSchedule failed for "+params.Name))
+		return nil, classifySchedulerError(err, "Failed to create schedule")
+	}
+	return &ports.ScheduleResult{
+		ScheduleArn:  aws.ToString(res.ScheduleArn),
+		ScheduleName: params.Name,
+		State:        ports.ScheduleStateEnabled,
+	}, nil
+}
+
+// Delete removes the schedule; a schedule that does not exist, e.g. a
+// one-time schedule that already ran, is not an error
+func (s *EventBridgeScheduler) Delete(ctx context.Context, name string) error {
+	_, err := s.client.DeleteSchedule(ctx, &scheduler.DeleteScheduleInput{
+		Name:      aws.String(name),
+		GroupName: aws.String(s.groupName),
+	})
+	var notFoundErr *types.ResourceNotFoundException
+	if err == nil || errors.As(err, &notFoundErr) {
+		return nil
+	}
+	s.logger.Error(utils.NewSafeError(err, "Error in EventBridgeScheduler.Delete: DeleteSchedule failed for "+name))
+	return classifySchedulerError(err, "Failed to delete schedule")
+}
+
+// scheduleInput is the JSON passed to the target: strings are sent as they
+// are, other values are marshalled
+func scheduleInput(input interface{}) (*string, error) {
+	switch value := input.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return aws.String(value), nil
+	}
+	body, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	return aws.String(string(body)), nil
+}
+
+// parseAtExpression returns the time of an at(...) expression, or nil for
+// recurring ones
+func parseAtExpression(expression, timeZone string) *time.Time {
+	if !strings.HasPrefix(expression, "at(") || !strings.HasSuffix(expression, ")") {
+		return nil
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	at, err := time.ParseInLocation(atLayout, strings.TrimSuffix(strings.TrimPrefix(expression, "at("), ")"), location)
+	if err != nil {
+		return nil
+	}
+	return &at
+}
+
+func classifySchedulerError(err error, message string) error {
+	var (
+		conflictErr  *types.ConflictException
+		throttledErr *types.ThrottlingException
+		quotaErr     *types.ServiceQuotaExceededException
+		validateErr  *types.ValidationException
+		notFoundErr  *types.ResourceNotFoundException
+	)
+
+	switch {
+	case errors.As(err, &conflictErr):
+		return errorCustom.Wrap(err, errorCustom.CONFLICT, "Schedule already exists", "ERR_SCHEDULE_EXISTS")
+	case errors.As(err, &throttledErr), errors.As(err, &quotaErr):
+		return errorCustom.Wrap(err, errorCustom.TOO_MANY_REQUESTS, "Scheduler rate limit exceeded", "SCHEDULER_THROTTLED")
+	case errors.As(err, &validateErr), errors.As(err, &notFoundErr):
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, message, "SCHEDULER_ERROR")
+	default:
+		return errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Scheduler unavailable", "SCHEDULER_UNAVAILABLE")
+	}
+}
+
+var _ ports.Scheduler = (*EventBridgeScheduler)(nil)
@@ -0,0 +1,84 @@
+package adapters
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+)
+
+// MemoryScheduler keeps schedules in memory and never runs them. It is meant
+// for local runs and tests; like EventBridgeScheduler it rejects duplicate
+// names with CONFLICT ERR_SCHEDULE_EXISTS.
+type MemoryScheduler struct {
+	mu        sync.Mutex
+	schedules map[string]ports.CreateScheduleParams
+}
+
+func NewMemoryScheduler() *MemoryScheduler {
+	return &MemoryScheduler{schedules: make(map[string]ports.CreateScheduleParams)}
+}
+
+func (s *MemoryScheduler) Get(_ context.Context, name string) (*ports.ScheduleInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	params, ok := s.schedules[name]
+	if !ok {
+		return nil, nil
+	}
+	return &ports.ScheduleInfo{
+		Arn:                "memory:" + name,
+		Name:               name,
+		Description:        params.Description,
+		State:              ports.ScheduleStateEnabled,
+		ScheduleExpression: params.ScheduleExpression,
+		TargetArn:          params.TargetArn,
+		NextExecutionTime:  params.ScheduledTime,
+	}, nil
+}
+
+func (s *MemoryScheduler) Create(_ context.Context, params ports.CreateScheduleParams) (*ports.ScheduleResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.schedules[params.Name]; ok {
+		return nil, errorCustom.NewError(errorCustom.CONFLICT, "Schedule already exists", "ERR_SCHEDULE_EXISTS")
+	}
+	s.schedules[params.Name] = params
+	return &ports.ScheduleResult{
+		ScheduleArn:  "memory:" + params.Name,
+		ScheduleName: params.Name,
+		State:        ports.ScheduleStateEnabled,
+	}, nil
+}
+
+func (s *MemoryScheduler) Delete(_ context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.schedules, name)
+	return nil
+}
+
+// Due returns the one-time schedules due at now, earliest first, so tests
+// can run them by hand
+func (s *MemoryScheduler) Due(now time.Time) []ports.CreateScheduleParams {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]ports.CreateScheduleParams, 0)
+	for _, params := range s.schedules {
+		if params.ScheduledTime != nil && !params.ScheduledTime.After(now) {
+			due = append(due, params)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].ScheduledTime.Before(*due[j].ScheduledTime)
+	})
+	return due
+}
+
+var _ ports.Scheduler = (*MemoryScheduler)(nil)
@@ -0,0 +1,60 @@
+package adapters
+
+import (
+	"sync"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+)
+
+type cachedSecret struct {
+	value     *ports.SecretValue
+	expiresAt time.Time
+}
+
+// MemorySecretCache keeps secrets in the Lambda container between invocations
+type MemorySecretCache struct {
+	mu      sync.RWMutex
+	entries map[string]cachedSecret
+}
+
+func NewMemorySecretCache() *MemorySecretCache {
+	return &MemorySecretCache{
+		entries: make(map[string]cachedSecret),
+	}
+}
+
+func (c *MemorySecretCache) Get(key string) (*ports.SecretValue, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		c.Delete(key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (c *MemorySecretCache) Set(key string, value *ports.SecretValue, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = cachedSecret{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+func (c *MemorySecretCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
+
+func (c *MemorySecretCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]cachedSecret)
+}
+
+var _ ports.SecretCache = (*MemorySecretCache)(nil)
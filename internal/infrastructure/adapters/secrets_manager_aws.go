@@ -0,0 +1,91 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+const defaultSecretCacheTTL = 5 * time.Minute
+
+type AWSSecretsManager struct {
+	logger logger.Logger
+	client *secretsmanager.Client
+	cache  ports.SecretCache
+	ttl    time.Duration
+}
+
+func NewAWSSecretsManager(logger logger.Logger, client *secretsmanager.Client, cache ports.SecretCache) *AWSSecretsManager {
+	return &AWSSecretsManager{
+		logger: logger,
+		client: client,
+		cache:  cache,
+		ttl:    defaultSecretCacheTTL,
+	}
+}
+
+func (s *AWSSecretsManager) GetSecret(ctx context.Context, secretName string, opts *ports.SecretOptions) (*ports.SecretValue, error) {
+	if opts == nil {
+		opts = &ports.SecretOptions{}
+	}
+
+	cacheKey := secretName + "|" + opts.VersionID + "|" + opts.VersionStage
+	if s.cache != nil && !opts.ForceRefresh {
+		if value, ok := s.cache.Get(cacheKey); ok {
+			return value, nil
+		}
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	input := &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretName),
+	}
+	if opts.VersionID != "" {
+		input.VersionId = aws.String(opts.VersionID)
+	}
+	if opts.VersionStage != "" {
+		input.VersionStage = aws.String(opts.VersionStage)
+	}
+
+	res, err := s.client.GetSecretValue(ctx, input)
+	if err != nil {
+		var notFound *types.ResourceNotFoundException
+		if errors.As(err, &notFound) {
+			return nil, errorCustom.Wrap(err, errorCustom.NOT_FOUND, "Secret not found", "ERR_SECRET_NOT_FOUND")
+		}
+		s.logger.Error(utils.NewSafeError(err, "Error in AWSSecretsManager.GetSecret: Failed to get secret"))
+		return nil, errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Failed to get secret", "SECRETS_ERROR")
+	}
+
+	value := &ports.SecretValue{
+		Value:   aws.ToString(res.SecretString),
+		Version: aws.ToString(res.VersionId),
+		ARN:     aws.ToString(res.ARN),
+	}
+	if res.CreatedDate != nil {
+		value.CreatedAt = *res.CreatedDate
+		value.LastUpdated = *res.CreatedDate
+	}
+
+	if s.cache != nil {
+		s.cache.Set(cacheKey, value, s.ttl)
+	}
+
+	return value, nil
+}
+
+var _ ports.SecretsManager = (*AWSSecretsManager)(nil)
@@ -0,0 +1,80 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+)
+
+// MemorySheetClient keeps rows in memory. It is meant for local runs and
+// tests: rows are created on first write and every write is recorded.
+type MemorySheetClient struct {
+	mu     sync.Mutex
+	rows   map[string]map[string]any
+	writes []SheetWrite
+}
+
+// SheetWrite is a call to UpdateCells recorded by MemorySheetClient
+type SheetWrite struct {
+	SheetID string
+	RowID   string
+	Values  map[string]any
+}
+
+func NewMemorySheetClient() *MemorySheetClient {
+	return &MemorySheetClient{
+		rows: make(map[string]map[string]any),
+	}
+}
+
+func (c *MemorySheetClient) UpdateCells(_ context.Context, sheetID, rowID string, values map[string]any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := rowKey(sheetID, rowID)
+	row, ok := c.rows[key]
+	if !ok {
+		row = make(map[string]any)
+		c.rows[key] = row
+	}
+
+	copied := make(map[string]any, len(values))
+	for columnID, value := range values {
+		row[columnID] = value
+		copied[columnID] = value
+	}
+	c.writes = append(c.writes, SheetWrite{SheetID: sheetID, RowID: rowID, Values: copied})
+	return nil
+}
+
+func (c *MemorySheetClient) GetRow(_ context.Context, sheetID, rowID string) (*ports.SheetRow, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	row, ok := c.rows[rowKey(sheetID, rowID)]
+	if !ok {
+		return nil, errorCustom.NewError(errorCustom.NOT_FOUND, "Sheet row not found", "ERR_SHEET_ROW_NOT_FOUND")
+	}
+
+	result := &ports.SheetRow{ID: rowID, SheetID: sheetID, Cells: make(map[string]ports.SheetCell, len(row))}
+	for columnID, value := range row {
+		result.Cells[columnID] = ports.SheetCell{ColumnID: columnID, Value: value, DisplayValue: fmt.Sprint(value)}
+	}
+	return result, nil
+}
+
+// Writes returns the calls to UpdateCells in order
+func (c *MemorySheetClient) Writes() []SheetWrite {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]SheetWrite(nil), c.writes...)
+}
+
+func rowKey(sheetID, rowID string) string {
+	return sheetID + "#" + rowID
+}
+
+var _ ports.SheetClient = (*MemorySheetClient)(nil)
@@ -0,0 +1,185 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+const (
+	DefaultSmartsheetURL = "https://api.smartsheet.com/2.0"
+	maxSmartsheetBody    = 1 << 20
+)
+
+// SmartsheetClient implements ports.SheetClient with the Smartsheet REST API
+type SmartsheetClient struct {
+	logger  logger.Logger
+	client  *http.Client
+	baseURL string
+	token   func(ctx context.Context) (string, error)
+}
+
+// NewSmartsheetClient builds the adapter on a client from adapters.NewHTTPClient.
+// token resolves the API access token, usually from Secrets Manager.
+func NewSmartsheetClient(logger logger.Logger, client *http.Client, baseURL string, token func(ctx context.Context) (string, error)) *SmartsheetClient {
+	if baseURL == "" {
+		baseURL = DefaultSmartsheetURL
+	}
+	return &SmartsheetClient{
+		logger:  logger,
+		client:  client,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+	}
+}
+
+type smartsheetCell struct {
+	ColumnID     int64  `json:"columnId"`
+	Value        any    `json:"value,omitempty"`
+	DisplayValue string `json:"displayValue,omitempty"`
+}
+
+type smartsheetRow struct {
+	ID    int64            `json:"id"`
+	Cells []smartsheetCell `json:"cells"`
+}
+
+type smartsheetError struct {
+	ErrorCode int    `json:"errorCode"`
+	Message   string `json:"message"`
+	RefID     string `json:"refId"`
+}
+
+func (c *SmartsheetClient) UpdateCells(ctx context.Context, sheetID, rowID string, values map[string]any) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	row := smartsheetRow{Cells: make([]smartsheetCell, 0, len(values))}
+	var err error
+	if row.ID, err = parseSheetID(rowID); err != nil {
+		return err
+	}
+	for columnID, value := range values {
+		id, err := parseSheetID(columnID)
+		if err != nil {
+			return err
+		}
+		// An empty string clears the cell
+		if value == nil {
+			value = ""
+		}
+		row.Cells = append(row.Cells, smartsheetCell{ColumnID: id, Value: value})
+	}
+
+	body, err := json.Marshal([]smartsheetRow{row})
+	if err != nil {
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encode sheet row", "SHEET_ERROR")
+	}
+
+	return c.do(ctx, http.MethodPut, fmt.Sprintf("/sheets/%s/rows", sheetID), body, nil)
+}
+
+func (c *SmartsheetClient) GetRow(ctx context.Context, sheetID, rowID string) (*ports.SheetRow, error) {
+	var row smartsheetRow
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/sheets/%s/rows/%s", sheetID, rowID), nil, &row); err != nil {
+		return nil, err
+	}
+
+	result := &ports.SheetRow{
+		ID:      strconv.FormatInt(row.ID, 10),
+		SheetID: sheetID,
+		Cells:   make(map[string]ports.SheetCell, len(row.Cells)),
+	}
+	for _, cell := range row.Cells {
+		columnID := strconv.FormatInt(cell.ColumnID, 10)
+		result.Cells[columnID] = ports.SheetCell{
+			ColumnID:     columnID,
+			Value:        cell.Value,
+			DisplayValue: cell.DisplayValue,
+		}
+	}
+	return result, nil
+}
+
+func (c *SmartsheetClient) do(ctx context.Context, method, path string, body []byte, out any) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to build sheet request", "SHEET_ERROR")
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		c.logger.Error(utils.NewSafeError(err, "Error in SmartsheetClient: Request failed"))
+		return errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Sheet service unavailable", "SHEET_UNAVAILABLE")
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(res.Body, maxSmartsheetBody))
+	if err != nil {
+		return errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Failed to read sheet response", "SHEET_UNAVAILABLE")
+	}
+
+	if res.StatusCode >= http.StatusBadRequest {
+		var apiErr smartsheetError
+		_ = json.Unmarshal(raw, &apiErr)
+		cause := fmt.Errorf("smartsheet %s %s: status %d, errorCode %d: %s (refId %s)",
+			method, path, res.StatusCode, apiErr.ErrorCode, apiErr.Message, apiErr.RefID)
+		c.logger.Error(utils.NewSafeError(cause, "Error in SmartsheetClient: Request rejected"))
+		return classifySheetStatus(res.StatusCode, cause)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Invalid sheet response", "SHEET_ERROR")
+	}
+	return nil
+}
+
+func classifySheetStatus(status int, cause error) error {
+	switch {
+	case status == http.StatusNotFound:
+		return errorCustom.Wrap(cause, errorCustom.NOT_FOUND, "Sheet row not found", "ERR_SHEET_ROW_NOT_FOUND")
+	case status == http.StatusTooManyRequests:
+		return errorCustom.Wrap(cause, errorCustom.TOO_MANY_REQUESTS, "Sheet rate limit exceeded", "SHEET_THROTTLED")
+	case status >= http.StatusInternalServerError:
+		return errorCustom.Wrap(cause, errorCustom.SERVICE_UNAVAILABLE, "Sheet service unavailable", "SHEET_UNAVAILABLE")
+	default:
+		return errorCustom.Wrap(cause, errorCustom.INTERNAL, "Sheet request rejected", "SHEET_ERROR")
+	}
+}
+
+func parseSheetID(value string) (int64, error) {
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, errorCustom.NewError(errorCustom.BAD_REQUEST, fmt.Sprintf("Invalid sheet ID %q", value), "ERR_INVALID_SHEET_ID")
+	}
+	return id, nil
+}
+
+var _ ports.SheetClient = (*SmartsheetClient)(nil)
@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
 )
 
 func Load() (*config.Config, error) {
@@ -15,8 +18,176 @@ func Load() (*config.Config, error) {
   if cfg.CANDIDATES_TABLE_NAME == "" {
     return nil, fmt.Errorf("CANDIDATES_TABLE_NAME environment variable is empty")
   }
+
+  cfg.CANDIDATES_PHONE_INDEX = getEnvOrDefault("CANDIDATES_PHONE_INDEX", "phone-index")
+  cfg.CANDIDATES_DOCUMENT_INDEX = getEnvOrDefault("CANDIDATES_DOCUMENT_INDEX", "documentKey-index")
+
+  cfg.IDEMPOTENCY_TABLE_NAME = os.Getenv("IDEMPOTENCY_TABLE_NAME")
+  if cfg.IDEMPOTENCY_TABLE_NAME == "" {
+    return nil, fmt.Errorf("IDEMPOTENCY_TABLE_NAME environment variable is empty")
+  }
+
+  cfg.OUTBOX_TABLE_NAME = os.Getenv("OUTBOX_TABLE_NAME")
+  if cfg.OUTBOX_TABLE_NAME == "" {
+    return nil, fmt.Errorf("OUTBOX_TABLE_NAME environment variable is empty")
+  }
+
+  cfg.OUTBOX_STATUS_INDEX = getEnvOrDefault("OUTBOX_STATUS_INDEX", "status-index")
+
+  cfg.SLOTS_TABLE_NAME = os.Getenv("SLOTS_TABLE_NAME")
+  if cfg.SLOTS_TABLE_NAME == "" {
+    return nil, fmt.Errorf("SLOTS_TABLE_NAME environment variable is empty")
+  }
+
+  cfg.SLOTS_STORE_INDEX = getEnvOrDefault("SLOTS_STORE_INDEX", "store-index")
+
+  cfg.MESSAGES_TABLE_NAME = os.Getenv("MESSAGES_TABLE_NAME")
+  if cfg.MESSAGES_TABLE_NAME == "" {
+    return nil, fmt.Errorf("MESSAGES_TABLE_NAME environment variable is empty")
+  }
+
+  cfg.MESSAGES_CANDIDATE_INDEX = getEnvOrDefault("MESSAGES_CANDIDATE_INDEX", "candidate-index")
+
+  // --- Outbox ---
+  maxAttempts, err := utils.ParseStringToInt(getEnvOrDefault("OUTBOX_MAX_ATTEMPTS", "8"))
+  if err != nil || maxAttempts < 1 {
+    return nil, fmt.Errorf("OUTBOX_MAX_ATTEMPTS must be a positive integer")
+  }
+  cfg.OUTBOX_MAX_ATTEMPTS = maxAttempts
+
+  // --- Locale ---
+  cfg.TIME_ZONE = getEnvOrDefault("TIME_ZONE", constants.DEFAULT_TIME_ZONE)
+
+  // --- Errors ---
+  cfg.PROBLEM_TYPE_BASE_URL = os.Getenv("PROBLEM_TYPE_BASE_URL")
+
+  // --- Encryption ---
+  cfg.ENCRYPTION_PROVIDER = getEnvOrDefault("ENCRYPTION_PROVIDER", constants.ENCRYPTION_PROVIDER_KMS)
+  switch cfg.ENCRYPTION_PROVIDER {
+  case constants.ENCRYPTION_PROVIDER_KMS:
+    cfg.ENCRYPTION_KMS_KEY_ID = os.Getenv("ENCRYPTION_KMS_KEY_ID")
+    if cfg.ENCRYPTION_KMS_KEY_ID == "" {
+      return nil, fmt.Errorf("ENCRYPTION_KMS_KEY_ID environment variable is empty")
+    }
+    cfg.BLIND_INDEX_SECRET_NAME = getEnvOrDefault("BLIND_INDEX_SECRET_NAME", "kfc-rec/candidates/blind-index-key")
+  case constants.ENCRYPTION_PROVIDER_STATIC:
+    cfg.ENCRYPTION_STATIC_KEY = os.Getenv("ENCRYPTION_STATIC_KEY")
+    if cfg.ENCRYPTION_STATIC_KEY == "" {
+      return nil, fmt.Errorf("ENCRYPTION_STATIC_KEY environment variable is empty")
+    }
+  default:
+    return nil, fmt.Errorf("ENCRYPTION_PROVIDER must be %q or %q", constants.ENCRYPTION_PROVIDER_KMS, constants.ENCRYPTION_PROVIDER_STATIC)
+  }
+
+  // --- Webhooks ---
+  cfg.WEBHOOK_SECRET_PREFIX = getEnvOrDefault("WEBHOOK_SECRET_PREFIX", "kfc-rec/webhook-clients")
+
+  replayWindow, err := utils.ParseStringToInt(getEnvOrDefault("WEBHOOK_REPLAY_WINDOW_SECONDS", "300"))
+  if err != nil {
+    return nil, fmt.Errorf("WEBHOOK_REPLAY_WINDOW_SECONDS is invalid: %w", err)
+  }
+  cfg.WEBHOOK_REPLAY_WINDOW = time.Duration(replayWindow) * time.Second
+  cfg.WHATSAPP_SECRET_NAME = getEnvOrDefault("WHATSAPP_SECRET_NAME", "kfc-rec/whatsapp/webhook")
+
+  // --- AI ---
+  // Without AI_API_URL replies are classified with keyword rules only
+  cfg.AI_API_URL = os.Getenv("AI_API_URL")
+  cfg.AI_MODEL = getEnvOrDefault("AI_MODEL", "gpt-4o-mini")
+  cfg.AI_API_KEY_SECRET_NAME = getEnvOrDefault("AI_API_KEY_SECRET_NAME", "kfc-rec/candidates/ai-api-key")
+
+  // --- Sheets ---
+  cfg.SHEETS_PROVIDER = getEnvOrDefault("SHEETS_PROVIDER", constants.SHEETS_PROVIDER_SMARTSHEET)
+  if cfg.SHEETS_PROVIDER != constants.SHEETS_PROVIDER_SMARTSHEET && cfg.SHEETS_PROVIDER != constants.SHEETS_PROVIDER_MEMORY {
+    return nil, fmt.Errorf("SHEETS_PROVIDER must be %q or %q", constants.SHEETS_PROVIDER_SMARTSHEET, constants.SHEETS_PROVIDER_MEMORY)
+  }
+  cfg.SMARTSHEET_API_URL = os.Getenv("SMARTSHEET_API_URL")
+  cfg.SMARTSHEET_TOKEN_SECRET_NAME = getEnvOrDefault("SMARTSHEET_TOKEN_SECRET_NAME", "kfc-rec/candidates/smartsheet-token")
+
+  // --- Meetings ---
+  cfg.MEETINGS_PROVIDER = getEnvOrDefault("MEETINGS_PROVIDER", constants.MEETINGS_PROVIDER_ZOOM)
+  if cfg.MEETINGS_PROVIDER != constants.MEETINGS_PROVIDER_ZOOM && cfg.MEETINGS_PROVIDER != constants.MEETINGS_PROVIDER_LOCAL {
+    return nil, fmt.Errorf("MEETINGS_PROVIDER must be %q or %q", constants.MEETINGS_PROVIDER_ZOOM, constants.MEETINGS_PROVIDER_LOCAL)
+  }
+  cfg.MEETINGS_LOCAL_URL = os.Getenv("MEETINGS_LOCAL_URL")
+  cfg.ZOOM_API_URL = os.Getenv("ZOOM_API_URL")
+  cfg.ZOOM_SECRET_NAME = getEnvOrDefault("ZOOM_SECRET_NAME", "kfc-rec/candidates/zoom-credentials")
+
+  // --- Messaging ---
+  // WhatsApp uses the access token of WHATSAPP_SECRET_NAME. Failed WhatsApp
+  // messages are resent over MESSAGING_FALLBACK_PROVIDER, when set.
+  cfg.MESSAGING_PROVIDER = getEnvOrDefault("MESSAGING_PROVIDER", constants.MESSAGING_PROVIDER_WHATSAPP)
+  switch cfg.MESSAGING_PROVIDER {
+  case constants.MESSAGING_PROVIDER_WHATSAPP:
+    cfg.WHATSAPP_API_URL = os.Getenv("WHATSAPP_API_URL")
+    cfg.WHATSAPP_PHONE_NUMBER_ID = os.Getenv("WHATSAPP_PHONE_NUMBER_ID")
+    if cfg.WHATSAPP_PHONE_NUMBER_ID == "" {
+      return nil, fmt.Errorf("WHATSAPP_PHONE_NUMBER_ID environment variable is empty")
+    }
+  case constants.MESSAGING_PROVIDER_SMS, constants.MESSAGING_PROVIDER_MEMORY:
+  default:
+    return nil, fmt.Errorf("MESSAGING_PROVIDER must be %q, %q or %q", constants.MESSAGING_PROVIDER_WHATSAPP, constants.MESSAGING_PROVIDER_SMS, constants.MESSAGING_PROVIDER_MEMORY)
+  }
+
+  cfg.MESSAGING_FALLBACK_PROVIDER = os.Getenv("MESSAGING_FALLBACK_PROVIDER")
+  switch cfg.MESSAGING_FALLBACK_PROVIDER {
+  case "", constants.MESSAGING_PROVIDER_SMS, constants.MESSAGING_PROVIDER_MEMORY:
+  default:
+    return nil, fmt.Errorf("MESSAGING_FALLBACK_PROVIDER must be empty, %q or %q", constants.MESSAGING_PROVIDER_SMS, constants.MESSAGING_PROVIDER_MEMORY)
+  }
+
+  if cfg.MESSAGING_PROVIDER == constants.MESSAGING_PROVIDER_SMS || cfg.MESSAGING_FALLBACK_PROVIDER == constants.MESSAGING_PROVIDER_SMS {
+    cfg.TWILIO_API_URL = os.Getenv("TWILIO_API_URL")
+    cfg.TWILIO_SECRET_NAME = getEnvOrDefault("TWILIO_SECRET_NAME", "kfc-rec/candidates/twilio")
+    cfg.TWILIO_STATUS_CALLBACK_URL = os.Getenv("TWILIO_STATUS_CALLBACK_URL")
+    cfg.TWILIO_FROM = os.Getenv("TWILIO_FROM")
+    if cfg.TWILIO_FROM == "" {
+      return nil, fmt.Errorf("TWILIO_FROM environment variable is empty")
+    }
+  }
+
+  // --- Events ---
+  cfg.EVENTS_PROVIDER = getEnvOrDefault("EVENTS_PROVIDER", constants.EVENTS_PROVIDER_EVENTBRIDGE)
+  if cfg.EVENTS_PROVIDER != constants.EVENTS_PROVIDER_EVENTBRIDGE && cfg.EVENTS_PROVIDER != constants.EVENTS_PROVIDER_MEMORY {
+    return nil, fmt.Errorf("EVENTS_PROVIDER must be %q or %q", constants.EVENTS_PROVIDER_EVENTBRIDGE, constants.EVENTS_PROVIDER_MEMORY)
+  }
+  cfg.EVENT_BUS_NAME = getEnvOrDefault("EVENT_BUS_NAME", "kfc-rec-events")
+
+  // --- Follow-ups ---
+  // Invitations unanswered after FOLLOWUP_AFTER_HOURS are followed up to
+  // FOLLOWUP_MAX_ATTEMPTS times by the follow-up lambda; 0 hours disables it
+  followUpHours, err := utils.ParseStringToInt(getEnvOrDefault("FOLLOWUP_AFTER_HOURS", "24"))
+  if err != nil || followUpHours < 0 {
+    return nil, fmt.Errorf("FOLLOWUP_AFTER_HOURS must be a non-negative integer")
+  }
+  cfg.FOLLOWUP_AFTER = time.Duration(followUpHours) * time.Hour
+
+  followUpAttempts, err := utils.ParseStringToInt(getEnvOrDefault("FOLLOWUP_MAX_ATTEMPTS", "2"))
+  if err != nil || followUpAttempts < 0 {
+    return nil, fmt.Errorf("FOLLOWUP_MAX_ATTEMPTS must be a non-negative integer")
+  }
+  cfg.FOLLOWUP_MAX_ATTEMPTS = followUpAttempts
+
+  cfg.SCHEDULER_PROVIDER = getEnvOrDefault("SCHEDULER_PROVIDER", constants.SCHEDULER_PROVIDER_EVENTBRIDGE)
+  switch cfg.SCHEDULER_PROVIDER {
+  case constants.SCHEDULER_PROVIDER_EVENTBRIDGE:
+    cfg.SCHEDULER_GROUP_NAME = os.Getenv("SCHEDULER_GROUP_NAME")
+    cfg.SCHEDULER_ROLE_ARN = os.Getenv("SCHEDULER_ROLE_ARN")
+    cfg.FOLLOWUP_TARGET_ARN = os.Getenv("FOLLOWUP_TARGET_ARN")
+    if cfg.FOLLOWUP_AFTER > 0 && (cfg.SCHEDULER_ROLE_ARN == "" || cfg.FOLLOWUP_TARGET_ARN == "") {
+      return nil, fmt.Errorf("SCHEDULER_ROLE_ARN and FOLLOWUP_TARGET_ARN environment variables are required for follow-ups")
+    }
+  case constants.SCHEDULER_PROVIDER_MEMORY:
+  default:
+    return nil, fmt.Errorf("SCHEDULER_PROVIDER must be %q or %q", constants.SCHEDULER_PROVIDER_EVENTBRIDGE, constants.SCHEDULER_PROVIDER_MEMORY)
+  }
    
   // --- Return ---
   return cfg, nil
 }
 
+func getEnvOrDefault(key, fallback string) string {
+  if value := os.Getenv(key); value != "" {
+    return value
+  }
+  return fallback
+}
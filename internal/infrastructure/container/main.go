@@ -2,20 +2,39 @@ package container
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 
+	"github.com/Yolto7/api-candidates/internal/application/dispatcher"
 	"github.com/Yolto7/api-candidates/internal/application/services/commands"
 	"github.com/Yolto7/api-candidates/internal/application/services/queries"
 	dConfig "github.com/Yolto7/api-candidates/internal/domain/config"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/ports"
+	iAdapters "github.com/Yolto7/api-candidates/internal/infrastructure/adapters"
 	iConfig "github.com/Yolto7/api-candidates/internal/infrastructure/config"
 	iRepositories "github.com/Yolto7/api-candidates/internal/infrastructure/repositories"
+	"github.com/Yolto7/api-candidates/internal/presentation/consumers"
 	"github.com/Yolto7/api-candidates/internal/presentation/controllers"
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
 	pkgDLogger "github.com/Yolto7/api-candidates/pkg/domain/logger"
+	pkgIAdapters "github.com/Yolto7/api-candidates/pkg/infrastructure/adapters"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/crypto"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/eventbus"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/idempotency"
 	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
 	"github.com/Yolto7/api-candidates/pkg/infrastructure/middlewares"
 	"github.com/Yolto7/api-candidates/pkg/infrastructure/persistence/dynamo"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/schedules"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/secrets"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/validators"
 )
 
 // =============================================================================
@@ -33,14 +52,102 @@ type MainLambdaContainer struct {
 	dynamoClient     *dynamodb.Client
 	dynamoErr        error
 	
+	repositoryOnce   sync.Once
+	candidateRepo    *iRepositories.CandidateDynamoRepository
+	repositoryErr    error
+
+	outboxRepoOnce   sync.Once
+	outboxRepo       *iRepositories.OutboxDynamoRepository
+	outboxRepoErr    error
+
 	controllersOnce  sync.Once
 	controller       *controllers.CandidateController
 	controllersErr   error
+
+	whatsAppOnce     sync.Once
+	whatsAppController *controllers.WhatsAppWebhookController
+	whatsAppErr      error
 	
 	middlewaresOnce  sync.Once
 	traceMiddleware  middlewares.Middleware
+	recoveryMiddleware middlewares.Middleware
 	baseMiddleware   middlewares.Middleware
 	errorMiddleware  middlewares.Middleware
+
+	encryptorOnce    sync.Once
+	itemEncryptor    *dynamo.ItemEncryptor
+	encryptorErr     error
+
+	secretsOnce      sync.Once
+	secretsManager   ports.SecretsManager
+	secretsErr       error
+
+	hmacOnce         sync.Once
+	hmacMiddleware   middlewares.Middleware
+	hmacErr          error
+
+	sheetOnce        sync.Once
+	sheetSync        *commands.SheetSync
+	sheetErr         error
+
+	meetingsOnce     sync.Once
+	meetings         *commands.InterviewMeetings
+	meetingsErr      error
+
+	senderOnce       sync.Once
+	messageSender    ports.MessageSender
+	senderErr        error
+
+	messageOnce      sync.Once
+	messageController *controllers.MessageController
+	messageErr       error
+
+	messageRepoOnce  sync.Once
+	messageRepo      *iRepositories.MessageDynamoRepository
+	messageRepoErr   error
+
+	fallbackOnce     sync.Once
+	fallbackSender   ports.MessageSender
+	fallbackErr      error
+
+	statusOnce       sync.Once
+	recordStatus     *commands.RecordMessageStatusService
+	statusErr        error
+
+	twilioOnce       sync.Once
+	twilioController *controllers.TwilioWebhookController
+	twilioErr        error
+
+	outboxOnce       sync.Once
+	outboxController *controllers.OutboxController
+	outboxErr        error
+
+	slotRepoOnce     sync.Once
+	slotRepo         *iRepositories.InterviewSlotDynamoRepository
+	slotRepoErr      error
+
+	slotOnce         sync.Once
+	slotController   *controllers.InterviewSlotController
+	slotErr          error
+
+	processorOnce    sync.Once
+	processOutbox    *commands.ProcessOutboxService
+	processorErr     error
+
+	dispatcherOnce   sync.Once
+	dispatcher       *dispatcher.Dispatcher
+
+	streamOnce       sync.Once
+	streamConsumer   *consumers.CandidateStreamConsumer
+	streamErr        error
+
+	followUpsOnce    sync.Once
+	followUps        *commands.FollowUps
+	followUpsErr     error
+
+	followUpOnce     sync.Once
+	processFollowUp  *commands.ProcessFollowUpService
+	followUpErr      error
 }
 
 func NewMainLambdaContainer(ctx context.Context) (*MainLambdaContainer, error) {
@@ -48,6 +155,10 @@ func NewMainLambdaContainer(ctx context.Context) (*MainLambdaContainer, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if err := validators.SetTimeZone(config.TIME_ZONE); err != nil {
+		return nil, err
+	}
 	
 	return &MainLambdaContainer{
 		ctx:    ctx,
@@ -63,51 +174,930 @@ func (c *MainLambdaContainer) getDynamoClient() (*dynamodb.Client, error) {
 	return c.dynamoClient, c.dynamoErr
 }
 
+func (c *MainLambdaContainer) getSecretsManager() (ports.SecretsManager, error) {
+	c.secretsOnce.Do(func() {
+		client, err := secrets.GetClient(c.ctx)
+		if err != nil {
+			c.secretsErr = err
+			return
+		}
+		c.secretsManager = iAdapters.NewAWSSecretsManager(c.logger, client, iAdapters.NewMemorySecretCache())
+	})
+	return c.secretsManager, c.secretsErr
+}
+
+// getItemEncryptor - Cifrado de PII con KMS (o llave estática en local) e índices ciegos
+func (c *MainLambdaContainer) getItemEncryptor() (*dynamo.ItemEncryptor, error) {
+	c.encryptorOnce.Do(func() {
+		var provider crypto.KeyProvider
+		var blindKey []byte
+
+		switch c.config.ENCRYPTION_PROVIDER {
+		case constants.ENCRYPTION_PROVIDER_STATIC:
+			masterKey, err := base64.StdEncoding.DecodeString(c.config.ENCRYPTION_STATIC_KEY)
+			if err != nil {
+				c.encryptorErr = fmt.Errorf("ENCRYPTION_STATIC_KEY must be base64: %w", err)
+				return
+			}
+			if provider, err = crypto.NewStaticKeyProvider(masterKey); err != nil {
+				c.encryptorErr = err
+				return
+			}
+			blindKey = crypto.DeriveKey(masterKey, "blind-index")
+
+		default:
+			kmsClient, err := crypto.GetKMSClient(c.ctx)
+			if err != nil {
+				c.encryptorErr = err
+				return
+			}
+			provider = crypto.NewKMSKeyProvider(kmsClient, c.config.ENCRYPTION_KMS_KEY_ID, map[string]string{
+				"service": "kfc-rec-candidates",
+			})
+
+			secretsManager, err := c.getSecretsManager()
+			if err != nil {
+				c.encryptorErr = err
+				return
+			}
+			secret, err := secretsManager.GetSecret(c.ctx, c.config.BLIND_INDEX_SECRET_NAME, nil)
+			if err != nil {
+				c.encryptorErr = err
+				return
+			}
+			if blindKey, err = base64.StdEncoding.DecodeString(secret.Value); err != nil {
+				c.encryptorErr = fmt.Errorf("blind index secret must be base64: %w", err)
+				return
+			}
+		}
+
+		blindIndex, err := crypto.NewBlindIndex(blindKey)
+		if err != nil {
+			c.encryptorErr = err
+			return
+		}
+		c.itemEncryptor = dynamo.NewItemEncryptor(crypto.NewEnvelope(crypto.EnvelopeConfig{Provider: provider}), blindIndex)
+	})
+	return c.itemEncryptor, c.encryptorErr
+}
+
 func (c *MainLambdaContainer) Logger() pkgDLogger.Logger {
 	return c.logger
 }
 
+// getOutboxRepository - Efectos secundarios (hoja, mensajes) escritos junto al cambio del candidato
+func (c *MainLambdaContainer) getOutboxRepository() (*iRepositories.OutboxDynamoRepository, error) {
+	c.outboxRepoOnce.Do(func() {
+		dynamoClient, err := c.getDynamoClient()
+		if err != nil {
+			c.outboxRepoErr = err
+			return
+		}
+
+		itemEncryptor, err := c.getItemEncryptor()
+		if err != nil {
+			c.outboxRepoErr = err
+			return
+		}
+
+		c.outboxRepo = iRepositories.NewOutboxDynamoRepository(c.logger, dynamoClient, c.config.OUTBOX_TABLE_NAME, c.config.OUTBOX_STATUS_INDEX, itemEncryptor)
+	})
+	return c.outboxRepo, c.outboxRepoErr
+}
+
+func (c *MainLambdaContainer) getCandidateRepository() (*iRepositories.CandidateDynamoRepository, error) {
+	c.repositoryOnce.Do(func() {
+		dynamoClient, err := c.getDynamoClient()
+		if err != nil {
+			c.repositoryErr = err
+			return
+		}
+		
+		itemEncryptor, err := c.getItemEncryptor()
+		if err != nil {
+			c.repositoryErr = err
+			return
+		}
+
+		outboxRepo, err := c.getOutboxRepository()
+		if err != nil {
+			c.repositoryErr = err
+			return
+		}
+		
+		c.candidateRepo = iRepositories.NewCandidateDynamoRepository(c.logger, dynamoClient, c.config.CANDIDATES_TABLE_NAME, c.config.CANDIDATES_PHONE_INDEX, c.config.CANDIDATES_DOCUMENT_INDEX, itemEncryptor, outboxRepo)
+	})
+	return c.candidateRepo, c.repositoryErr
+}
+
 func (c *MainLambdaContainer) GetCandidateController() (*controllers.CandidateController, error) {
 	c.controllersOnce.Do(func() {
-		dynamoClient, err := c.getDynamoClient()
+		candidateRepo, err := c.getCandidateRepository()
 		if err != nil {
 			c.controllersErr = err
 			return
 		}
 		
-		candidateRepo := iRepositories.NewCandidateDynamoRepository(c.logger, dynamoClient, c.config.CANDIDATES_TABLE_NAME)
-		
+		messageRepo, err := c.getMessageRepository()
+		if err != nil {
+			c.controllersErr = err
+			return
+		}
+
 		getByIDService := queries.NewGetByIDService(queries.GetByIDServiceConfig{
 			CandidateRepository: candidateRepo,
+			MessageRepository:   messageRepo,
+		})
+		
+		findByPhoneService := queries.NewFindByPhoneService(queries.FindByPhoneServiceConfig{
+			CandidateRepository: candidateRepo,
+		})
+
+		findByDocumentService := queries.NewFindByDocumentService(queries.FindByDocumentServiceConfig{
+			CandidateRepository: candidateRepo,
 		})
 		
 		createService := commands.NewCreateService(commands.CreateServiceConfig{
 			Config:                 c.config,
 			Logger:         				c.logger,
 			CandidateRepository: candidateRepo,
+			Dispatcher:          c.GetEventDispatcher(),
+		})
+
+		sheetSync, err := c.getSheetSync()
+		if err != nil {
+			c.controllersErr = err
+			return
+		}
+
+		updateService := commands.NewUpdateService(commands.UpdateServiceConfig{
+			Config:                 c.config,
+			Logger:         				c.logger,
+			CandidateRepository: candidateRepo,
+			SheetSync:           sheetSync,
 		})
 
+		slotRepo, err := c.getSlotRepository()
+		if err != nil {
+			c.controllersErr = err
+			return
+		}
+
+		meetings, err := c.getInterviewMeetings()
+		if err != nil {
+			c.controllersErr = err
+			return
+		}
+
 		deleteService := commands.NewDeleteService(commands.DeleteServiceConfig{
 			Config:                 c.config,
 			Logger:         				c.logger,
 			CandidateRepository: candidateRepo,
+			SlotRepository:      slotRepo,
+			Meetings:            meetings,
+			Dispatcher:          c.GetEventDispatcher(),
 		})
 		
 		c.controller = controllers.NewCandidateController(controllers.CandidateControllerConfig{
 			Logger:         c.logger,
 			GetByIDService: getByIDService,
+			FindByPhoneService: findByPhoneService,
+			FindByDocumentService: findByDocumentService,
 			CreateService:  createService,
+			UpdateService:  updateService,
 			DeleteService:  deleteService,
 		})
 	})
 	return c.controller, c.controllersErr
 }
 
-func (c *MainLambdaContainer) GetMiddlewares() (trace, base, errorMw middlewares.Middleware) {
+// GetMiddlewares - Middlewares globales en orden de ejecución (trace, base, error, recovery).
+// Recovery va dentro de error para que un panic se responda con el formato y las cabeceras de cualquier error
+func (c *MainLambdaContainer) GetMiddlewares() []middlewares.Middleware {
 	c.middlewaresOnce.Do(func() {
 		c.traceMiddleware = middlewares.TraceMiddleware(c.logger)
+		c.recoveryMiddleware = middlewares.RecoveryMiddleware(c.logger)
 		c.baseMiddleware = middlewares.BaseMiddleware(c.logger)
-		c.errorMiddleware = middlewares.ErrorMiddleware(c.logger)
+		c.errorMiddleware = middlewares.ErrorMiddlewareWithConfig(c.logger, middlewares.ErrorMiddlewareConfig{
+			DefaultFormat:      middlewares.ErrorFormatEnvelope,
+			ProblemTypeBaseURL: c.config.PROBLEM_TYPE_BASE_URL,
+		})
+	})
+	return []middlewares.Middleware{c.traceMiddleware, c.baseMiddleware, c.errorMiddleware, c.recoveryMiddleware}
+}
+
+// GetHMACAuthMiddleware - Autenticación para clientes máquina (automatización de sheets)
+func (c *MainLambdaContainer) GetHMACAuthMiddleware() (middlewares.Middleware, error) {
+	c.hmacOnce.Do(func() {
+		dynamoClient, err := c.getDynamoClient()
+		if err != nil {
+			c.hmacErr = err
+			return
+		}
+
+		secretsManager, err := c.getSecretsManager()
+		if err != nil {
+			c.hmacErr = err
+			return
+		}
+
+		prefix := strings.TrimSuffix(c.config.WEBHOOK_SECRET_PREFIX, "/")
+		c.hmacMiddleware = middlewares.HMACAuthMiddleware(c.logger, middlewares.HMACAuthConfig{
+			SecretLookup: func(ctx context.Context, clientID string) (string, error) {
+				secret, err := secretsManager.GetSecret(ctx, fmt.Sprintf("%s/%s", prefix, clientID), nil)
+				if err != nil {
+					return "", err
+				}
+				return secret.Value, nil
+			},
+			Nonces:       idempotency.NewDynamoStore(dynamoClient, c.config.IDEMPOTENCY_TABLE_NAME),
+			ReplayWindow: c.config.WEBHOOK_REPLAY_WINDOW,
+		})
+	})
+	return c.hmacMiddleware, c.hmacErr
+}
+
+
+// whatsAppSecret - Secreto JSON con el app secret (firma), el verify token (suscripción)
+// y el access token (envío de mensajes)
+type whatsAppSecret struct {
+	AppSecret   string `json:"appSecret"`
+	VerifyToken string `json:"verifyToken"`
+	AccessToken string `json:"accessToken"`
+}
+
+func (c *MainLambdaContainer) getWhatsAppSecret(ctx context.Context) (*whatsAppSecret, error) {
+	secretsManager, err := c.getSecretsManager()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := secretsManager.GetSecret(ctx, c.config.WHATSAPP_SECRET_NAME, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret whatsAppSecret
+	if err := json.Unmarshal([]byte(value.Value), &secret); err != nil {
+		return nil, fmt.Errorf("invalid WhatsApp secret: %w", err)
+	}
+	return &secret, nil
+}
+
+// getAIService - Modelo compatible con OpenAI si AI_API_URL está configurado, si no reglas por palabras clave
+func (c *MainLambdaContainer) getAIService() ports.AIService {
+	keywords := iAdapters.NewKeywordAIService()
+	if c.config.AI_API_URL == "" {
+		return keywords
+	}
+
+	return iAdapters.NewOpenAIService(c.logger, iAdapters.OpenAIServiceConfig{
+		BaseURL: c.config.AI_API_URL,
+		Model:   c.config.AI_MODEL,
+		APIKey: func(ctx context.Context) (string, error) {
+			secretsManager, err := c.getSecretsManager()
+			if err != nil {
+				return "", err
+			}
+			secret, err := secretsManager.GetSecret(ctx, c.config.AI_API_KEY_SECRET_NAME, nil)
+			if err != nil {
+				return "", err
+			}
+			return secret.Value, nil
+		},
+		Fallback: keywords,
+	})
+}
+
+// getSheetSync - Escritura de cambios del candidato en Smartsheet (o en memoria en local) vía outbox
+func (c *MainLambdaContainer) getSheetSync() (*commands.SheetSync, error) {
+	c.sheetOnce.Do(func() {
+		// Los registros se entregan con los valores actuales del candidato
+		candidateRepo, err := c.getCandidateRepository()
+		if err != nil {
+			c.sheetErr = err
+			return
+		}
+
+		if c.config.SHEETS_PROVIDER == constants.SHEETS_PROVIDER_MEMORY {
+			c.sheetSync = commands.NewSheetSync(c.logger, iAdapters.NewMemorySheetClient(), candidateRepo)
+			return
+		}
+
+		httpClient, err := pkgIAdapters.NewHTTPClient(pkgIAdapters.HTTPClientConfig{
+			Timeout: 10 * time.Second,
+		})
+		if err != nil {
+			c.sheetErr = err
+			return
+		}
+
+		client := iAdapters.NewSmartsheetClient(c.logger, httpClient, c.config.SMARTSHEET_API_URL, func(ctx context.Context) (string, error) {
+			secretsManager, err := c.getSecretsManager()
+			if err != nil {
+				return "", err
+			}
+			secret, err := secretsManager.GetSecret(ctx, c.config.SMARTSHEET_TOKEN_SECRET_NAME, nil)
+			if err != nil {
+				return "", err
+			}
+			return secret.Value, nil
+		})
+		c.sheetSync = commands.NewSheetSync(c.logger, client, candidateRepo)
+	})
+	return c.sheetSync, c.sheetErr
+}
+
+// getInterviewMeetings - Reuniones de Zoom (o locales con URL fija) para las entrevistas virtuales
+func (c *MainLambdaContainer) getInterviewMeetings() (*commands.InterviewMeetings, error) {
+	c.meetingsOnce.Do(func() {
+		if c.config.MEETINGS_PROVIDER == constants.MEETINGS_PROVIDER_LOCAL {
+			c.meetings = commands.NewInterviewMeetings(c.logger, iAdapters.NewLocalMeetingProvider(c.config.MEETINGS_LOCAL_URL))
+			return
+		}
+
+		httpClient, err := pkgIAdapters.NewHTTPClient(pkgIAdapters.HTTPClientConfig{
+			Timeout: 10 * time.Second,
+		})
+		if err != nil {
+			c.meetingsErr = err
+			return
+		}
+
+		provider := iAdapters.NewZoomMeetingProvider(c.logger, httpClient, c.config.ZOOM_API_URL, func(ctx context.Context) (*iAdapters.ZoomCredentials, error) {
+			secretsManager, err := c.getSecretsManager()
+			if err != nil {
+				return nil, err
+			}
+			secret, err := secretsManager.GetSecret(ctx, c.config.ZOOM_SECRET_NAME, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var credentials iAdapters.ZoomCredentials
+			if err := json.Unmarshal([]byte(secret.Value), &credentials); err != nil {
+				return nil, fmt.Errorf("invalid Zoom secret: %w", err)
+			}
+			return &credentials, nil
+		})
+		c.meetings = commands.NewInterviewMeetings(c.logger, provider)
+	})
+	return c.meetings, c.meetingsErr
+}
+
+func (c *MainLambdaContainer) GetWhatsAppWebhookController() (*controllers.WhatsAppWebhookController, error) {
+	c.whatsAppOnce.Do(func() {
+		dynamoClient, err := c.getDynamoClient()
+		if err != nil {
+			c.whatsAppErr = err
+			return
+		}
+
+		candidateRepo, err := c.getCandidateRepository()
+		if err != nil {
+			c.whatsAppErr = err
+			return
+		}
+
+		sheetSync, err := c.getSheetSync()
+		if err != nil {
+			c.whatsAppErr = err
+			return
+		}
+
+		recordStatus, err := c.getRecordMessageStatusService()
+		if err != nil {
+			c.whatsAppErr = err
+			return
+		}
+
+		registerResponseService := commands.NewRegisterResponseService(commands.RegisterResponseServiceConfig{
+			Config:              c.config,
+			Logger:              c.logger,
+			CandidateRepository: candidateRepo,
+			AIService:           c.getAIService(),
+			SheetSync:           sheetSync,
+			Dispatcher:          c.GetEventDispatcher(),
+		})
+
+		c.whatsAppController = controllers.NewWhatsAppWebhookController(controllers.WhatsAppWebhookControllerConfig{
+			Logger: c.logger,
+			VerifyToken: func(ctx context.Context) (string, error) {
+				secret, err := c.getWhatsAppSecret(ctx)
+				if err != nil {
+					return "", err
+				}
+				return secret.VerifyToken, nil
+			},
+			Messages:                   idempotency.NewDynamoStore(dynamoClient, c.config.IDEMPOTENCY_TABLE_NAME),
+			RegisterResponseService:    registerResponseService,
+			RecordMessageStatusService: recordStatus,
+		})
+	})
+	return c.whatsAppController, c.whatsAppErr
+}
+
+// GetWhatsAppSignatureMiddleware - Verifica x-hub-signature-256 con el app secret de WhatsApp
+func (c *MainLambdaContainer) GetWhatsAppSignatureMiddleware() middlewares.Middleware {
+	return middlewares.HubSignatureMiddleware(c.logger, func(ctx context.Context) (string, error) {
+		secret, err := c.getWhatsAppSecret(ctx)
+		if err != nil {
+			return "", err
+		}
+		return secret.AppSecret, nil
+	})
+}
+
+// getMessageSender - WhatsApp Cloud API, SMS por Twilio o en memoria en local
+func (c *MainLambdaContainer) getMessageSender() (ports.MessageSender, error) {
+	c.senderOnce.Do(func() {
+		if c.config.MESSAGING_PROVIDER == constants.MESSAGING_PROVIDER_MEMORY {
+			c.messageSender = iAdapters.NewMemoryMessageSender(ports.ChannelWhatsApp)
+			return
+		}
+
+		httpClient, err := pkgIAdapters.NewHTTPClient(pkgIAdapters.HTTPClientConfig{
+			Timeout: 10 * time.Second,
+		})
+		if err != nil {
+			c.senderErr = err
+			return
+		}
+
+		if c.config.MESSAGING_PROVIDER == constants.MESSAGING_PROVIDER_SMS {
+			c.messageSender = c.newTwilioSender(httpClient)
+			return
+		}
+
+		c.messageSender = iAdapters.NewWhatsAppSender(c.logger, httpClient, c.config.WHATSAPP_API_URL, c.config.WHATSAPP_PHONE_NUMBER_ID, func(ctx context.Context) (string, error) {
+			secret, err := c.getWhatsAppSecret(ctx)
+			if err != nil {
+				return "", err
+			}
+			return secret.AccessToken, nil
+		})
+	})
+	return c.messageSender, c.senderErr
+}
+
+// getFallbackSender - Canal para reenviar los mensajes de WhatsApp fallidos; nil si no está configurado
+func (c *MainLambdaContainer) getFallbackSender() (ports.MessageSender, error) {
+	c.fallbackOnce.Do(func() {
+		switch c.config.MESSAGING_FALLBACK_PROVIDER {
+		case constants.MESSAGING_PROVIDER_MEMORY:
+			c.fallbackSender = iAdapters.NewMemoryMessageSender(ports.ChannelSMS)
+		case constants.MESSAGING_PROVIDER_SMS:
+			httpClient, err := pkgIAdapters.NewHTTPClient(pkgIAdapters.HTTPClientConfig{
+				Timeout: 10 * time.Second,
+			})
+			if err != nil {
+				c.fallbackErr = err
+				return
+			}
+			c.fallbackSender = c.newTwilioSender(httpClient)
+		}
+	})
+	return c.fallbackSender, c.fallbackErr
+}
+
+func (c *MainLambdaContainer) newTwilioSender(httpClient *http.Client) *iAdapters.TwilioSMSSender {
+	return iAdapters.NewTwilioSMSSender(c.logger, httpClient, c.config.TWILIO_API_URL, c.config.TWILIO_FROM, c.config.TWILIO_STATUS_CALLBACK_URL, c.getTwilioCredentials)
+}
+
+func (c *MainLambdaContainer) getTwilioCredentials(ctx context.Context) (*iAdapters.TwilioCredentials, error) {
+	secretsManager, err := c.getSecretsManager()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := secretsManager.GetSecret(ctx, c.config.TWILIO_SECRET_NAME, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var credentials iAdapters.TwilioCredentials
+	if err := json.Unmarshal([]byte(secret.Value), &credentials); err != nil {
+		return nil, fmt.Errorf("invalid Twilio secret: %w", err)
+	}
+	return &credentials, nil
+}
+
+// getMessageRepository - Mensajes enviados con su historial de estados de entrega
+func (c *MainLambdaContainer) getMessageRepository() (*iRepositories.MessageDynamoRepository, error) {
+	c.messageRepoOnce.Do(func() {
+		dynamoClient, err := c.getDynamoClient()
+		if err != nil {
+			c.messageRepoErr = err
+			return
+		}
+
+		c.messageRepo = iRepositories.NewMessageDynamoRepository(c.logger, dynamoClient, c.config.MESSAGES_TABLE_NAME, c.config.MESSAGES_CANDIDATE_INDEX)
+	})
+	return c.messageRepo, c.messageRepoErr
+}
+
+// getRecordMessageStatusService - Estados de entrega de WhatsApp y Twilio, con reenvío por el canal alterno
+func (c *MainLambdaContainer) getRecordMessageStatusService() (*commands.RecordMessageStatusService, error) {
+	c.statusOnce.Do(func() {
+		candidateRepo, err := c.getCandidateRepository()
+		if err != nil {
+			c.statusErr = err
+			return
+		}
+
+		messageRepo, err := c.getMessageRepository()
+		if err != nil {
+			c.statusErr = err
+			return
+		}
+
+		fallback, err := c.getFallbackSender()
+		if err != nil {
+			c.statusErr = err
+			return
+		}
+
+		sheetSync, err := c.getSheetSync()
+		if err != nil {
+			c.statusErr = err
+			return
+		}
+
+		c.recordStatus = commands.NewRecordMessageStatusService(commands.RecordMessageStatusServiceConfig{
+			Config:              c.config,
+			Logger:              c.logger,
+			CandidateRepository: candidateRepo,
+			MessageRepository:   messageRepo,
+			Fallback:            fallback,
+			SheetSync:           sheetSync,
+		})
+	})
+	return c.recordStatus, c.statusErr
+}
+
+func (c *MainLambdaContainer) GetTwilioWebhookController() (*controllers.TwilioWebhookController, error) {
+	c.twilioOnce.Do(func() {
+		recordStatus, err := c.getRecordMessageStatusService()
+		if err != nil {
+			c.twilioErr = err
+			return
+		}
+
+		c.twilioController = controllers.NewTwilioWebhookController(controllers.TwilioWebhookControllerConfig{
+			Logger:                     c.logger,
+			RecordMessageStatusService: recordStatus,
+		})
+	})
+	return c.twilioController, c.twilioErr
+}
+
+// GetTwilioSignatureMiddleware - Verifica x-twilio-signature con el auth token contra TWILIO_STATUS_CALLBACK_URL
+func (c *MainLambdaContainer) GetTwilioSignatureMiddleware() middlewares.Middleware {
+	return middlewares.TwilioSignatureMiddleware(c.logger, c.config.TWILIO_STATUS_CALLBACK_URL, func(ctx context.Context) (string, error) {
+		if c.config.TWILIO_SECRET_NAME == "" {
+			return "", fmt.Errorf("no Twilio secret configured")
+		}
+		credentials, err := c.getTwilioCredentials(ctx)
+		if err != nil {
+			return "", err
+		}
+		return credentials.AuthToken, nil
+	})
+}
+
+func (c *MainLambdaContainer) GetMessageController() (*controllers.MessageController, error) {
+	c.messageOnce.Do(func() {
+		candidateRepo, err := c.getCandidateRepository()
+		if err != nil {
+			c.messageErr = err
+			return
+		}
+
+		sender, err := c.getMessageSender()
+		if err != nil {
+			c.messageErr = err
+			return
+		}
+
+		sheetSync, err := c.getSheetSync()
+		if err != nil {
+			c.messageErr = err
+			return
+		}
+
+		messageRepo, err := c.getMessageRepository()
+		if err != nil {
+			c.messageErr = err
+			return
+		}
+
+		followUps, err := c.getFollowUps()
+		if err != nil {
+			c.messageErr = err
+			return
+		}
+
+		c.messageController = controllers.NewMessageController(controllers.MessageControllerConfig{
+			Logger: c.logger,
+			SendMessageService: commands.NewSendMessageService(commands.SendMessageServiceConfig{
+				Config:              c.config,
+				Logger:              c.logger,
+				CandidateRepository: candidateRepo,
+				MessageRepository:   messageRepo,
+				Sender:              sender,
+				SheetSync:           sheetSync,
+				FollowUps:           followUps,
+			}),
+			ListMessagesService: queries.NewListMessagesService(queries.ListMessagesServiceConfig{
+				CandidateRepository: candidateRepo,
+				MessageRepository:   messageRepo,
+			}),
+		})
+	})
+	return c.messageController, c.messageErr
+}
+
+func (c *MainLambdaContainer) GetOutboxController() (*controllers.OutboxController, error) {
+	c.outboxOnce.Do(func() {
+		outboxRepo, err := c.getOutboxRepository()
+		if err != nil {
+			c.outboxErr = err
+			return
+		}
+
+		c.outboxController = controllers.NewOutboxController(controllers.OutboxControllerConfig{
+			Logger: c.logger,
+			ListFailedOutboxService: queries.NewListFailedOutboxService(queries.ListFailedOutboxServiceConfig{
+				OutboxRepository: outboxRepo,
+			}),
+			RetryOutboxService: commands.NewRetryOutboxService(commands.RetryOutboxServiceConfig{
+				Config:           c.config,
+				Logger:           c.logger,
+				OutboxRepository: outboxRepo,
+			}),
+		})
+	})
+	return c.outboxController, c.outboxErr
+}
+
+// getSlotRepository - Horarios de entrevista por tienda; las reservas escriben también al candidato
+func (c *MainLambdaContainer) getSlotRepository() (*iRepositories.InterviewSlotDynamoRepository, error) {
+	c.slotRepoOnce.Do(func() {
+		dynamoClient, err := c.getDynamoClient()
+		if err != nil {
+			c.slotRepoErr = err
+			return
+		}
+
+		candidateRepo, err := c.getCandidateRepository()
+		if err != nil {
+			c.slotRepoErr = err
+			return
+		}
+
+		outboxRepo, err := c.getOutboxRepository()
+		if err != nil {
+			c.slotRepoErr = err
+			return
+		}
+
+		c.slotRepo = iRepositories.NewInterviewSlotDynamoRepository(c.logger, dynamoClient, c.config.SLOTS_TABLE_NAME, c.config.SLOTS_STORE_INDEX, candidateRepo, outboxRepo)
+	})
+	return c.slotRepo, c.slotRepoErr
+}
+
+func (c *MainLambdaContainer) GetInterviewSlotController() (*controllers.InterviewSlotController, error) {
+	c.slotOnce.Do(func() {
+		slotRepo, err := c.getSlotRepository()
+		if err != nil {
+			c.slotErr = err
+			return
+		}
+
+		candidateRepo, err := c.getCandidateRepository()
+		if err != nil {
+			c.slotErr = err
+			return
+		}
+
+		sheetSync, err := c.getSheetSync()
+		if err != nil {
+			c.slotErr = err
+			return
+		}
+
+		meetings, err := c.getInterviewMeetings()
+		if err != nil {
+			c.slotErr = err
+			return
+		}
+
+		c.slotController = controllers.NewInterviewSlotController(controllers.InterviewSlotControllerConfig{
+			Logger: c.logger,
+			ListSlotsService: queries.NewListSlotsService(queries.ListSlotsServiceConfig{
+				Config:         c.config,
+				SlotRepository: slotRepo,
+			}),
+			PublishSlotsService: commands.NewPublishSlotsService(commands.PublishSlotsServiceConfig{
+				Config:         c.config,
+				Logger:         c.logger,
+				SlotRepository: slotRepo,
+			}),
+			BookSlotService: commands.NewBookSlotService(commands.BookSlotServiceConfig{
+				Config:              c.config,
+				Logger:              c.logger,
+				CandidateRepository: candidateRepo,
+				SlotRepository:      slotRepo,
+				SheetSync:           sheetSync,
+				Meetings:            meetings,
+			}),
+			CancelBookingService: commands.NewCancelBookingService(commands.CancelBookingServiceConfig{
+				Config:              c.config,
+				Logger:              c.logger,
+				CandidateRepository: candidateRepo,
+				SlotRepository:      slotRepo,
+				SheetSync:           sheetSync,
+				Meetings:            meetings,
+			}),
+		})
+	})
+	return c.slotController, c.slotErr
+}
+
+// GetProcessOutboxService - Entrega de registros del outbox (lambda outbox), un handler por tipo
+func (c *MainLambdaContainer) GetProcessOutboxService() (*commands.ProcessOutboxService, error) {
+	c.processorOnce.Do(func() {
+		outboxRepo, err := c.getOutboxRepository()
+		if err != nil {
+			c.processorErr = err
+			return
+		}
+
+		sheetSync, err := c.getSheetSync()
+		if err != nil {
+			c.processorErr = err
+			return
+		}
+
+		meetings, err := c.getInterviewMeetings()
+		if err != nil {
+			c.processorErr = err
+			return
+		}
+
+		followUps, err := c.getFollowUps()
+		if err != nil {
+			c.processorErr = err
+			return
+		}
+
+		handlers := map[string]commands.OutboxHandler{
+			entities.OutboxSheetUpdate:   sheetSync,
+			entities.OutboxMeetingCancel: meetings,
+		}
+		if followUps != nil {
+			handlers[entities.OutboxFollowUpSchedule] = followUps
+		}
+
+		c.processOutbox = commands.NewProcessOutboxService(commands.ProcessOutboxServiceConfig{
+			Config:           c.config,
+			Logger:           c.logger,
+			OutboxRepository: outboxRepo,
+			Handlers:         handlers,
+			MaxAttempts:      c.config.OUTBOX_MAX_ATTEMPTS,
+		})
+	})
+	return c.processOutbox, c.processorErr
+}
+
+// getScheduler - EventBridge Scheduler (o en memoria en local) para los trabajos de una sola ejecución
+func (c *MainLambdaContainer) getScheduler() (ports.Scheduler, error) {
+	if c.config.SCHEDULER_PROVIDER == constants.SCHEDULER_PROVIDER_MEMORY {
+		return iAdapters.NewMemoryScheduler(), nil
+	}
+
+	client, err := schedules.GetClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return iAdapters.NewEventBridgeScheduler(c.logger, client, c.config.SCHEDULER_GROUP_NAME), nil
+}
+
+// getFollowUps - Política de seguimiento de invitaciones sin respuesta; nil si FOLLOWUP_AFTER_HOURS es 0
+func (c *MainLambdaContainer) getFollowUps() (*commands.FollowUps, error) {
+	c.followUpsOnce.Do(func() {
+		if c.config.FOLLOWUP_AFTER <= 0 {
+			return
+		}
+
+		scheduler, err := c.getScheduler()
+		if err != nil {
+			c.followUpsErr = err
+			return
+		}
+
+		c.followUps = commands.NewFollowUps(commands.FollowUpsConfig{
+			Logger:      c.logger,
+			Scheduler:   scheduler,
+			After:       c.config.FOLLOWUP_AFTER,
+			MaxAttempts: c.config.FOLLOWUP_MAX_ATTEMPTS,
+			TargetArn:   c.config.FOLLOWUP_TARGET_ARN,
+			RoleArn:     c.config.SCHEDULER_ROLE_ARN,
+		})
+	})
+	return c.followUps, c.followUpsErr
+}
+
+// GetProcessFollowUpService - Revisión programada de invitaciones sin respuesta (lambda followup)
+func (c *MainLambdaContainer) GetProcessFollowUpService() (*commands.ProcessFollowUpService, error) {
+	c.followUpOnce.Do(func() {
+		followUps, err := c.getFollowUps()
+		if err != nil {
+			c.followUpErr = err
+			return
+		}
+		if followUps == nil {
+			c.followUpErr = fmt.Errorf("follow-ups are disabled: FOLLOWUP_AFTER_HOURS is 0")
+			return
+		}
+
+		candidateRepo, err := c.getCandidateRepository()
+		if err != nil {
+			c.followUpErr = err
+			return
+		}
+
+		messageRepo, err := c.getMessageRepository()
+		if err != nil {
+			c.followUpErr = err
+			return
+		}
+
+		sender, err := c.getMessageSender()
+		if err != nil {
+			c.followUpErr = err
+			return
+		}
+
+		sheetSync, err := c.getSheetSync()
+		if err != nil {
+			c.followUpErr = err
+			return
+		}
+
+		c.processFollowUp = commands.NewProcessFollowUpService(commands.ProcessFollowUpServiceConfig{
+			Config:              c.config,
+			Logger:              c.logger,
+			CandidateRepository: candidateRepo,
+			MessageRepository:   messageRepo,
+			Sender:              sender,
+			FollowUps:           followUps,
+			SheetSync:           sheetSync,
+			Dispatcher:          c.GetEventDispatcher(),
+		})
+	})
+	return c.processFollowUp, c.followUpErr
+}
+
+// GetEventDispatcher - Reacciones en proceso a eventos de dominio, después del commit.
+// Los handlers se registran aquí; la lambda espera los asíncronos con Wait antes de responder.
+func (c *MainLambdaContainer) GetEventDispatcher() *dispatcher.Dispatcher {
+	c.dispatcherOnce.Do(func() {
+		c.dispatcher = dispatcher.New(dispatcher.Config{Logger: c.logger})
+		c.dispatcher.On(dispatcher.AllEvents, dispatcher.AuditLog(c.logger))
+	})
+	return c.dispatcher
+}
+
+// getEventPublisher - EventBridge (o en memoria en local) para los eventos de dominio
+func (c *MainLambdaContainer) getEventPublisher() (ports.EventPublisher, error) {
+	if c.config.EVENTS_PROVIDER == constants.EVENTS_PROVIDER_MEMORY {
+		return iAdapters.NewMemoryEventPublisher(), nil
+	}
+
+	client, err := eventbus.GetClient(c.ctx)
+	if err != nil {
+		return nil, err
+	}
+	return iAdapters.NewEventBridgePublisher(c.logger, client, c.config.EVENT_BUS_NAME, constants.EVENT_SOURCE_CANDIDATES), nil
+}
+
+// GetCandidateStreamConsumer - Consumidor del stream de la tabla de candidatos (lambda streams)
+func (c *MainLambdaContainer) GetCandidateStreamConsumer() (*consumers.CandidateStreamConsumer, error) {
+	c.streamOnce.Do(func() {
+		publisher, err := c.getEventPublisher()
+		if err != nil {
+			c.streamErr = err
+			return
+		}
+
+		c.streamConsumer = consumers.NewCandidateStreamConsumer(consumers.CandidateStreamConsumerConfig{
+			Logger:      c.logger,
+			DecodeImage: iRepositories.CandidateFromStreamImage,
+			PublishCandidateChangeService: commands.NewPublishCandidateChangeService(commands.PublishCandidateChangeServiceConfig{
+				Logger:    c.logger,
+				Publisher: publisher,
+			}),
+		})
 	})
-	return c.traceMiddleware, c.baseMiddleware, c.errorMiddleware
+	return c.streamConsumer, c.streamErr
 }
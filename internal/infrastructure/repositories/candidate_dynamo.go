@@ -2,10 +2,12 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
@@ -15,20 +17,37 @@ import (
 	"github.com/Yolto7/api-candidates/internal/domain/repositories"
 	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
 	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/crypto"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/persistence/dynamo"
 	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
 )
 
+// candidateFields are the pii attributes of a candidate, see entities.Candidate
+var candidateFields = crypto.FieldsOf(entities.Candidate{})
+
+// maxMatches bounds FindByPhone and FindByDocument; a phone or document is
+// expected to have a handful of candidacies at most
+const maxMatches = 50
+
 type CandidateDynamoRepository struct {
-	logger logger.Logger
-	client *dynamodb.Client
-	table  string
+	logger        logger.Logger
+	client        *dynamodb.Client
+	table         string
+	phoneIndex    string
+	documentIndex string
+	encryptor     *dynamo.ItemEncryptor
+	outbox        *OutboxDynamoRepository
 }
 
-func NewCandidateDynamoRepository(logger logger.Logger, client *dynamodb.Client, table string) *CandidateDynamoRepository {
+func NewCandidateDynamoRepository(logger logger.Logger, client *dynamodb.Client, table, phoneIndex, documentIndex string, encryptor *dynamo.ItemEncryptor, outbox *OutboxDynamoRepository) *CandidateDynamoRepository {
 	return &CandidateDynamoRepository{
-		logger: logger,
-		client: client,
-		table:  table,
+		logger:        logger,
+		client:        client,
+		table:         table,
+		phoneIndex:    phoneIndex,
+		documentIndex: documentIndex,
+		encryptor:     encryptor,
+		outbox:        outbox,
 	}
 }
 
@@ -41,26 +60,108 @@ func (r *CandidateDynamoRepository) GetByID(ctx context.Context, id string) (*en
 	})
 	if err != nil {
 		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.GetByID: Failed to get candidate"))
-		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Failed to get candidate data", "DATABASE_ERROR")
+		return nil, dynamo.ClassifyError(err, "Failed to get candidate data")
 	}
 	if len(res.Item) == 0 {
 		return nil, nil
 	}
 
+	if err := r.encryptor.DecryptItem(ctx, res.Item, candidateFields, id); err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.GetByID: Failed to decrypt candidate"))
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to decrypt candidate", "ENCRYPTION_ERROR")
+	}
+
 	var candidate entities.Candidate
 	if err := attributevalue.UnmarshalMap(res.Item, &candidate); err != nil {
 		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.GetByID: Failed to unmarshal candidate"))
-		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Failed to unmarshal building", "DATABASE_ERROR")
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to unmarshal candidate", "DATABASE_ERROR")
 	}
 
 	return &candidate, nil
 }
 
-func (r *CandidateDynamoRepository) Create(ctx context.Context, candidate *entities.Candidate) error {
+func (r *CandidateDynamoRepository) FindByPhone(ctx context.Context, phone string) ([]*entities.Candidate, error) {
+	return r.queryCandidates(ctx, "FindByPhone", &dynamodb.QueryInput{
+		TableName:              aws.String(r.table),
+		IndexName:              aws.String(r.phoneIndex),
+		KeyConditionExpression: aws.String("phoneHash = :phoneHash"),
+		FilterExpression:       aws.String("deleted = :deleted"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":phoneHash": &types.AttributeValueMemberS{Value: r.encryptor.BlindIndex("phoneHash", phone)},
+			":deleted":   &types.AttributeValueMemberBOOL{Value: false},
+		},
+		// createdAt is the sort key of the index: newest candidacy first
+		ScanIndexForward: aws.Bool(false),
+	})
+}
+
+func (r *CandidateDynamoRepository) FindByDocument(ctx context.Context, documentType, number string) ([]*entities.Candidate, error) {
+	// documentKey is pii:"hash": the item stores the blind index of the key
+	documentKey := entities.BuildDocumentKey(documentType, strings.ToUpper(number))
+	return r.queryCandidates(ctx, "FindByDocument", &dynamodb.QueryInput{
+		TableName:              aws.String(r.table),
+		IndexName:              aws.String(r.documentIndex),
+		KeyConditionExpression: aws.String("documentKey = :documentKey"),
+		FilterExpression:       aws.String("deleted = :deleted"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":documentKey": &types.AttributeValueMemberS{Value: r.encryptor.BlindIndex("documentKey", documentKey)},
+			":deleted":     &types.AttributeValueMemberBOOL{Value: false},
+		},
+	})
+}
+
+// queryCandidates runs an index query and decrypts up to maxMatches
+// candidates; operation names the caller in the logs
+func (r *CandidateDynamoRepository) queryCandidates(ctx context.Context, operation string, input *dynamodb.QueryInput) ([]*entities.Candidate, error) {
+	candidates := make([]*entities.Candidate, 0)
+	paginator := dynamodb.NewQueryPaginator(r.client, input)
+	for paginator.HasMorePages() && len(candidates) < maxMatches {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.logger.Error(utils.NewSafeError(err, fmt.Sprintf("Error in CandidateRepository.%s: Query failed", operation)))
+			return nil, dynamo.ClassifyError(err, "Failed to find candidates")
+		}
+
+		for _, item := range page.Items {
+			id, _ := item["id"].(*types.AttributeValueMemberS)
+			if id == nil {
+				continue
+			}
+			if err := r.encryptor.DecryptItem(ctx, item, candidateFields, id.Value); err != nil {
+				r.logger.Error(utils.NewSafeError(err, fmt.Sprintf("Error in CandidateRepository.%s: Failed to decrypt candidate", operation)))
+				return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to decrypt candidate", "ENCRYPTION_ERROR")
+			}
+
+			var candidate entities.Candidate
+			if err := attributevalue.UnmarshalMap(item, &candidate); err != nil {
+				r.logger.Error(utils.NewSafeError(err, fmt.Sprintf("Error in CandidateRepository.%s: Failed to unmarshal candidate", operation)))
+				return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to unmarshal candidate", "DATABASE_ERROR")
+			}
+			candidates = append(candidates, &candidate)
+		}
+	}
+
+	if len(candidates) > maxMatches {
+		candidates = candidates[:maxMatches]
+	}
+	return candidates, nil
+}
+
+func (r *CandidateDynamoRepository) Create(ctx context.Context, candidate *entities.Candidate, outbox ...*entities.OutboxRecord) error {
 	item, err := attributevalue.MarshalMap(candidate)
 	if err != nil {
 		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.Create: Failed to marshal candidate"))
-		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Failed to marshal candidate", "DATABASE_ERROR")
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to marshal candidate", "DATABASE_ERROR")
+	}
+
+	if err := r.encryptor.EncryptItem(ctx, item, candidateFields, candidate.ID); err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.Create: Failed to encrypt candidate"))
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encrypt candidate", "ENCRYPTION_ERROR")
+	}
+
+	if len(outbox) > 0 {
+		put := types.TransactWriteItem{Put: &types.Put{TableName: aws.String(r.table), Item: item}}
+		return r.transactWithOutbox(ctx, "Create", put, outbox)
 	}
 
 	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
@@ -69,21 +170,93 @@ func (r *CandidateDynamoRepository) Create(ctx context.Context, candidate *entit
 	})
 	if err != nil {
 		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.Create: Failed to create candidate"))
-		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Failed to create candidate", "DATABASE_ERROR")
+		return dynamo.ClassifyError(err, "Failed to create candidate")
 	}
 
 	return nil
 }
 
-func (r *CandidateDynamoRepository) Update(ctx context.Context, id string, updates map[string]interface{}) error {
+func (r *CandidateDynamoRepository) Update(ctx context.Context, id string, updates map[string]interface{}, outbox ...*entities.OutboxRecord) error {
 	if id == "" {
 		return errorCustom.NewError(errorCustom.BAD_REQUEST, "ID is required for update", "VALIDATION_ERROR")
 	}
 
-	if len(updates) == 0 {
+	if len(updates) == 0 && len(outbox) == 0 {
 		return nil
 	}
 
+	updates, err := r.encryptor.EncryptUpdates(ctx, updates, candidateFields, id)
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.Update: Failed to encrypt fields"))
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encrypt candidate", "ENCRYPTION_ERROR")
+	}
+
+	updateExpr, exprAttrNames, exprAttrValues, err := buildUpdateExpression(updates)
+	if err != nil {
+		return err
+	}
+
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}}
+	if len(outbox) > 0 {
+		if updateExpr == "" {
+			return r.transactWithOutbox(ctx, "Update", types.TransactWriteItem{}, outbox)
+		}
+		update := types.TransactWriteItem{Update: &types.Update{
+			TableName:                 aws.String(r.table),
+			Key:                       key,
+			UpdateExpression:          aws.String(updateExpr),
+			ExpressionAttributeNames:  exprAttrNames,
+			ExpressionAttributeValues: exprAttrValues,
+		}}
+		return r.transactWithOutbox(ctx, "Update", update, outbox)
+	}
+
+	if updateExpr == "" {
+		return nil
+	}
+
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.table),
+		Key:                       key,
+		UpdateExpression:          aws.String(updateExpr),
+		ExpressionAttributeNames:  exprAttrNames,
+		ExpressionAttributeValues: exprAttrValues,
+	})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.Update: Update failed"))
+		return dynamo.ClassifyError(err, "Failed to update candidate")
+	}
+
+	return nil
+}
+
+func (r *CandidateDynamoRepository) UpdateIf(ctx context.Context, id string, guard repositories.UpdateGuard, updates map[string]interface{}, outbox ...*entities.OutboxRecord) error {
+	if id == "" {
+		return errorCustom.NewError(errorCustom.BAD_REQUEST, "ID is required for update", "VALIDATION_ERROR")
+	}
+
+	conditions := []string{"attribute_exists(id)"}
+	if guard.NotBooked {
+		conditions = append(conditions, "attribute_not_exists(interviewSlotId)")
+	}
+	if guard.NotResponded {
+		conditions = append(conditions, "attribute_not_exists(responseAt)")
+	}
+
+	change, err := r.transactUpdate(ctx, id, updates, nil, strings.Join(conditions, " AND "), nil)
+	if err != nil {
+		return err
+	}
+	err = r.transactWithOutbox(ctx, "Update", change, outbox)
+	if dynamo.ConditionFailed(err, 0) {
+		return errorCustom.NewError(errorCustom.CONFLICT, "Candidate changed, try again", "ERR_CANDIDATE_CHANGED").WithCause(err)
+	}
+	return err
+}
+
+// buildUpdateExpression returns a SET expression for the non-null values of
+// updates, or an empty expression if there is nothing to set
+func buildUpdateExpression(updates map[string]interface{}) (string, map[string]string, map[string]types.AttributeValue, error) {
 	exprAttrNames := make(map[string]string)
 	exprAttrValues := make(map[string]types.AttributeValue)
 
@@ -94,7 +267,7 @@ func (r *CandidateDynamoRepository) Update(ctx context.Context, id string, updat
 	for key, value := range updates {
 		av, err := attributevalue.Marshal(value)
 		if err != nil {
-			return errorCustom.NewError(errorCustom.BAD_REQUEST, fmt.Sprintf("Failed to marshal field %s", key), "DATABASE_ERROR")
+			return "", nil, nil, errorCustom.Wrap(err, errorCustom.INTERNAL, fmt.Sprintf("Failed to marshal field %s", key), "DATABASE_ERROR")
 		}
 
 		if av, ok := av.(*types.AttributeValueMemberNULL); ok && av.Value {
@@ -118,37 +291,129 @@ func (r *CandidateDynamoRepository) Update(ctx context.Context, id string, updat
 	}
 
 	if fieldCount == 0 {
-		return nil
+		return "", nil, nil, nil
+	}
+	return updateExpr.String(), exprAttrNames, exprAttrValues, nil
+}
+
+// transactUpdate returns the Update of a candidate for a TransactWriteItems
+// call: updates are encrypted and set, remove lists attributes to delete and
+// condition, with its values, guards the candidate
+func (r *CandidateDynamoRepository) transactUpdate(ctx context.Context, id string, updates map[string]interface{}, remove []string, condition string, values map[string]types.AttributeValue) (types.TransactWriteItem, error) {
+	updates, err := r.encryptor.EncryptUpdates(ctx, updates, candidateFields, id)
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.transactUpdate: Failed to encrypt fields"))
+		return types.TransactWriteItem{}, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encrypt candidate", "ENCRYPTION_ERROR")
+	}
+
+	updateExpr, exprAttrNames, exprAttrValues, err := buildUpdateExpression(updates)
+	if err != nil {
+		return types.TransactWriteItem{}, err
+	}
+	if exprAttrValues == nil {
+		exprAttrValues = make(map[string]types.AttributeValue, len(values))
+	}
+	for k, v := range values {
+		exprAttrValues[k] = v
+	}
+	if len(remove) > 0 {
+		updateExpr = strings.TrimSpace(updateExpr + " REMOVE " + strings.Join(remove, ", "))
 	}
 
-	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+	update := &types.Update{
 		TableName:                 aws.String(r.table),
 		Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}},
-		UpdateExpression:          aws.String(updateExpr.String()),
-		ExpressionAttributeNames:  exprAttrNames,
+		UpdateExpression:          aws.String(updateExpr),
+		ConditionExpression:       aws.String(condition),
 		ExpressionAttributeValues: exprAttrValues,
-	})
+	}
+	if len(exprAttrNames) > 0 {
+		update.ExpressionAttributeNames = exprAttrNames
+	}
+	return types.TransactWriteItem{Update: update}, nil
+}
+
+// transactDelete builds the deletion of a candidate for a transaction
+func (r *CandidateDynamoRepository) transactDelete(id string, condition string, values map[string]types.AttributeValue) types.TransactWriteItem {
+	return types.TransactWriteItem{Delete: &types.Delete{
+		TableName:                 aws.String(r.table),
+		Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}},
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeValues: values,
+	}}
+}
+
+// transactWithOutbox writes the candidate change and its outbox records
+// atomically. An empty change writes the records only.
+func (r *CandidateDynamoRepository) transactWithOutbox(ctx context.Context, operation string, change types.TransactWriteItem, outbox []*entities.OutboxRecord) error {
+	if r.outbox == nil {
+		return errorCustom.NewError(errorCustom.INTERNAL, "Outbox is not configured", "DATABASE_ERROR")
+	}
+
+	items := make([]types.TransactWriteItem, 0, len(outbox)+1)
+	if change.Put != nil || change.Update != nil {
+		items = append(items, change)
+	}
+	for _, record := range outbox {
+		put, err := r.outbox.transactPut(ctx, record)
+		if err != nil {
+			return err
+		}
+		items = append(items, put)
+	}
+
+	_, err := r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
 	if err != nil {
-		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.Update: Update failed"))
-		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Failed to update visit", "DATABASE_ERROR")
+		// A failed condition on the candidate is an expected outcome of
+		// UpdateIf, reported by the caller
+		if !dynamo.ConditionFailed(err, 0) {
+			r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository."+operation+": Transaction failed"))
+		}
+		return dynamo.ClassifyError(err, "Failed to "+strings.ToLower(operation)+" candidate")
 	}
 
 	return nil
 }
 
+// Delete removes a candidate that holds no interview booking; the seat of a
+// booked candidate is released with InterviewSlotRepository.DeleteBooked
 func (r *CandidateDynamoRepository) Delete(ctx context.Context, id string) error {
 	_, err := r.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: aws.String(r.table),
 		Key: map[string]types.AttributeValue{
 			"id": &types.AttributeValueMemberS{Value: id},
 		},
+		ConditionExpression: aws.String("attribute_not_exists(interviewSlotId)"),
 	})
+	var conditionalErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionalErr) {
+		return errorCustom.Wrap(err, errorCustom.CONFLICT, "Interview booking changed, try again", "ERR_BOOKING_CHANGED")
+	}
 	if err != nil {
 		r.logger.Error(utils.NewSafeError(err, "Error in CandidateRepository.Delete: Delete failed"))
-		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Failed to delete candidate", "DATABASE_ERROR")
+		return dynamo.ClassifyError(err, "Failed to delete candidate")
 	}
 
 	return nil
 }
 
+// CandidateFromStreamImage decodes an image of the table stream. Images are
+// not decrypted: the pii attributes keep their ciphertext.
+func CandidateFromStreamImage(image map[string]events.DynamoDBAttributeValue) (*entities.Candidate, error) {
+	if len(image) == 0 {
+		return nil, nil
+	}
+
+	item, err := dynamo.FromStreamImage(image)
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.UNPROCESSABLE_ENTITY, "Invalid candidate stream image", "DATABASE_ERROR")
+	}
+
+	var candidate entities.Candidate
+	if err := attributevalue.UnmarshalMap(item, &candidate); err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.UNPROCESSABLE_ENTITY, "Invalid candidate stream image", "DATABASE_ERROR")
+	}
+	return &candidate, nil
+}
+
 var _ repositories.CandidateRepository = (*CandidateDynamoRepository)(nil)
@@ -0,0 +1,152 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/crypto"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/persistence/dynamo"
+)
+
+// fakeDynamo serves PutItem and single-equality Query requests from memory,
+// so the items the repository writes are the ones it queries back
+type fakeDynamo struct {
+	mu    sync.Mutex
+	items []map[string]any
+}
+
+func (f *fakeDynamo) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body struct {
+		Item                      map[string]any
+		KeyConditionExpression    string
+		FilterExpression          string
+		ExpressionAttributeValues map[string]any
+	}
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	switch target := req.Header.Get("X-Amz-Target"); target {
+	case "DynamoDB_20120810.PutItem":
+		f.items = append(f.items, body.Item)
+		_, _ = w.Write([]byte(`{}`))
+	case "DynamoDB_20120810.Query":
+		matches := make([]map[string]any, 0)
+		for _, item := range f.items {
+			if f.matches(item, body.KeyConditionExpression, body.ExpressionAttributeValues) &&
+				f.matches(item, body.FilterExpression, body.ExpressionAttributeValues) {
+				matches = append(matches, item)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Items": matches, "Count": len(matches)})
+	default:
+		http.Error(w, "unexpected target "+target, http.StatusBadRequest)
+	}
+}
+
+// matches evaluates an "attribute = :value" expression; empty matches all
+func (f *fakeDynamo) matches(item map[string]any, expression string, values map[string]any) bool {
+	if expression == "" {
+		return true
+	}
+	attribute, placeholder, _ := strings.Cut(expression, " = ")
+	return reflect.DeepEqual(item[attribute], values[placeholder])
+}
+
+func newTestCandidateRepository(t *testing.T) (*CandidateDynamoRepository, *fakeDynamo) {
+	t.Helper()
+
+	fake := &fakeDynamo{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := dynamodb.New(dynamodb.Options{
+		Region:       "us-east-1",
+		BaseEndpoint: aws.String(server.URL),
+		Credentials:  aws.AnonymousCredentials{},
+	})
+
+	masterKey := []byte(strings.Repeat("k", crypto.DataKeySize))
+	provider, err := crypto.NewStaticKeyProvider(masterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blindIndex, err := crypto.NewBlindIndex(crypto.DeriveKey(masterKey, "blind-index"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encryptor := dynamo.NewItemEncryptor(crypto.NewEnvelope(crypto.EnvelopeConfig{Provider: provider}), blindIndex)
+
+	repo := NewCandidateDynamoRepository(pkgILogger.NewZeroLogLogger(), client, "candidates", "phone-index", "documentKey-index", encryptor, nil)
+	return repo, fake
+}
+
+func TestFindByDocument(t *testing.T) {
+	ctx := context.Background()
+	repo, fake := newTestCandidateRepository(t)
+
+	candidates := []*entities.Candidate{
+		{ID: "c1", Names: "Ana", DocumentType: "DNI", DocumentNumber: "45678912", DocumentKey: entities.BuildDocumentKey("DNI", "45678912")},
+		{ID: "c2", Names: "Ana", DocumentType: "DNI", DocumentNumber: "45678912", DocumentKey: entities.BuildDocumentKey("DNI", "45678912"), Deleted: true},
+		{ID: "c3", Names: "Luis", DocumentType: "DNI", DocumentNumber: "12345678", DocumentKey: entities.BuildDocumentKey("DNI", "12345678")},
+		{ID: "c4", Names: "Eva", DocumentType: "CE", DocumentNumber: "45678912", DocumentKey: entities.BuildDocumentKey("CE", "45678912")},
+	}
+	for _, candidate := range candidates {
+		if err := repo.Create(ctx, candidate); err != nil {
+			t.Fatalf("Create(%s) error = %v", candidate.ID, err)
+		}
+	}
+
+	for _, item := range fake.items {
+		if raw, _ := json.Marshal(item); strings.Contains(string(raw), "45678912") {
+			t.Fatalf("stored item has the document in clear: %s", raw)
+		}
+	}
+
+	tests := []struct {
+		name         string
+		documentType string
+		number       string
+		want         []string
+	}{
+		{"active DNI", "DNI", "45678912", []string{"c1"}},
+		{"same number of another type", "CE", "45678912", []string{"c4"}},
+		{"unknown document", "DNI", "87654321", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := repo.FindByDocument(ctx, tt.documentType, tt.number)
+			if err != nil {
+				t.Fatalf("FindByDocument() error = %v", err)
+			}
+
+			var ids []string
+			for _, candidate := range found {
+				ids = append(ids, candidate.ID)
+				if candidate.DocumentNumber != tt.number || candidate.Names == "" || crypto.IsCiphertext(candidate.Names) {
+					t.Errorf("candidate %s was not decrypted: %+v", candidate.ID, candidate)
+				}
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Fatalf("FindByDocument() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/persistence/dynamo"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// Positions of the items in the booking transactions, used to tell which
+// condition failed
+const (
+	slotItem = iota
+	candidateItem
+)
+
+type InterviewSlotDynamoRepository struct {
+	logger     logger.Logger
+	client     *dynamodb.Client
+	table      string
+	storeIndex string
+	candidates *CandidateDynamoRepository
+	outbox     *OutboxDynamoRepository
+}
+
+// NewInterviewSlotDynamoRepository uses a table keyed by id with a GSI on
+// store (hash) and startAt (range). Bookings also write the candidate
+// through candidates, and its outbox records through outbox.
+func NewInterviewSlotDynamoRepository(logger logger.Logger, client *dynamodb.Client, table, storeIndex string, candidates *CandidateDynamoRepository, outbox *OutboxDynamoRepository) *InterviewSlotDynamoRepository {
+	return &InterviewSlotDynamoRepository{
+		logger:     logger,
+		client:     client,
+		table:      table,
+		storeIndex: storeIndex,
+		candidates: candidates,
+		outbox:     outbox,
+	}
+}
+
+func (r *InterviewSlotDynamoRepository) GetByID(ctx context.Context, id string) (*entities.InterviewSlot, error) {
+	res, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.table),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+	})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in InterviewSlotRepository.GetByID: Failed to get slot"))
+		return nil, dynamo.ClassifyError(err, "Failed to get interview slot")
+	}
+	if len(res.Item) == 0 {
+		return nil, nil
+	}
+
+	var slot entities.InterviewSlot
+	if err := attributevalue.UnmarshalMap(res.Item, &slot); err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in InterviewSlotRepository.GetByID: Failed to unmarshal slot"))
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to unmarshal interview slot", "DATABASE_ERROR")
+	}
+	return &slot, nil
+}
+
+func (r *InterviewSlotDynamoRepository) Create(ctx context.Context, slot *entities.InterviewSlot) (bool, error) {
+	item, err := attributevalue.MarshalMap(slot)
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in InterviewSlotRepository.Create: Failed to marshal slot"))
+		return false, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to marshal interview slot", "DATABASE_ERROR")
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.table),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(id)"),
+	})
+
+	var conditionalErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionalErr) {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in InterviewSlotRepository.Create: Failed to create slot"))
+		return false, dynamo.ClassifyError(err, "Failed to create interview slot")
+	}
+	return true, nil
+}
+
+func (r *InterviewSlotDynamoRepository) FindByStore(ctx context.Context, store string, from, to time.Time, limit int) ([]*entities.InterviewSlot, error) {
+	input := &dynamodb.QueryInput{
+		TableName:                aws.String(r.table),
+		IndexName:                aws.String(r.storeIndex),
+		KeyConditionExpression:   aws.String("#store = :store AND startAt BETWEEN :from AND :to"),
+		ExpressionAttributeNames: map[string]string{"#store": "store"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":store": &types.AttributeValueMemberS{Value: store},
+			":from":  &types.AttributeValueMemberS{Value: from.UTC().Format(entities.SlotTimeFormat)},
+			// BETWEEN is inclusive: stop one second before to
+			":to": &types.AttributeValueMemberS{Value: to.Add(-time.Second).UTC().Format(entities.SlotTimeFormat)},
+		},
+		ScanIndexForward: aws.Bool(true),
+	}
+
+	slots := make([]*entities.InterviewSlot, 0)
+	paginator := dynamodb.NewQueryPaginator(r.client, input)
+	for paginator.HasMorePages() && len(slots) < limit {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.logger.Error(utils.NewSafeError(err, "Error in InterviewSlotRepository.FindByStore: Query failed"))
+			return nil, dynamo.ClassifyError(err, "Failed to find interview slots")
+		}
+
+		for _, item := range page.Items {
+			var slot entities.InterviewSlot
+			if err := attributevalue.UnmarshalMap(item, &slot); err != nil {
+				r.logger.Error(utils.NewSafeError(err, "Error in InterviewSlotRepository.FindByStore: Failed to unmarshal slot"))
+				return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to unmarshal interview slot", "DATABASE_ERROR")
+			}
+			slots = append(slots, &slot)
+		}
+	}
+
+	if len(slots) > limit {
+		slots = slots[:limit]
+	}
+	return slots, nil
+}
+
+func (r *InterviewSlotDynamoRepository) Book(ctx context.Context, slotID, candidateID string, candidateUpdates map[string]interface{}, outbox ...*entities.OutboxRecord) error {
+	updates := make(map[string]interface{}, len(candidateUpdates)+1)
+	for k, v := range candidateUpdates {
+		updates[k] = v
+	}
+	updates["interviewSlotId"] = slotID
+
+	seat := types.TransactWriteItem{Update: &types.Update{
+		TableName:           aws.String(r.table),
+		Key:                 map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: slotID}},
+		UpdateExpression:    aws.String("SET booked = booked + :one"),
+		ConditionExpression: aws.String("attribute_exists(id) AND booked < capacity"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one": &types.AttributeValueMemberN{Value: "1"},
+		},
+	}}
+
+	candidate, err := r.candidates.transactUpdate(ctx, candidateID, updates, nil,
+		"attribute_exists(id) AND deleted = :notDeleted AND attribute_not_exists(interviewSlotId)",
+		map[string]types.AttributeValue{
+			":notDeleted": &types.AttributeValueMemberBOOL{Value: false},
+		})
+	if err != nil {
+		return err
+	}
+
+	return r.transact(ctx, "Book", "Failed to book interview slot", seat, candidate, outbox, []*errorCustom.CustomError{
+		slotItem:      errorCustom.NewError(errorCustom.CONFLICT, "Interview slot is full", "ERR_SLOT_FULL"),
+		candidateItem: errorCustom.NewError(errorCustom.CONFLICT, "Candidate already has an interview booked", "ERR_CANDIDATE_ALREADY_BOOKED"),
+	})
+}
+
+func (r *InterviewSlotDynamoRepository) Cancel(ctx context.Context, slotID, candidateID string, candidateUpdates map[string]interface{}, outbox ...*entities.OutboxRecord) error {
+	seat := types.TransactWriteItem{Update: &types.Update{
+		TableName:           aws.String(r.table),
+		Key:                 map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: slotID}},
+		UpdateExpression:    aws.String("SET booked = booked - :one"),
+		ConditionExpression: aws.String("booked >= :one"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one": &types.AttributeValueMemberN{Value: "1"},
+		},
+	}}
+
+	candidate, err := r.candidates.transactUpdate(ctx, candidateID, candidateUpdates, []string{"interviewSlotId", "interviewMeetingId"},
+		"interviewSlotId = :slotId",
+		map[string]types.AttributeValue{
+			":slotId": &types.AttributeValueMemberS{Value: slotID},
+		})
+	if err != nil {
+		return err
+	}
+
+	return r.transact(ctx, "Cancel", "Failed to cancel interview booking", seat, candidate, outbox, []*errorCustom.CustomError{
+		candidateItem: errorCustom.NewError(errorCustom.CONFLICT, "Interview booking changed, try again", "ERR_BOOKING_CHANGED"),
+	})
+}
+
+func (r *InterviewSlotDynamoRepository) DeleteBooked(ctx context.Context, slotID, candidateID string, outbox ...*entities.OutboxRecord) error {
+	seat := types.TransactWriteItem{Update: &types.Update{
+		TableName:           aws.String(r.table),
+		Key:                 map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: slotID}},
+		UpdateExpression:    aws.String("SET booked = booked - :one"),
+		ConditionExpression: aws.String("booked >= :one"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one": &types.AttributeValueMemberN{Value: "1"},
+		},
+	}}
+
+	candidate := r.candidates.transactDelete(candidateID, "interviewSlotId = :slotId", map[string]types.AttributeValue{
+		":slotId": &types.AttributeValueMemberS{Value: slotID},
+	})
+
+	return r.transact(ctx, "DeleteBooked", "Failed to delete booked candidate", seat, candidate, outbox, []*errorCustom.CustomError{
+		candidateItem: errorCustom.NewError(errorCustom.CONFLICT, "Interview booking changed, try again", "ERR_BOOKING_CHANGED"),
+	})
+}
+
+// transact writes the seat change, the candidate change and the outbox
+// records atomically, in this order. A failed condition returns the error at
+// the position of its item in conflicts, if any.
+func (r *InterviewSlotDynamoRepository) transact(ctx context.Context, operation, message string, seat, candidate types.TransactWriteItem, outbox []*entities.OutboxRecord, conflicts []*errorCustom.CustomError) error {
+	items := make([]types.TransactWriteItem, 0, len(outbox)+2)
+	items = append(items, seat, candidate)
+	for _, record := range outbox {
+		put, err := r.outbox.transactPut(ctx, record)
+		if err != nil {
+			return err
+		}
+		items = append(items, put)
+	}
+
+	_, err := r.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+	if err == nil {
+		return nil
+	}
+	for index, conflict := range conflicts {
+		if conflict != nil && dynamo.ConditionFailed(err, index) {
+			return conflict.WithCause(err)
+		}
+	}
+
+	r.logger.Error(utils.NewSafeError(err, "Error in InterviewSlotRepository."+operation+": Transaction failed"))
+	return dynamo.ClassifyError(err, message)
+}
+
+var _ repositories.InterviewSlotRepository = (*InterviewSlotDynamoRepository)(nil)
@@ -0,0 +1,242 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/persistence/dynamo"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+type MessageDynamoRepository struct {
+	logger         logger.Logger
+	client         *dynamodb.Client
+	table          string
+	candidateIndex string
+}
+
+// NewMessageDynamoRepository uses a table keyed by id (the provider message
+// ID) with a GSI on candidateId (hash) and sentAt (range)
+func NewMessageDynamoRepository(logger logger.Logger, client *dynamodb.Client, table, candidateIndex string) *MessageDynamoRepository {
+	return &MessageDynamoRepository{
+		logger:         logger,
+		client:         client,
+		table:          table,
+		candidateIndex: candidateIndex,
+	}
+}
+
+func (r *MessageDynamoRepository) Create(ctx context.Context, message *entities.Message) error {
+	item, err := attributevalue.MarshalMap(message)
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.Create: Failed to marshal message"))
+		return errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to marshal message", "DATABASE_ERROR")
+	}
+
+	_, err = r.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(r.table),
+		Item:                item,
+		ConditionExpression: aws.String("attribute_not_exists(id)"),
+	})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.Create: Failed to create message"))
+		return dynamo.ClassifyError(err, "Failed to create message")
+	}
+	return nil
+}
+
+func (r *MessageDynamoRepository) GetByID(ctx context.Context, id string) (*entities.Message, error) {
+	res, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.table),
+		Key:       r.key(id),
+	})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.GetByID: Failed to get message"))
+		return nil, dynamo.ClassifyError(err, "Failed to get message")
+	}
+	if len(res.Item) == 0 {
+		return nil, nil
+	}
+	return r.unmarshal(res.Item)
+}
+
+func (r *MessageDynamoRepository) FindByCandidate(ctx context.Context, candidateID string, limit int) ([]*entities.Message, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(r.table),
+		IndexName:              aws.String(r.candidateIndex),
+		KeyConditionExpression: aws.String("candidateId = :candidateId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":candidateId": &types.AttributeValueMemberS{Value: candidateID},
+		},
+		ScanIndexForward: aws.Bool(false),
+	}
+
+	messages := make([]*entities.Message, 0)
+	paginator := dynamodb.NewQueryPaginator(r.client, input)
+	for paginator.HasMorePages() && len(messages) < limit {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.FindByCandidate: Query failed"))
+			return nil, dynamo.ClassifyError(err, "Failed to find messages")
+		}
+
+		for _, item := range page.Items {
+			message, err := r.unmarshal(item)
+			if err != nil {
+				return nil, err
+			}
+			messages = append(messages, message)
+		}
+	}
+
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+func (r *MessageDynamoRepository) RecordStatus(ctx context.Context, id string, entry entities.MessageStatusEntry) (*entities.Message, error) {
+	value, err := attributevalue.Marshal(entry)
+	if err != nil {
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to marshal message status", "DATABASE_ERROR")
+	}
+
+	// The history entry is written once per status: a redelivered callback
+	// keeps the first time reported
+	res, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                aws.String(r.table),
+		Key:                      r.key(id),
+		UpdateExpression:         aws.String("SET statuses.#entry = if_not_exists(statuses.#entry, :entry)"),
+		ConditionExpression:      aws.String("attribute_exists(id)"),
+		ExpressionAttributeNames: map[string]string{"#entry": string(entry.Status)},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":entry": value,
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+
+	var conditionalErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionalErr) {
+		return nil, nil
+	}
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.RecordStatus: Failed to record status"))
+		return nil, dynamo.ClassifyError(err, "Failed to record message status")
+	}
+
+	message, err := r.unmarshal(res.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Status.Rank() <= message.StatusRank {
+		return message, nil
+	}
+
+	_, err = r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                aws.String(r.table),
+		Key:                      r.key(id),
+		UpdateExpression:         aws.String("SET #status = :status, statusAt = :at, statusRank = :rank"),
+		ConditionExpression:      aws.String("statusRank < :rank"),
+		ExpressionAttributeNames: map[string]string{"#status": "status"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":status": &types.AttributeValueMemberS{Value: string(entry.Status)},
+			":at":     &types.AttributeValueMemberS{Value: entry.At},
+			":rank":   &types.AttributeValueMemberN{Value: strconv.Itoa(entry.Status.Rank())},
+		},
+	})
+	if errors.As(err, &conditionalErr) {
+		// A higher status was recorded concurrently
+		return message, nil
+	}
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.RecordStatus: Failed to update status"))
+		return nil, dynamo.ClassifyError(err, "Failed to record message status")
+	}
+
+	message.Status = entry.Status
+	message.StatusAt = entry.At
+	message.StatusRank = entry.Status.Rank()
+	return message, nil
+}
+
+func (r *MessageDynamoRepository) ClaimFallback(ctx context.Context, id string) (bool, error) {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(r.table),
+		Key:                 r.key(id),
+		UpdateExpression:    aws.String("SET fallbackAt = :now"),
+		ConditionExpression: aws.String("attribute_exists(id) AND attribute_not_exists(fallbackAt)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": &types.AttributeValueMemberS{Value: time.Now().UTC().Format(entities.MessageTimeFormat)},
+		},
+	})
+
+	var conditionalErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionalErr) {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.ClaimFallback: Failed to claim fallback"))
+		return false, dynamo.ClassifyError(err, "Failed to claim message fallback")
+	}
+	return true, nil
+}
+
+func (r *MessageDynamoRepository) CompleteFallback(ctx context.Context, id, fallbackMessageID string) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:        aws.String(r.table),
+		Key:              r.key(id),
+		UpdateExpression: aws.String("SET fallbackMessageId = :fallbackMessageId"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":fallbackMessageId": &types.AttributeValueMemberS{Value: fallbackMessageID},
+		},
+	})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.CompleteFallback: Failed to record fallback"))
+		return dynamo.ClassifyError(err, "Failed to record message fallback")
+	}
+	return nil
+}
+
+func (r *MessageDynamoRepository) ReleaseFallback(ctx context.Context, id string) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:           aws.String(r.table),
+		Key:                 r.key(id),
+		UpdateExpression:    aws.String("REMOVE fallbackAt"),
+		ConditionExpression: aws.String("attribute_not_exists(fallbackMessageId)"),
+	})
+
+	var conditionalErr *types.ConditionalCheckFailedException
+	if err != nil && !errors.As(err, &conditionalErr) {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository.ReleaseFallback: Failed to release fallback"))
+		return dynamo.ClassifyError(err, "Failed to release message fallback")
+	}
+	return nil
+}
+
+func (r *MessageDynamoRepository) key(id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"id": &types.AttributeValueMemberS{Value: id},
+	}
+}
+
+func (r *MessageDynamoRepository) unmarshal(item map[string]types.AttributeValue) (*entities.Message, error) {
+	var message entities.Message
+	if err := attributevalue.UnmarshalMap(item, &message); err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in MessageRepository: Failed to unmarshal message"))
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to unmarshal message", "DATABASE_ERROR")
+	}
+	return &message, nil
+}
+
+var _ repositories.MessageRepository = (*MessageDynamoRepository)(nil)
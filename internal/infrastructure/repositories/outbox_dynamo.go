@@ -0,0 +1,257 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/internal/domain/repositories"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/crypto"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/persistence/dynamo"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// outboxFields are the pii attributes of an outbox record, see entities.OutboxRecord
+var outboxFields = crypto.FieldsOf(entities.OutboxRecord{})
+
+// deliveredOutboxTTL is how long delivered records are kept for inspection
+const deliveredOutboxTTL = 7 * 24 * time.Hour
+
+type OutboxDynamoRepository struct {
+	logger      logger.Logger
+	client      *dynamodb.Client
+	table       string
+	statusIndex string
+	encryptor   *dynamo.ItemEncryptor
+}
+
+// NewOutboxDynamoRepository uses a table keyed by id with a GSI on
+// status (hash) and nextAttemptAt (range)
+func NewOutboxDynamoRepository(logger logger.Logger, client *dynamodb.Client, table, statusIndex string, encryptor *dynamo.ItemEncryptor) *OutboxDynamoRepository {
+	return &OutboxDynamoRepository{
+		logger:      logger,
+		client:      client,
+		table:       table,
+		statusIndex: statusIndex,
+		encryptor:   encryptor,
+	}
+}
+
+// transactPut returns the Put of a new record for a TransactWriteItems call
+func (r *OutboxDynamoRepository) transactPut(ctx context.Context, record *entities.OutboxRecord) (types.TransactWriteItem, error) {
+	item, err := attributevalue.MarshalMap(record)
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository: Failed to marshal record"))
+		return types.TransactWriteItem{}, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to marshal outbox record", "DATABASE_ERROR")
+	}
+
+	if err := r.encryptor.EncryptItem(ctx, item, outboxFields, record.ID); err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository: Failed to encrypt record"))
+		return types.TransactWriteItem{}, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to encrypt outbox record", "ENCRYPTION_ERROR")
+	}
+
+	return types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:           aws.String(r.table),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(id)"),
+		},
+	}, nil
+}
+
+func (r *OutboxDynamoRepository) GetByID(ctx context.Context, id string) (*entities.OutboxRecord, error) {
+	res, err := r.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(r.table),
+		Key: map[string]types.AttributeValue{
+			"id": &types.AttributeValueMemberS{Value: id},
+		},
+	})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository.GetByID: Failed to get record"))
+		return nil, dynamo.ClassifyError(err, "Failed to get outbox record")
+	}
+	if len(res.Item) == 0 {
+		return nil, nil
+	}
+
+	return r.unmarshal(ctx, res.Item)
+}
+
+func (r *OutboxDynamoRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*entities.OutboxRecord, error) {
+	return r.query(ctx, limit, "#status = :status AND nextAttemptAt <= :now", map[string]types.AttributeValue{
+		":status": &types.AttributeValueMemberS{Value: string(entities.OutboxPending)},
+		":now":    &types.AttributeValueMemberS{Value: now.UTC().Format(entities.OutboxTimeFormat)},
+	})
+}
+
+func (r *OutboxDynamoRepository) FindFailed(ctx context.Context, limit int) ([]*entities.OutboxRecord, error) {
+	return r.query(ctx, limit, "#status = :status", map[string]types.AttributeValue{
+		":status": &types.AttributeValueMemberS{Value: string(entities.OutboxFailed)},
+	})
+}
+
+func (r *OutboxDynamoRepository) query(ctx context.Context, limit int, keyCondition string, values map[string]types.AttributeValue) ([]*entities.OutboxRecord, error) {
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(r.table),
+		IndexName:                 aws.String(r.statusIndex),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeNames:  map[string]string{"#status": "status"},
+		ExpressionAttributeValues: values,
+		ScanIndexForward:          aws.Bool(true),
+		Limit:                     aws.Int32(int32(limit)),
+	}
+
+	records := make([]*entities.OutboxRecord, 0)
+	paginator := dynamodb.NewQueryPaginator(r.client, input)
+	for paginator.HasMorePages() && len(records) < limit {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository.query: Query failed"))
+			return nil, dynamo.ClassifyError(err, "Failed to query outbox records")
+		}
+
+		for _, item := range page.Items {
+			record, err := r.unmarshal(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+	}
+
+	if len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+func (r *OutboxDynamoRepository) unmarshal(ctx context.Context, item map[string]types.AttributeValue) (*entities.OutboxRecord, error) {
+	id, _ := item["id"].(*types.AttributeValueMemberS)
+	if id == nil {
+		return nil, errorCustom.NewError(errorCustom.INTERNAL, "Outbox record without ID", "DATABASE_ERROR")
+	}
+
+	if err := r.encryptor.DecryptItem(ctx, item, outboxFields, id.Value); err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository: Failed to decrypt record"))
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to decrypt outbox record", "ENCRYPTION_ERROR")
+	}
+
+	var record entities.OutboxRecord
+	if err := attributevalue.UnmarshalMap(item, &record); err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository: Failed to unmarshal record"))
+		return nil, errorCustom.Wrap(err, errorCustom.INTERNAL, "Failed to unmarshal outbox record", "DATABASE_ERROR")
+	}
+	return &record, nil
+}
+
+func (r *OutboxDynamoRepository) Claim(ctx context.Context, record *entities.OutboxRecord, leaseUntil time.Time) (bool, error) {
+	err := r.update(ctx, record.ID,
+		"SET attempts = :next, nextAttemptAt = :lease",
+		"#status = :pending AND attempts = :attempts",
+		map[string]types.AttributeValue{
+			":next":     &types.AttributeValueMemberN{Value: strconv.Itoa(record.Attempts + 1)},
+			":lease":    &types.AttributeValueMemberS{Value: leaseUntil.UTC().Format(entities.OutboxTimeFormat)},
+			":pending":  &types.AttributeValueMemberS{Value: string(entities.OutboxPending)},
+			":attempts": &types.AttributeValueMemberN{Value: strconv.Itoa(record.Attempts)},
+		})
+
+	var conditionalErr *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionalErr) {
+		return false, nil
+	}
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository.Claim: Update failed"))
+		return false, dynamo.ClassifyError(err, "Failed to claim outbox record")
+	}
+
+	record.Attempts++
+	return true, nil
+}
+
+func (r *OutboxDynamoRepository) MarkDelivered(ctx context.Context, id string, at time.Time) error {
+	err := r.update(ctx, id,
+		"SET #status = :delivered, deliveredAt = :at, expiresAt = :expiresAt",
+		"attribute_exists(id)",
+		map[string]types.AttributeValue{
+			":delivered": &types.AttributeValueMemberS{Value: string(entities.OutboxDelivered)},
+			":at":        &types.AttributeValueMemberS{Value: at.UTC().Format(entities.OutboxTimeFormat)},
+			":expiresAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(at.Add(deliveredOutboxTTL).Unix(), 10)},
+		})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository.MarkDelivered: Update failed"))
+		return dynamo.ClassifyError(err, "Failed to update outbox record")
+	}
+	return nil
+}
+
+func (r *OutboxDynamoRepository) MarkRetry(ctx context.Context, id string, nextAttemptAt time.Time, lastError string) error {
+	err := r.update(ctx, id,
+		"SET #status = :pending, nextAttemptAt = :next, lastError = :lastError",
+		"attribute_exists(id)",
+		map[string]types.AttributeValue{
+			":pending":   &types.AttributeValueMemberS{Value: string(entities.OutboxPending)},
+			":next":      &types.AttributeValueMemberS{Value: nextAttemptAt.UTC().Format(entities.OutboxTimeFormat)},
+			":lastError": &types.AttributeValueMemberS{Value: lastError},
+		})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository.MarkRetry: Update failed"))
+		return dynamo.ClassifyError(err, "Failed to update outbox record")
+	}
+	return nil
+}
+
+func (r *OutboxDynamoRepository) MarkFailed(ctx context.Context, id string, lastError string) error {
+	err := r.update(ctx, id,
+		"SET #status = :failed, lastError = :lastError",
+		"attribute_exists(id)",
+		map[string]types.AttributeValue{
+			":failed":    &types.AttributeValueMemberS{Value: string(entities.OutboxFailed)},
+			":lastError": &types.AttributeValueMemberS{Value: lastError},
+		})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository.MarkFailed: Update failed"))
+		return dynamo.ClassifyError(err, "Failed to update outbox record")
+	}
+	return nil
+}
+
+func (r *OutboxDynamoRepository) Requeue(ctx context.Context, id string, now time.Time) error {
+	err := r.update(ctx, id,
+		"SET #status = :pending, attempts = :zero, nextAttemptAt = :now",
+		"#status = :failed",
+		map[string]types.AttributeValue{
+			":pending": &types.AttributeValueMemberS{Value: string(entities.OutboxPending)},
+			":zero":    &types.AttributeValueMemberN{Value: "0"},
+			":now":     &types.AttributeValueMemberS{Value: now.UTC().Format(entities.OutboxTimeFormat)},
+			":failed":  &types.AttributeValueMemberS{Value: string(entities.OutboxFailed)},
+		})
+	if err != nil {
+		r.logger.Error(utils.NewSafeError(err, "Error in OutboxRepository.Requeue: Update failed"))
+		return dynamo.ClassifyError(err, "Outbox record is not failed")
+	}
+	return nil
+}
+
+// update expressions always refer to status through #status, a reserved word
+func (r *OutboxDynamoRepository) update(ctx context.Context, id, expression, condition string, values map[string]types.AttributeValue) error {
+	_, err := r.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(r.table),
+		Key:                       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: id}},
+		UpdateExpression:          aws.String(expression),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  map[string]string{"#status": "status"},
+		ExpressionAttributeValues: values,
+	})
+	return err
+}
+
+var _ repositories.OutboxRepository = (*OutboxDynamoRepository)(nil)
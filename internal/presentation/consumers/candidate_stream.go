@@ -0,0 +1,89 @@
+package consumers
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// CandidateImageDecoder decodes a stream image; the repository owns the
+// item layout
+type CandidateImageDecoder func(image map[string]events.DynamoDBAttributeValue) (*entities.Candidate, error)
+
+// CandidateStreamConsumer publishes the domain events of the candidates
+// table stream
+type CandidateStreamConsumer struct {
+	logger                        logger.Logger
+	decodeImage                   CandidateImageDecoder
+	publishCandidateChangeService *commands.PublishCandidateChangeService
+}
+
+type CandidateStreamConsumerConfig struct {
+	Logger                        logger.Logger
+	DecodeImage                   CandidateImageDecoder
+	PublishCandidateChangeService *commands.PublishCandidateChangeService
+}
+
+func NewCandidateStreamConsumer(cfg CandidateStreamConsumerConfig) *CandidateStreamConsumer {
+	return &CandidateStreamConsumer{
+		logger:                        cfg.Logger,
+		decodeImage:                   cfg.DecodeImage,
+		publishCandidateChangeService: cfg.PublishCandidateChangeService,
+	}
+}
+
+// Handle processes the records in order and stops at the first failure,
+// reporting it as the batch item failure: Lambda checkpoints the records
+// before it and retries from it, which keeps the events of a candidate in
+// order. Requires ReportBatchItemFailures on the event source mapping.
+func (c *CandidateStreamConsumer) Handle(ctx context.Context, event events.DynamoDBEvent) (events.DynamoDBEventResponse, error) {
+	var response events.DynamoDBEventResponse
+
+	for _, record := range event.Records {
+		if err := c.handleRecord(ctx, record); err != nil {
+			c.logger.Error(utils.NewSafeError(err, "Error in CandidateStreamConsumer.Handle: Failed to publish record "+record.EventID))
+			response.BatchItemFailures = []events.DynamoDBBatchItemFailure{
+				{ItemIdentifier: record.Change.SequenceNumber},
+			}
+			return response, nil
+		}
+	}
+
+	return response, nil
+}
+
+func (c *CandidateStreamConsumer) handleRecord(ctx context.Context, record events.DynamoDBEventRecord) error {
+	before, err := c.decodeImage(record.Change.OldImage)
+	if err != nil {
+		return err
+	}
+	after, err := c.decodeImage(record.Change.NewImage)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.publishCandidateChangeService.Execute(ctx, &commands.PublishCandidateChangeServiceInput{
+		ChangeID:   record.EventID,
+		Change:     commands.CandidateChange(record.EventName),
+		Before:     before,
+		After:      after,
+		OccurredAt: record.Change.ApproximateCreationDateTime.Time,
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.Event != "" {
+		c.logger.Info(map[string]any{
+			"msg":      "Candidate event published",
+			"event":    result.Event,
+			"recordId": record.EventID,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package consumers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	domainEvents "github.com/Yolto7/api-candidates/internal/domain/events"
+	"github.com/Yolto7/api-candidates/internal/infrastructure/adapters"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+)
+
+// failingPublisher is the memory publisher made to fail on one event ID
+type failingPublisher struct {
+	*adapters.MemoryEventPublisher
+	failID string
+}
+
+func (p *failingPublisher) Publish(ctx context.Context, evts ...domainEvents.Event) error {
+	for _, event := range evts {
+		if event.Meta().ID == p.failID {
+			return errors.New("event bus unavailable")
+		}
+	}
+	return p.MemoryEventPublisher.Publish(ctx, evts...)
+}
+
+// decodeTestImage reads the id and status of an image, nil when empty
+func decodeTestImage(image map[string]events.DynamoDBAttributeValue) (*entities.Candidate, error) {
+	if len(image) == 0 {
+		return nil, nil
+	}
+	return &entities.Candidate{
+		ID:     image["id"].String(),
+		Status: entities.CandidateStatus(image["status"].String()),
+	}, nil
+}
+
+func insertRecord(eventID, sequenceNumber, candidateID string) events.DynamoDBEventRecord {
+	return events.DynamoDBEventRecord{
+		EventID:   eventID,
+		EventName: string(commands.CandidateInserted),
+		Change: events.DynamoDBStreamRecord{
+			SequenceNumber: sequenceNumber,
+			NewImage: map[string]events.DynamoDBAttributeValue{
+				"id":     events.NewStringAttribute(candidateID),
+				"status": events.NewStringAttribute(string(entities.StatusPending)),
+			},
+		},
+	}
+}
+
+func TestCandidateStreamHandle(t *testing.T) {
+	publisher := &failingPublisher{MemoryEventPublisher: adapters.NewMemoryEventPublisher(), failID: "e2"}
+	consumer := NewCandidateStreamConsumer(CandidateStreamConsumerConfig{
+		Logger:      pkgILogger.NewZeroLogLogger(),
+		DecodeImage: decodeTestImage,
+		PublishCandidateChangeService: commands.NewPublishCandidateChangeService(commands.PublishCandidateChangeServiceConfig{
+			Logger:    pkgILogger.NewZeroLogLogger(),
+			Publisher: publisher,
+		}),
+	})
+
+	response, err := consumer.Handle(context.Background(), events.DynamoDBEvent{Records: []events.DynamoDBEventRecord{
+		insertRecord("e1", "100", "c1"),
+		insertRecord("e2", "200", "c2"),
+		insertRecord("e3", "300", "c3"),
+	}})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+
+	want := []events.DynamoDBBatchItemFailure{{ItemIdentifier: "200"}}
+	if !reflect.DeepEqual(response.BatchItemFailures, want) {
+		t.Fatalf("BatchItemFailures = %+v, want %+v", response.BatchItemFailures, want)
+	}
+
+	var published []string
+	for _, event := range publisher.Events() {
+		published = append(published, event.Meta().ID)
+	}
+	if !reflect.DeepEqual(published, []string{"e1"}) {
+		t.Fatalf("published = %v, want only the records before the failure", published)
+	}
+}
+
+func TestCandidateStreamHandleWithoutFailures(t *testing.T) {
+	publisher := adapters.NewMemoryEventPublisher()
+	consumer := NewCandidateStreamConsumer(CandidateStreamConsumerConfig{
+		Logger:      pkgILogger.NewZeroLogLogger(),
+		DecodeImage: decodeTestImage,
+		PublishCandidateChangeService: commands.NewPublishCandidateChangeService(commands.PublishCandidateChangeServiceConfig{
+			Logger:    pkgILogger.NewZeroLogLogger(),
+			Publisher: publisher,
+		}),
+	})
+
+	response, err := consumer.Handle(context.Background(), events.DynamoDBEvent{Records: []events.DynamoDBEventRecord{
+		insertRecord("e1", "100", "c1"),
+		insertRecord("e2", "200", "c2"),
+	}})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if len(response.BatchItemFailures) != 0 {
+		t.Fatalf("BatchItemFailures = %+v, want none", response.BatchItemFailures)
+	}
+	if got := len(publisher.Events()); got != 2 {
+		t.Fatalf("published %d events, want 2", got)
+	}
+}
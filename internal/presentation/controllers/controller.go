@@ -2,31 +2,39 @@ package controllers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
+	"strings"
 
 	"github.com/Yolto7/api-candidates/internal/application/services/commands"
 	"github.com/Yolto7/api-candidates/internal/application/services/queries"
 	"github.com/Yolto7/api-candidates/internal/presentation/validators"
 	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
 	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/request"
 	"github.com/Yolto7/api-candidates/pkg/infrastructure/response"
-	"github.com/aws/aws-lambda-go/events"
 )
 
 
 type CandidateController struct {
 	logger 					logger.Logger
 	getByIDService 	*queries.GetByIDService
+	findByPhoneService *queries.FindByPhoneService
+	findByDocumentService *queries.FindByDocumentService
 	createService 	*commands.CreateService
+	updateService 	*commands.UpdateService
 	deleteService 	*commands.DeleteService
 }
 
 type CandidateControllerConfig struct {
 	Logger 		logger.Logger
 	GetByIDService 	*queries.GetByIDService
+	FindByPhoneService *queries.FindByPhoneService
+	FindByDocumentService *queries.FindByDocumentService
 	CreateService		*commands.CreateService
+	UpdateService		*commands.UpdateService
 	DeleteService		*commands.DeleteService
 }
 
@@ -34,13 +42,16 @@ func NewCandidateController(cfg CandidateControllerConfig) *CandidateController
   return &CandidateController{
 		logger: 					cfg.Logger,
 		getByIDService: 	cfg.GetByIDService,
+		findByPhoneService: cfg.FindByPhoneService,
+		findByDocumentService: cfg.FindByDocumentService,
 		createService: 		cfg.CreateService,
+		updateService: 		cfg.UpdateService,
 		deleteService: 		cfg.DeleteService,
   }
 }
 
 // Queries
-func (ctr *CandidateController) GetByID(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+func (ctr *CandidateController) GetByID(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
 	id, ok := event.PathParameters["id"]
 	if !ok || id == "" {
 		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid ID", "ERR_INVALID_ID")
@@ -53,37 +64,115 @@ func (ctr *CandidateController) GetByID(ctx context.Context, event events.APIGat
 		return nil, err
 	}
 
-	ctr.logger.Info(fmt.Sprintf("GetByID request: %+v", req))
+	ctr.logger.Info(map[string]any{"msg": "GetByID request", "candidateId": req.ID})
 	result, err := ctr.getByIDService.Execute(ctx, req)
 	if err != nil {
 		return nil, errorCustom.FromError(err) 
 	}
 
-	ctr.logger.Info(fmt.Sprintf("GetByID result: %+v", result))
+	ctr.logger.Info(map[string]any{"msg": "GetByID result", "candidateId": result.ID, "status": result.Status})
 	return response.Success(http.StatusOK, "Got candidate successfully", result)
 }
 
+func (ctr *CandidateController) FindByPhone(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	req := queries.FindByPhoneServiceInput{
+		Phone: event.QueryStringParameters["phone"],
+	}
+	if err := validators.FindByPhone(&req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info("FindByPhone request")
+	result, err := ctr.findByPhoneService.Execute(ctx, req)
+	if err != nil {
+		return nil, errorCustom.FromError(err) 
+	}
+
+	ctr.logger.Info(fmt.Sprintf("FindByPhone result: %d candidates", len(result.Candidates)))
+	return response.Success(http.StatusOK, "Found candidates successfully", result)
+}
+
+func (ctr *CandidateController) FindByDocument(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	req := queries.FindByDocumentServiceInput{
+		DocumentType:   event.QueryStringParameters["documentType"],
+		DocumentNumber: event.QueryStringParameters["documentNumber"],
+	}
+	if err := validators.FindByDocument(&req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(map[string]any{"msg": "FindByDocument request", "documentType": req.DocumentType})
+	result, err := ctr.findByDocumentService.Execute(ctx, req)
+	if err != nil {
+		return nil, errorCustom.FromError(err) 
+	}
+
+	ctr.logger.Info(fmt.Sprintf("FindByDocument result: %d candidates", len(result.Candidates)))
+	return response.Success(http.StatusOK, "Found candidates successfully", result)
+}
+
 // Commands
-func (ctr *CandidateController) Create(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+func (ctr *CandidateController) Create(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
 	var req commands.CreateServiceInput
-	if err := json.Unmarshal([]byte(event.Body), &req); err != nil {
-		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid JSON format", "ERR_INVALID_JSON")
+	if err := request.BindJSON(event, &req); err != nil {
+		return nil, err
 	}
 	if err := validators.Create(&req); err != nil {
 		return nil, err
 	}
 
-	ctr.logger.Info(fmt.Sprintf("Create request: %+v", req))
+	ctr.logger.Info(map[string]any{"msg": "Create request", "candidateId": req.ID, "sheetId": req.SheetID, "rowId": req.RowID})
 	result, err := ctr.createService.Execute(ctx, &req)
 	if err != nil {
 		return nil, errorCustom.FromError(err) 
 	}
 
-	ctr.logger.Info(fmt.Sprintf("Create result: %+v", result))
+	ctr.logger.Info(map[string]any{"msg": "Create result", "candidateId": req.ID})
 	return response.Success(http.StatusOK, "Create candidate successfully", result)
 }
 
-func (ctr *CandidateController) Delete(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+func (ctr *CandidateController) Update(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	id, ok := event.PathParameters["id"]
+	if !ok || id == "" {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid ID", "ERR_INVALID_ID")
+	}
+
+	var req commands.UpdateServiceInput
+	if err := request.BindJSON(event, &req); err != nil {
+		return nil, err
+	}
+	req.ID = id
+	if err := validators.Update(&req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(map[string]any{"msg": "Update request", "candidateId": req.ID, "fields": updatedFields(req)})
+	result, err := ctr.updateService.Execute(ctx, &req)
+	if err != nil {
+		return nil, errorCustom.FromError(err) 
+	}
+
+	ctr.logger.Info(map[string]any{"msg": "Update result", "candidateId": req.ID})
+	return response.Success(http.StatusOK, "Update candidate successfully", result)
+}
+
+// updatedFields returns the JSON names of the fields sent in an update, so
+// the profile values, which are PII, are never logged
+func updatedFields(req commands.UpdateServiceInput) []string {
+	value := reflect.ValueOf(req)
+	fields := make([]string, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Kind() != reflect.Ptr || field.IsNil() {
+			continue
+		}
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+func (ctr *CandidateController) Delete(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
 	id, ok := event.PathParameters["id"]
 	if !ok || id == "" {
 		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid ID", "ERR_INVALID_ID")
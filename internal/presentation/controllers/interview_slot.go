@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/application/services/queries"
+	"github.com/Yolto7/api-candidates/internal/presentation/validators"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/request"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/response"
+)
+
+// InterviewSlotController exposes the interview slots of the stores and the
+// bookings of the candidates
+type InterviewSlotController struct {
+	logger               logger.Logger
+	listSlotsService     *queries.ListSlotsService
+	publishSlotsService  *commands.PublishSlotsService
+	bookSlotService      *commands.BookSlotService
+	cancelBookingService *commands.CancelBookingService
+}
+
+type InterviewSlotControllerConfig struct {
+	Logger               logger.Logger
+	ListSlotsService     *queries.ListSlotsService
+	PublishSlotsService  *commands.PublishSlotsService
+	BookSlotService      *commands.BookSlotService
+	CancelBookingService *commands.CancelBookingService
+}
+
+func NewInterviewSlotController(cfg InterviewSlotControllerConfig) *InterviewSlotController {
+	return &InterviewSlotController{
+		logger:               cfg.Logger,
+		listSlotsService:     cfg.ListSlotsService,
+		publishSlotsService:  cfg.PublishSlotsService,
+		bookSlotService:      cfg.BookSlotService,
+		cancelBookingService: cfg.CancelBookingService,
+	}
+}
+
+// Queries
+func (ctr *InterviewSlotController) List(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	req := queries.ListSlotsServiceInput{
+		Store: event.QueryStringParameters["store"],
+		From:  event.QueryStringParameters["from"],
+		To:    event.QueryStringParameters["to"],
+	}
+	if err := validators.ListSlots(req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(fmt.Sprintf("ListSlots request: %+v", req))
+	result, err := ctr.listSlotsService.Execute(ctx, req)
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(fmt.Sprintf("ListSlots result: %d slots", len(result.Slots)))
+	return response.Success(http.StatusOK, "Listed interview slots successfully", result)
+}
+
+// Commands
+func (ctr *InterviewSlotController) Publish(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	var req commands.PublishSlotsServiceInput
+	if err := request.BindJSON(event, &req); err != nil {
+		return nil, err
+	}
+	if err := validators.PublishSlots(&req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(fmt.Sprintf("PublishSlots request: store %s, %d slots", req.Store, len(req.Slots)))
+	result, err := ctr.publishSlotsService.Execute(ctx, &req)
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(fmt.Sprintf("PublishSlots result: %d published, %d existing", len(result.Published), len(result.Existing)))
+	return response.Success(http.StatusOK, "Published interview slots successfully", result)
+}
+
+func (ctr *InterviewSlotController) Book(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	id, ok := event.PathParameters["id"]
+	if !ok || id == "" {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid ID", "ERR_INVALID_ID")
+	}
+
+	var req commands.BookSlotServiceInput
+	if err := request.BindJSON(event, &req); err != nil {
+		return nil, err
+	}
+	req.CandidateID = id
+	if err := validators.BookSlot(&req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(fmt.Sprintf("BookSlot request: %+v", req))
+	result, err := ctr.bookSlotService.Execute(ctx, &req)
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(fmt.Sprintf("BookSlot result: candidate %s, slot %s", result.CandidateID, result.Slot.ID))
+	return response.Success(http.StatusOK, "Booked interview successfully", result)
+}
+
+func (ctr *InterviewSlotController) Cancel(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	id, ok := event.PathParameters["id"]
+	if !ok || id == "" {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid ID", "ERR_INVALID_ID")
+	}
+
+	req := commands.CancelBookingServiceInput{
+		CandidateID: id,
+	}
+	if err := validators.CancelBooking(&req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(fmt.Sprintf("CancelBooking request: %+v", req))
+	result, err := ctr.cancelBookingService.Execute(ctx, &req)
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(fmt.Sprintf("CancelBooking result: %+v", result))
+	return response.Success(http.StatusOK, "Cancelled interview successfully", result)
+}
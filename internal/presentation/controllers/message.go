@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/application/services/queries"
+	"github.com/Yolto7/api-candidates/internal/presentation/validators"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/request"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/response"
+)
+
+// MessageController sends template messages to candidates and lists them
+type MessageController struct {
+	logger              logger.Logger
+	sendMessageService  *commands.SendMessageService
+	listMessagesService *queries.ListMessagesService
+}
+
+type MessageControllerConfig struct {
+	Logger              logger.Logger
+	SendMessageService  *commands.SendMessageService
+	ListMessagesService *queries.ListMessagesService
+}
+
+func NewMessageController(cfg MessageControllerConfig) *MessageController {
+	return &MessageController{
+		logger:              cfg.Logger,
+		sendMessageService:  cfg.SendMessageService,
+		listMessagesService: cfg.ListMessagesService,
+	}
+}
+
+// Queries
+func (ctr *MessageController) List(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	id, ok := event.PathParameters["id"]
+	if !ok || id == "" {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid ID", "ERR_INVALID_ID")
+	}
+
+	req := queries.ListMessagesServiceInput{
+		CandidateID: id,
+	}
+	if err := validators.ListMessages(req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(fmt.Sprintf("ListMessages request: %+v", req))
+	result, err := ctr.listMessagesService.Execute(ctx, req)
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(fmt.Sprintf("ListMessages result: %d messages", len(result.Messages)))
+	return response.Success(http.StatusOK, "Listed messages successfully", result)
+}
+
+// Commands
+func (ctr *MessageController) Send(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	id, ok := event.PathParameters["id"]
+	if !ok || id == "" {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid ID", "ERR_INVALID_ID")
+	}
+
+	var req commands.SendMessageServiceInput
+	if err := request.BindJSON(event, &req); err != nil {
+		return nil, err
+	}
+	req.CandidateID = id
+	if err := validators.SendMessage(&req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(fmt.Sprintf("SendMessage request: %+v", req))
+	result, err := ctr.sendMessageService.Execute(ctx, &req)
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(fmt.Sprintf("SendMessage result: candidate %s, message %s", result.CandidateID, result.MessageID))
+	return response.Success(http.StatusOK, "Sent message successfully", result)
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/application/services/queries"
+	"github.com/Yolto7/api-candidates/internal/presentation/validators"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/response"
+)
+
+// OutboxController exposes the administration of the outbox
+type OutboxController struct {
+	logger                  logger.Logger
+	listFailedOutboxService *queries.ListFailedOutboxService
+	retryOutboxService      *commands.RetryOutboxService
+}
+
+type OutboxControllerConfig struct {
+	Logger                  logger.Logger
+	ListFailedOutboxService *queries.ListFailedOutboxService
+	RetryOutboxService      *commands.RetryOutboxService
+}
+
+func NewOutboxController(cfg OutboxControllerConfig) *OutboxController {
+	return &OutboxController{
+		logger:                  cfg.Logger,
+		listFailedOutboxService: cfg.ListFailedOutboxService,
+		retryOutboxService:      cfg.RetryOutboxService,
+	}
+}
+
+// Queries
+func (ctr *OutboxController) ListFailed(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	result, err := ctr.listFailedOutboxService.Execute(ctx, queries.ListFailedOutboxServiceInput{})
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(fmt.Sprintf("ListFailedOutbox result: %d records", len(result.Records)))
+	return response.Success(http.StatusOK, "Listed failed outbox records successfully", result)
+}
+
+// Commands
+func (ctr *OutboxController) Retry(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	id, ok := event.PathParameters["id"]
+	if !ok || id == "" {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid ID", "ERR_INVALID_ID")
+	}
+
+	req := commands.RetryOutboxServiceInput{
+		ID: id,
+	}
+	if err := validators.RetryOutbox(&req); err != nil {
+		return nil, err
+	}
+
+	ctr.logger.Info(fmt.Sprintf("RetryOutbox request: %+v", req))
+	result, err := ctr.retryOutboxService.Execute(ctx, &req)
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(fmt.Sprintf("RetryOutbox result: %+v", result))
+	return response.Success(http.StatusOK, "Outbox record queued for retry", result)
+}
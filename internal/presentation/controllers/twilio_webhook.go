@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/request"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/response"
+)
+
+type TwilioWebhookController struct {
+	logger                     logger.Logger
+	recordMessageStatusService *commands.RecordMessageStatusService
+}
+
+type TwilioWebhookControllerConfig struct {
+	Logger                     logger.Logger
+	RecordMessageStatusService *commands.RecordMessageStatusService
+}
+
+func NewTwilioWebhookController(cfg TwilioWebhookControllerConfig) *TwilioWebhookController {
+	return &TwilioWebhookController{
+		logger:                     cfg.Logger,
+		recordMessageStatusService: cfg.RecordMessageStatusService,
+	}
+}
+
+// Status records the delivery status posted by Twilio for an SMS. Statuses
+// before the message leaves Twilio (queued, sending...) are acknowledged and
+// skipped. An error makes Twilio retry the callback.
+func (ctr *TwilioWebhookController) Status(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	form, err := request.BindForm(event)
+	if err != nil {
+		return nil, err
+	}
+
+	messageID := form.Get("MessageSid")
+	status, ok := twilioMessageStatuses[form.Get("MessageStatus")]
+	if messageID == "" || !ok {
+		ctr.logger.Info(map[string]any{
+			"msg":       "Skipping Twilio status",
+			"messageId": messageID,
+			"status":    form.Get("MessageStatus"),
+		})
+		return response.Success(http.StatusOK, "Webhook received successfully", map[string]int{"statuses": 0})
+	}
+
+	result, err := ctr.recordMessageStatusService.Execute(ctx, &commands.RecordMessageStatusServiceInput{
+		MessageID:  messageID,
+		Status:     status,
+		ErrorCode:  form.Get("ErrorCode"),
+		ErrorTitle: form.Get("ErrorMessage"),
+	})
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	ctr.logger.Info(map[string]any{
+		"msg":       "Twilio status recorded",
+		"messageId": messageID,
+		"status":    status,
+		"matched":   result.Matched,
+	})
+	statuses := 0
+	if result.Matched {
+		statuses = 1
+	}
+	return response.Success(http.StatusOK, "Webhook received successfully", map[string]int{"statuses": statuses})
+}
+
+var twilioMessageStatuses = map[string]entities.MessageStatus{
+	"sent":        entities.MessageSent,
+	"delivered":   entities.MessageDelivered,
+	"read":        entities.MessageRead,
+	"undelivered": entities.MessageFailed,
+	"failed":      entities.MessageFailed,
+}
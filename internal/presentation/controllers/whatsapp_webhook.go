@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/idempotency"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/request"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/response"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/validators"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/whatsapp"
+)
+
+// The provider retries a failed delivery for up to 7 days
+const whatsAppMessageTTL = 7 * 24 * time.Hour
+
+type WhatsAppWebhookController struct {
+	logger                     logger.Logger
+	verifyToken                func(ctx context.Context) (string, error)
+	messages                   idempotency.Store
+	registerResponseService    *commands.RegisterResponseService
+	recordMessageStatusService *commands.RecordMessageStatusService
+}
+
+type WhatsAppWebhookControllerConfig struct {
+	Logger                  logger.Logger
+	VerifyToken             func(ctx context.Context) (string, error)
+	Messages                idempotency.Store
+	RegisterResponseService *commands.RegisterResponseService
+	// RecordMessageStatusService is optional; without it status updates of
+	// outbound messages are acknowledged and dropped
+	RecordMessageStatusService *commands.RecordMessageStatusService
+}
+
+func NewWhatsAppWebhookController(cfg WhatsAppWebhookControllerConfig) *WhatsAppWebhookController {
+	return &WhatsAppWebhookController{
+		logger:                     cfg.Logger,
+		verifyToken:                cfg.VerifyToken,
+		messages:                   cfg.Messages,
+		registerResponseService:    cfg.RegisterResponseService,
+		recordMessageStatusService: cfg.RecordMessageStatusService,
+	}
+}
+
+// Verify answers the subscription challenge with hub.challenge as plain text
+func (ctr *WhatsAppWebhookController) Verify(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	token, err := ctr.verifyToken(ctx)
+	if err != nil {
+		return nil, errorCustom.FromError(err)
+	}
+
+	challenge, ok := whatsapp.VerifyChallenge(event.QueryStringParameters, token)
+	if !ok {
+		return nil, errorCustom.NewError(errorCustom.FORBIDDEN, "Invalid verify token", "ERR_INVALID_VERIFY_TOKEN")
+	}
+
+	return &gateway.Response{
+		StatusCode: http.StatusOK,
+		Headers:    map[string]string{"Content-Type": "text/plain"},
+		Body:       challenge,
+	}, nil
+}
+
+// Receive registers the replies and the status updates of a notification.
+// Each message is claimed by ID first, so duplicate deliveries are
+// acknowledged without being applied twice. If a message fails its claim is
+// released and the error is returned, letting the provider retry the
+// delivery. Status updates need no claim: recording one twice is a no-op.
+func (ctr *WhatsAppWebhookController) Receive(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	var payload whatsapp.WebhookPayload
+	if err := request.BindJSON(event, &payload, request.BindOptions{AllowUnknownFields: true}); err != nil {
+		return nil, err
+	}
+	if payload.Object != whatsapp.OBJECT_BUSINESS_ACCOUNT {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Unsupported webhook object", "ERR_UNSUPPORTED_WEBHOOK")
+	}
+
+	processed := 0
+	for _, message := range payload.Messages() {
+		applied, err := ctr.receiveMessage(ctx, message)
+		if err != nil {
+			return nil, errorCustom.FromError(err)
+		}
+		if applied {
+			processed++
+		}
+	}
+
+	statuses := 0
+	for _, status := range payload.Statuses() {
+		matched, err := ctr.recordStatus(ctx, status)
+		if err != nil {
+			return nil, errorCustom.FromError(err)
+		}
+		if matched {
+			statuses++
+		}
+	}
+
+	return response.Success(http.StatusOK, "Webhook received successfully", map[string]int{"processed": processed, "statuses": statuses})
+}
+
+func (ctr *WhatsAppWebhookController) receiveMessage(ctx context.Context, message whatsapp.Message) (bool, error) {
+	phone, ok := validators.NormalizePEPhone(message.From)
+	content := message.Content()
+	if message.ID == "" || !ok || content == "" {
+		ctr.logger.Info(map[string]any{
+			"msg":       "Skipping WhatsApp message",
+			"messageId": message.ID,
+			"type":      message.Type,
+		})
+		return false, nil
+	}
+
+	key := "whatsapp#message#" + message.ID
+	fresh, err := ctr.messages.Claim(ctx, key, whatsAppMessageTTL)
+	if err != nil {
+		ctr.logger.Error(utils.NewSafeError(err, "Error in WhatsAppWebhookController.Receive: Failed to claim message"))
+		return false, errorCustom.Wrap(err, errorCustom.SERVICE_UNAVAILABLE, "Unable to register message", "ERR_IDEMPOTENCY")
+	}
+	if !fresh {
+		ctr.logger.Info(map[string]any{"msg": "Duplicate WhatsApp message", "messageId": message.ID})
+		return false, nil
+	}
+
+	result, err := ctr.registerResponseService.Execute(ctx, &commands.RegisterResponseServiceInput{
+		Phone:      phone,
+		MessageID:  message.ID,
+		Message:    content,
+		ReceivedAt: message.SentAt(),
+	})
+	if err != nil {
+		if releaseErr := ctr.messages.Release(ctx, key); releaseErr != nil {
+			ctr.logger.Error(utils.NewSafeError(releaseErr, "Error in WhatsAppWebhookController.Receive: Failed to release message"))
+		}
+		return false, err
+	}
+
+	ctr.logger.Info(map[string]any{
+		"msg":         "WhatsApp reply registered",
+		"messageId":   message.ID,
+		"matched":     result.Matched,
+		"candidateId": result.CandidateID,
+		"intent":      result.Intent,
+	})
+	return result.Matched, nil
+}
+
+func (ctr *WhatsAppWebhookController) recordStatus(ctx context.Context, status whatsapp.Status) (bool, error) {
+	if ctr.recordMessageStatusService == nil {
+		return false, nil
+	}
+
+	messageStatus, ok := whatsAppMessageStatuses[status.Status]
+	if status.ID == "" || !ok {
+		ctr.logger.Info(map[string]any{
+			"msg":       "Skipping WhatsApp status",
+			"messageId": status.ID,
+			"status":    status.Status,
+		})
+		return false, nil
+	}
+
+	input := &commands.RecordMessageStatusServiceInput{
+		MessageID: status.ID,
+		Status:    messageStatus,
+		At:        status.ReportedAt(),
+	}
+	if len(status.Errors) > 0 {
+		input.ErrorCode = strconv.Itoa(status.Errors[0].Code)
+		input.ErrorTitle = status.Errors[0].Title
+	}
+
+	result, err := ctr.recordMessageStatusService.Execute(ctx, input)
+	if err != nil {
+		return false, err
+	}
+
+	ctr.logger.Info(map[string]any{
+		"msg":               "WhatsApp status recorded",
+		"messageId":         status.ID,
+		"status":            messageStatus,
+		"matched":           result.Matched,
+		"fallbackMessageId": result.FallbackMessageID,
+	})
+	return result.Matched, nil
+}
+
+var whatsAppMessageStatuses = map[string]entities.MessageStatus{
+	whatsapp.STATUS_SENT:      entities.MessageSent,
+	whatsapp.STATUS_DELIVERED: entities.MessageDelivered,
+	whatsapp.STATUS_READ:      entities.MessageRead,
+	whatsapp.STATUS_FAILED:    entities.MessageFailed,
+}
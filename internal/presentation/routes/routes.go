@@ -0,0 +1,248 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/application/services/queries"
+	"github.com/Yolto7/api-candidates/internal/presentation/controllers"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/middlewares"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/openapi"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/router"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/whatsapp"
+)
+
+const PREFIX = "candidates"
+
+// Info is the header of the generated OpenAPI contract
+var Info = openapi.Info{
+	Title:       "KFC Reclutador - Candidates API",
+	Version:     "1.0.0",
+	Description: "Candidates of the KFC recruiting flow and the spreadsheet coordinates used to track them.",
+}
+
+type Dependencies struct {
+	CandidateController       *controllers.CandidateController
+	WhatsAppWebhookController *controllers.WhatsAppWebhookController
+	// WhatsAppSignature verifies the provider signature on inbound
+	// notifications; it may be nil when only the contract is generated
+	WhatsAppSignature       middlewares.Middleware
+	OutboxController        *controllers.OutboxController
+	InterviewSlotController *controllers.InterviewSlotController
+	MessageController       *controllers.MessageController
+	TwilioWebhookController *controllers.TwilioWebhookController
+	// TwilioSignature verifies the signature of Twilio status callbacks; it
+	// may be nil when only the contract is generated
+	TwilioSignature middlewares.Middleware
+	// AdminAuth authenticates the administration routes; it may be nil when
+	// only the contract is generated
+	AdminAuth middlewares.Middleware
+}
+
+// Register declares every HTTP route of the service. It is shared by the
+// Lambda entrypoint and the OpenAPI build command so both see the same table.
+func Register(r *router.Router, deps Dependencies) {
+	ctr := deps.CandidateController
+
+	candidates := r.Group(PREFIX)
+
+	candidates.GET("/openapi.json", openapi.Handler(r, Info),
+		router.Name("candidates.openapi"),
+		router.Doc(router.Docs{Hidden: true}),
+	)
+
+	candidates.GET("/search", ctr.FindByPhone,
+		router.Name("candidates.findByPhone"),
+		router.Doc(router.Docs{
+			Summary:     "Find candidates by phone",
+			Description: "Active candidacies of a Peruvian mobile number, most recent first. Used to match inbound WhatsApp messages.",
+			Tags:        []string{"Candidates"},
+			Params:      queries.FindByPhoneServiceInput{},
+			Response:    queries.FindByPhoneServiceOutput{},
+		}),
+	)
+	candidates.GET("/search/document", ctr.FindByDocument,
+		router.Name("candidates.findByDocument"),
+		router.Doc(router.Docs{
+			Summary:     "Find candidates by document",
+			Description: "Active candidacies of a DNI or CE.",
+			Tags:        []string{"Candidates"},
+			Params:      queries.FindByDocumentServiceInput{},
+			Response:    queries.FindByDocumentServiceOutput{},
+		}),
+	)
+	candidates.GET("/{id}", ctr.GetByID,
+		router.Name("candidates.getById"),
+		router.Doc(router.Docs{
+			Summary:  "Get a candidate by ID",
+			Tags:     []string{"Candidates"},
+			Params:   queries.GetByIDServiceInput{},
+			Response: queries.GetByIDServiceOutput{},
+		}),
+	)
+	candidates.POST("/", ctr.Create,
+		router.Name("candidates.create"),
+		router.Doc(router.Docs{
+			Summary:  "Create a candidate",
+			Tags:     []string{"Candidates"},
+			Body:     commands.CreateServiceInput{},
+			Response: commands.CreateServiceOutput{},
+			Status:   http.StatusOK,
+		}),
+	)
+	candidates.PATCH("/{id}", ctr.Update,
+		router.Name("candidates.update"),
+		router.Doc(router.Docs{
+			Summary:     "Update the profile of a candidate",
+			Description: "Partial update: only the fields sent are changed. documentType and documentNumber must be sent together. Changing the interview of a candidate holding a slot booking fails with 409; cancel the booking instead.",
+			Tags:        []string{"Candidates"},
+			Body:        commands.UpdateServiceInput{},
+			Response:    commands.UpdateServiceOutput{},
+		}),
+	)
+	registerInterviews(candidates, deps)
+	candidates.GET("/{id}/messages", deps.MessageController.List,
+		router.Name("candidates.messages.list"),
+		router.Doc(router.Docs{
+			Summary:     "List the messages sent to a candidate",
+			Description: "Up to 50 messages, newest first, each with its current delivery status and the history of statuses reported by the provider.",
+			Tags:        []string{"Messages"},
+			Params:      queries.ListMessagesServiceInput{},
+			Response:    queries.ListMessagesServiceOutput{},
+		}),
+	)
+	candidates.POST("/{id}/messages", deps.MessageController.Send,
+		router.Name("candidates.messages.send"),
+		router.Doc(router.Docs{
+			Summary:     "Send a template message to a candidate",
+			Description: "Renders the named Spanish template (invitation, interview, reminder or followup) with the data of the candidate, sends it and records sentAt and sentMessageId. Fails with 400 when the candidate lacks data the template requires.",
+			Tags:        []string{"Messages"},
+			Body:        commands.SendMessageServiceInput{},
+			Response:    commands.SendMessageServiceOutput{},
+		}),
+	)
+	registerWebhooks(candidates.Group("/webhooks"), deps)
+	registerAdmin(candidates.Group("/admin"), deps)
+
+	candidates.DELETE("/{id}", ctr.Delete,
+		router.Name("candidates.delete"),
+		router.Doc(router.Docs{
+			Summary:     "Delete a candidate",
+			Description: "Releases the interview seat of a booked candidate in the same transaction and cancels its meeting. Fails with 409 when the booking changes meanwhile.",
+			Tags:        []string{"Candidates"},
+			Params:      commands.DeleteServiceInput{},
+			Response:    commands.DeleteServiceOutput{},
+		}),
+	)
+}
+
+func registerInterviews(candidates *router.Group, deps Dependencies) {
+	slots := deps.InterviewSlotController
+
+	candidates.GET("/slots", slots.List,
+		router.Name("candidates.slots.list"),
+		router.Doc(router.Docs{
+			Summary:     "List the interview slots of a store",
+			Description: "Slots starting between from and to, both included, earliest first. Defaults to the next two weeks.",
+			Tags:        []string{"Interviews"},
+			Params:      queries.ListSlotsServiceInput{},
+			Response:    queries.ListSlotsServiceOutput{},
+		}),
+	)
+	candidates.POST("/slots", slots.Publish,
+		router.Name("candidates.slots.publish"),
+		router.Doc(router.Docs{
+			Summary:     "Publish interview slots",
+			Description: "Dates and times are local to timeZone, the zone of the store. Publishing is idempotent: slots already published are listed in existing and left as they are.",
+			Tags:        []string{"Interviews"},
+			Body:        commands.PublishSlotsServiceInput{},
+			Response:    commands.PublishSlotsServiceOutput{},
+		}),
+	)
+	candidates.POST("/{id}/interview", slots.Book,
+		router.Name("candidates.interview.book"),
+		router.Doc(router.Docs{
+			Summary:     "Book an interview slot for a candidate",
+			Description: "Takes a seat of the slot and fills the interview date, time and link of the candidate atomically. Fails with 409 when the slot is full or the candidate already holds another booking.",
+			Tags:        []string{"Interviews"},
+			Body:        commands.BookSlotServiceInput{},
+			Response:    commands.BookSlotServiceOutput{},
+		}),
+	)
+	candidates.DELETE("/{id}/interview", slots.Cancel,
+		router.Name("candidates.interview.cancel"),
+		router.Doc(router.Docs{
+			Summary:     "Cancel the interview booking of a candidate",
+			Description: "Releases the seat and clears the interview date, time and link of the candidate.",
+			Tags:        []string{"Interviews"},
+			Params:      commands.CancelBookingServiceInput{},
+			Response:    commands.CancelBookingServiceOutput{},
+		}),
+	)
+}
+
+func registerWebhooks(webhooks *router.Group, deps Dependencies) {
+	whatsApp := deps.WhatsAppWebhookController
+
+	webhooks.GET("/whatsapp", whatsApp.Verify,
+		router.Name("candidates.webhooks.whatsapp.verify"),
+		router.Doc(router.Docs{
+			Summary:     "Verify the WhatsApp webhook subscription",
+			Description: "Returns hub.challenge as text/plain when hub.verify_token matches.",
+			Tags:        []string{"Webhooks"},
+			Params:      whatsAppVerifyParams{},
+		}),
+	)
+	webhooks.POST("/whatsapp", whatsApp.Receive,
+		router.Name("candidates.webhooks.whatsapp.receive"),
+		router.With(deps.WhatsAppSignature),
+		router.Doc(router.Docs{
+			Summary:     "Receive WhatsApp notifications",
+			Description: "Signed with x-hub-signature-256. Replies are matched to the candidate by phone, analyzed and stored; duplicate message IDs are ignored. Status updates of outbound messages are added to their history.",
+			Tags:        []string{"Webhooks"},
+			Body:        whatsapp.WebhookPayload{},
+		}),
+	)
+	webhooks.POST("/twilio", deps.TwilioWebhookController.Status,
+		router.Name("candidates.webhooks.twilio.status"),
+		router.With(deps.TwilioSignature),
+		router.Doc(router.Docs{
+			Summary:     "Receive Twilio SMS status callbacks",
+			Description: "Form-encoded and signed with x-twilio-signature. The status is added to the history of the message.",
+			Tags:        []string{"Webhooks"},
+		}),
+	)
+}
+
+func registerAdmin(admin *router.Group, deps Dependencies) {
+	outbox := deps.OutboxController
+
+	admin.GET("/outbox/failed", outbox.ListFailed,
+		router.Name("candidates.admin.outbox.listFailed"),
+		router.With(deps.AdminAuth),
+		router.Doc(router.Docs{
+			Summary:     "List failed outbox records",
+			Description: "Side effects that exhausted their delivery attempts, oldest first. Payloads are not included.",
+			Tags:        []string{"Admin"},
+			Response:    queries.ListFailedOutboxServiceOutput{},
+		}),
+	)
+	admin.POST("/outbox/{id}/retry", outbox.Retry,
+		router.Name("candidates.admin.outbox.retry"),
+		router.With(deps.AdminAuth),
+		router.Doc(router.Docs{
+			Summary:     "Retry a failed outbox record",
+			Description: "Resets the attempts of the record and makes it due on the next processor run.",
+			Tags:        []string{"Admin"},
+			Params:      commands.RetryOutboxServiceInput{},
+			Response:    commands.RetryOutboxServiceOutput{},
+		}),
+	)
+}
+
+// whatsAppVerifyParams documents the query of the subscription handshake
+type whatsAppVerifyParams struct {
+	Mode        string `json:"hub.mode" validate:"required,oneof=subscribe"`
+	VerifyToken string `json:"hub.verify_token" validate:"required"`
+	Challenge   string `json:"hub.challenge" validate:"required"`
+}
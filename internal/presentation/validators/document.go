@@ -0,0 +1,68 @@
+package validators
+
+import (
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/application/services/queries"
+	"github.com/Yolto7/api-candidates/internal/domain/entities"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/validators"
+)
+
+func init() {
+	validators.RegisterStructValidator(validateCreateDocument, commands.CreateServiceInput{})
+	validators.RegisterStructValidator(validateUpdateDocument, commands.UpdateServiceInput{})
+	validators.RegisterStructValidator(validateFindDocument, queries.FindByDocumentServiceInput{})
+}
+
+// validateCreateDocument and validateUpdateDocument require documentType and
+// documentNumber together, since the document key is built from both
+func validateCreateDocument(sl validator.StructLevel) {
+	input := sl.Current().Interface().(commands.CreateServiceInput)
+
+	switch {
+	case input.DocumentType != "" && input.DocumentNumber == "":
+		sl.ReportError(input.DocumentNumber, "documentNumber", "DocumentNumber", "required_with", "documentType")
+	case input.DocumentType == "" && input.DocumentNumber != "":
+		sl.ReportError(input.DocumentType, "documentType", "DocumentType", "required_with", "documentNumber")
+	default:
+		reportDocumentNumber(sl, input.DocumentType, input.DocumentNumber)
+	}
+}
+
+func validateUpdateDocument(sl validator.StructLevel) {
+	input := sl.Current().Interface().(commands.UpdateServiceInput)
+
+	switch {
+	case input.DocumentType != nil && input.DocumentNumber == nil:
+		sl.ReportError(input.DocumentNumber, "documentNumber", "DocumentNumber", "required_with", "documentType")
+	case input.DocumentType == nil && input.DocumentNumber != nil:
+		sl.ReportError(input.DocumentType, "documentType", "DocumentType", "required_with", "documentNumber")
+	case input.DocumentType != nil && input.DocumentNumber != nil:
+		reportDocumentNumber(sl, *input.DocumentType, *input.DocumentNumber)
+	}
+}
+
+func validateFindDocument(sl validator.StructLevel) {
+	input := sl.Current().Interface().(queries.FindByDocumentServiceInput)
+	reportDocumentNumber(sl, input.DocumentType, input.DocumentNumber)
+}
+
+// reportDocumentNumber checks the number against the format of its type.
+// Unknown types are already reported by the oneof rule.
+func reportDocumentNumber(sl validator.StructLevel, documentType, documentNumber string) {
+	if documentNumber == "" {
+		return
+	}
+
+	switch documentType {
+	case entities.DocumentTypeDNI:
+		if !validators.IsDNI(documentNumber) {
+			sl.ReportError(documentNumber, "documentNumber", "DocumentNumber", "dni", "")
+		}
+	case entities.DocumentTypeCE:
+		if !validators.IsCE(documentNumber) {
+			sl.ReportError(documentNumber, "documentNumber", "DocumentNumber", "ce", "")
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package validators
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Yolto7/api-candidates/internal/application/services/commands"
+	"github.com/Yolto7/api-candidates/internal/application/services/queries"
+)
+
+func TestCreateProfile(t *testing.T) {
+	base := func() commands.CreateServiceInput {
+		return commands.CreateServiceInput{
+			ID:                                "c1",
+			SheetID:                           "1234567890123456",
+			RowID:                             "1234567890123457",
+			ColumnPostulantResponseId:         "1234567890123458",
+			ColumnPostulantDateTimeResponseId: "1234567890123459",
+			ColumnPostulantConfirmedId:        "1234567890123460",
+			ColumnInterviewDateId:             "1234567890123461",
+			ColumnInterviewTimeId:             "1234567890123462",
+			ColumnInterviewLinkId:             "1234567890123463",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(input *commands.CreateServiceInput)
+		valid  bool
+	}{
+		{"without profile", func(input *commands.CreateServiceInput) {}, true},
+		{"full profile", func(input *commands.CreateServiceInput) {
+			input.Names = "Ana"
+			input.Surnames = "Pérez"
+			input.DocumentType = "DNI"
+			input.DocumentNumber = "45678912"
+			input.Phone = "987654321"
+		}, true},
+		{"blank names are left out", func(input *commands.CreateServiceInput) { input.Names = "   " }, true},
+		{"names too long", func(input *commands.CreateServiceInput) { input.Names = strings.Repeat("a", 101) }, false},
+		{"invalid phone", func(input *commands.CreateServiceInput) { input.Phone = "12345" }, false},
+		{"document type without number", func(input *commands.CreateServiceInput) { input.DocumentType = "DNI" }, false},
+		{"document number without type", func(input *commands.CreateServiceInput) { input.DocumentNumber = "45678912" }, false},
+		{"document number of another type", func(input *commands.CreateServiceInput) {
+			input.DocumentType = "DNI"
+			input.DocumentNumber = "ABC123"
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := base()
+			tt.modify(&input)
+			if err := Create(&input); (err == nil) != tt.valid {
+				t.Fatalf("Create() error = %v, valid = %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestFindByDocument(t *testing.T) {
+	tests := []struct {
+		name  string
+		input queries.FindByDocumentServiceInput
+		valid bool
+	}{
+		{"DNI", queries.FindByDocumentServiceInput{DocumentType: "DNI", DocumentNumber: " 45678912 "}, true},
+		{"without number", queries.FindByDocumentServiceInput{DocumentType: "DNI"}, false},
+		{"without type", queries.FindByDocumentServiceInput{DocumentNumber: "45678912"}, false},
+		{"unknown type", queries.FindByDocumentServiceInput{DocumentType: "RUC", DocumentNumber: "45678912"}, false},
+		{"number of another type", queries.FindByDocumentServiceInput{DocumentType: "DNI", DocumentNumber: "ABC123"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			if err := FindByDocument(&input); (err == nil) != tt.valid {
+				t.Fatalf("FindByDocument() error = %v, valid = %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -11,10 +11,52 @@ func GetByID(input queries.GetByIDServiceInput) error {
 	return validators.ValidateSchema(&input)
 }
 
+// FindByPhone takes a pointer: pe_phone normalizes the phone to E.164
+func FindByPhone(input *queries.FindByPhoneServiceInput) error {
+	return validators.ValidateSchema(input)
+}
+
+// FindByDocument takes a pointer: strings are trimmed before the document
+// number is checked against its type
+func FindByDocument(input *queries.FindByDocumentServiceInput) error {
+	return validators.ValidateSchema(input)
+}
+
 func Create(input *commands.CreateServiceInput) error {
 	return validators.ValidateSchema(input)
 }
 
+func Update(input *commands.UpdateServiceInput) error {
+	return validators.ValidateSchema(input)
+}
+
 func Delete(input *commands.DeleteServiceInput) error {
 	return validators.ValidateSchema(input)
-}
\ No newline at end of file
+}
+func RetryOutbox(input *commands.RetryOutboxServiceInput) error {
+	return validators.ValidateSchema(input)
+}
+
+func ListSlots(input queries.ListSlotsServiceInput) error {
+	return validators.ValidateSchema(&input)
+}
+
+func PublishSlots(input *commands.PublishSlotsServiceInput) error {
+	return validators.ValidateSchema(input)
+}
+
+func BookSlot(input *commands.BookSlotServiceInput) error {
+	return validators.ValidateSchema(input)
+}
+
+func CancelBooking(input *commands.CancelBookingServiceInput) error {
+	return validators.ValidateSchema(input)
+}
+
+func SendMessage(input *commands.SendMessageServiceInput) error {
+	return validators.ValidateSchema(input)
+}
+
+func ListMessages(input queries.ListMessagesServiceInput) error {
+	return validators.ValidateSchema(&input)
+}
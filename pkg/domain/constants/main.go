@@ -8,8 +8,14 @@ const (
 )
 
 const (
-	HEADER_AUTHORIZATION = "authorization"
-	HEADER_TRACE_ID       = "x-trace-id"
+	HEADER_AUTHORIZATION    = "authorization"
+	HEADER_TRACE_ID         = "x-trace-id"
+	HEADER_CLIENT_ID        = "x-client-id"
+	HEADER_TIMESTAMP        = "x-timestamp"
+	HEADER_NONCE            = "x-nonce"
+	HEADER_SIGNATURE        = "x-signature"
+	HEADER_HUB_SIGNATURE    = "x-hub-signature-256"
+	HEADER_TWILIO_SIGNATURE = "x-twilio-signature"
 )
 
 const (
@@ -25,4 +31,38 @@ const (
 const (
 	DEFAULT_DATE_FORMAT = "2006-01-02"
 	DEFAULT_TIME_ZONE = "America/Lima"
+)
+
+const (
+	ENCRYPTION_PROVIDER_KMS    = "kms"
+	ENCRYPTION_PROVIDER_STATIC = "static"
+)
+
+const (
+	SHEETS_PROVIDER_SMARTSHEET = "smartsheet"
+	SHEETS_PROVIDER_MEMORY     = "memory"
+)
+
+const (
+	MEETINGS_PROVIDER_ZOOM  = "zoom"
+	MEETINGS_PROVIDER_LOCAL = "local"
+)
+
+const (
+	MESSAGING_PROVIDER_WHATSAPP = "whatsapp"
+	MESSAGING_PROVIDER_SMS      = "sms"
+	MESSAGING_PROVIDER_MEMORY   = "memory"
+)
+
+const (
+	EVENTS_PROVIDER_EVENTBRIDGE = "eventbridge"
+	EVENTS_PROVIDER_MEMORY      = "memory"
+
+	// EVENT_SOURCE_CANDIDATES is the source of the events of this service
+	EVENT_SOURCE_CANDIDATES = "kfc-rec.candidates"
+)
+
+const (
+	SCHEDULER_PROVIDER_EVENTBRIDGE = "eventbridge"
+	SCHEDULER_PROVIDER_MEMORY      = "memory"
 )
\ No newline at end of file
@@ -1,6 +1,7 @@
 package error
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -15,7 +16,13 @@ const (
 	NOT_FOUND            ErrorType = "NOT_FOUND"
 	NOT_ALLOWED          ErrorType = "NOT_ALLOWED"
 	UNSUPPORTED_CONTENT  ErrorType = "UNSUPPORTED_CONTENT_TYPE"
+	PAYLOAD_TOO_LARGE    ErrorType = "PAYLOAD_TOO_LARGE"
 	UNPROCESSABLE_ENTITY ErrorType = "UNPROCESSABLE_ENTITY"
+	CONFLICT             ErrorType = "CONFLICT"
+	TOO_MANY_REQUESTS    ErrorType = "TOO_MANY_REQUESTS"
+	INTERNAL             ErrorType = "INTERNAL"
+	SERVICE_UNAVAILABLE  ErrorType = "SERVICE_UNAVAILABLE"
+	TIMEOUT              ErrorType = "TIMEOUT"
 )
 
 var errorTypeToHttpCode = map[ErrorType]int{
@@ -25,22 +32,35 @@ var errorTypeToHttpCode = map[ErrorType]int{
 	NOT_FOUND:            http.StatusNotFound,
 	NOT_ALLOWED:          http.StatusMethodNotAllowed,
 	UNSUPPORTED_CONTENT:  http.StatusUnsupportedMediaType,
+	PAYLOAD_TOO_LARGE:    http.StatusRequestEntityTooLarge,
 	UNPROCESSABLE_ENTITY: http.StatusUnprocessableEntity,
+	CONFLICT:             http.StatusConflict,
+	TOO_MANY_REQUESTS:    http.StatusTooManyRequests,
+	INTERNAL:             http.StatusInternalServerError,
+	SERVICE_UNAVAILABLE:  http.StatusServiceUnavailable,
+	TIMEOUT:              http.StatusGatewayTimeout,
 }
 
 // ==== AppError ====
+// Cause and Details are internal: they are logged but never serialized
 type AppError struct {
 	HttpCode  int       `json:"-"`
 	ErrorType ErrorType `json:"errorType"`
 	Message   string    `json:"message"`
 	ErrorCode string    `json:"errorCode,omitempty"`
 	Payload   any       `json:"payload,omitempty"`
+	Cause     error     `json:"-"`
+	Details   any       `json:"-"`
 }
 
 func (e *AppError) Error() string {
 	return e.Message
 }
 
+func (e *AppError) Unwrap() error {
+	return e.Cause
+}
+
 // Creates an AppError directly (for controllers or services)
 func New(errorType ErrorType, message, errorCode string, payload ...any) *AppError {
 	code, ok := errorTypeToHttpCode[errorType]
@@ -63,17 +83,51 @@ func New(errorType ErrorType, message, errorCode string, payload ...any) *AppErr
 }
 
 // ==== CustomError: Reusable error for multiple services ====
+// Payload is returned to clients; Cause and Details are only for logs
 type CustomError struct {
 	ErrorType ErrorType
 	Message   string
 	ErrorCode string
 	Payload   any
+	Cause     error
+	Details   any
 }
 
 func (e *CustomError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s: %v", e.ErrorCode, e.Message, e.Cause)
+	}
 	return fmt.Sprintf("%s: %s", e.ErrorCode, e.Message)
 }
 
+func (e *CustomError) Unwrap() error {
+	return e.Cause
+}
+
+// Is matches errors with the same error code, so a CustomError declared as a
+// package-level value can be used as a sentinel with errors.Is
+func (e *CustomError) Is(target error) bool {
+	t, ok := target.(*CustomError)
+	if !ok {
+		return false
+	}
+	return e.ErrorCode != "" && e.ErrorCode == t.ErrorCode && e.ErrorType == t.ErrorType
+}
+
+// WithCause returns a copy of the error that wraps cause
+func (e *CustomError) WithCause(cause error) *CustomError {
+	copied := *e
+	copied.Cause = cause
+	return &copied
+}
+
+// WithDetails returns a copy of the error carrying internal details
+func (e *CustomError) WithDetails(details any) *CustomError {
+	copied := *e
+	copied.Details = details
+	return &copied
+}
+
 // Constructor
 func NewError(errorType ErrorType, message, errorCode string, payload ...any) *CustomError {
 	var p any
@@ -89,23 +143,51 @@ func NewError(errorType ErrorType, message, errorCode string, payload ...any) *C
 	}
 }
 
+// Wrap creates a CustomError whose cause is err
+func Wrap(err error, errorType ErrorType, message, errorCode string, payload ...any) *CustomError {
+	cerr := NewError(errorType, message, errorCode, payload...)
+	cerr.Cause = err
+	return cerr
+}
+
 // ==== Generic error transformer -> AppError ====
+// The whole chain is searched, so errors wrapped with fmt.Errorf("%w")
+// keep their type, status and code
 func FromError(err error) *AppError {
 	if err == nil {
 		return nil
 	}
 
-	// If is a CustomError, convert it to AppError
-	if cerr, ok := err.(*CustomError); ok {
-		return New(cerr.ErrorType, cerr.Message, cerr.ErrorCode, cerr.Payload)
+	// The outermost typed error wins, so a CustomError wrapping an AppError
+	// keeps its own status and code
+	for current := err; current != nil; current = errors.Unwrap(current) {
+		switch typed := current.(type) {
+		case *AppError:
+			return typed
+		case *CustomError:
+			return fromCustomError(err, typed)
+		}
 	}
 
-	// If is already an AppError, return it as is
-	if aerr, ok := err.(*AppError); ok {
+	// Joined errors (errors.Join) are not reachable through errors.Unwrap
+	var aerr *AppError
+	if errors.As(err, &aerr) {
 		return aerr
 	}
+	var cerr *CustomError
+	if errors.As(err, &cerr) {
+		return fromCustomError(err, cerr)
+	}
 
-	// Generic error
-	return New(BAD_REQUEST, err.Error(), "GENERIC_ERROR")
+	// Unknown errors are server faults; their text is not exposed to clients
+	appErr := New(INTERNAL, "Internal server error", "ERR_INTERNAL")
+	appErr.Cause = err
+	return appErr
 }
 
+func fromCustomError(err error, cerr *CustomError) *AppError {
+	appErr := New(cerr.ErrorType, cerr.Message, cerr.ErrorCode, cerr.Payload)
+	appErr.Cause = err
+	appErr.Details = cerr.Details
+	return appErr
+}
@@ -0,0 +1,25 @@
+package route
+
+import "context"
+
+type contextKey string
+
+const routeKey contextKey = "route"
+
+// Info describes the route that matched the current request. Pattern is the
+// registered template (e.g. "candidates/{id}") so logs and metrics can group
+// requests without the raw path values.
+type Info struct {
+	Method  string
+	Pattern string
+	Name    string
+}
+
+func SetInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, routeKey, info)
+}
+
+func GetInfo(ctx context.Context) (Info, bool) {
+	info, ok := ctx.Value(routeKey).(Info)
+	return info, ok
+}
@@ -0,0 +1,44 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// MinBlindIndexKeySize is the minimum length of the blind index key
+const MinBlindIndexKeySize = 32
+
+// BlindIndex computes deterministic HMAC-SHA256 digests of sensitive values
+// so they can be stored in a key attribute and queried by equality without
+// storing the value itself. The name of the index is part of the MAC, so the
+// same value yields unrelated digests in different indexes.
+type BlindIndex struct {
+	key []byte
+}
+
+func NewBlindIndex(key []byte) (*BlindIndex, error) {
+	if len(key) < MinBlindIndexKeySize {
+		return nil, fmt.Errorf("blind index key must be at least %d bytes, got %d", MinBlindIndexKeySize, len(key))
+	}
+	return &BlindIndex{key: key}, nil
+}
+
+// Compute returns the hex digest of value for the given index. Values must be
+// normalized by the caller (E.164 phone, upper-case document number).
+func (b *BlindIndex) Compute(index, value string) string {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write([]byte(index))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeriveKey derives an independent key for another purpose from a master
+// key, e.g. the blind index key from the static master key in local runs
+func DeriveKey(master []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, master)
+	mac.Write([]byte(purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,189 @@
+package crypto
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CiphertextPrefix marks values written by Envelope. Values without it are
+// returned as they are, so items stored before encryption remain readable.
+const CiphertextPrefix = "enc:v1:"
+
+const (
+	defaultKeyMaxAge  = 5 * time.Minute
+	defaultKeyMaxUses = 1000
+	maxCachedKeys     = 256
+)
+
+type EnvelopeConfig struct {
+	Provider KeyProvider
+	// KeyMaxAge and KeyMaxUses bound how long a data key is reused for
+	// encryption before a new one is requested from the provider
+	KeyMaxAge  time.Duration
+	KeyMaxUses int
+}
+
+// Envelope encrypts values with AES-256-GCM data keys issued by a
+// KeyProvider. Each ciphertext is self-contained:
+//
+//	enc:v1:base64(len(wrappedKey) uint16 || wrappedKey || nonce || sealed)
+//
+// Data keys are cached for a short time on both sides so that a request does
+// not call the provider once per field.
+type Envelope struct {
+	provider   KeyProvider
+	keyMaxAge  time.Duration
+	keyMaxUses int
+
+	mu        sync.Mutex
+	current   *cachedKey
+	unwrapped map[[sha256.Size]byte]*cachedKey
+}
+
+type cachedKey struct {
+	plaintext []byte
+	wrapped   []byte
+	createdAt time.Time
+	uses      int
+}
+
+func NewEnvelope(cfg EnvelopeConfig) *Envelope {
+	if cfg.KeyMaxAge <= 0 {
+		cfg.KeyMaxAge = defaultKeyMaxAge
+	}
+	if cfg.KeyMaxUses <= 0 {
+		cfg.KeyMaxUses = defaultKeyMaxUses
+	}
+
+	return &Envelope{
+		provider:   cfg.Provider,
+		keyMaxAge:  cfg.KeyMaxAge,
+		keyMaxUses: cfg.KeyMaxUses,
+		unwrapped:  make(map[[sha256.Size]byte]*cachedKey),
+	}
+}
+
+// Encrypt seals plaintext. aad is authenticated but not stored; the same
+// value must be given to Decrypt, which binds a ciphertext to its item and
+// attribute.
+func (e *Envelope) Encrypt(ctx context.Context, plaintext, aad string) (string, error) {
+	key, err := e.encryptionKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newGCM(key.plaintext)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(aead, []byte(plaintext), []byte(aad))
+	if err != nil {
+		return "", err
+	}
+
+	raw := make([]byte, 2, 2+len(key.wrapped)+len(sealed))
+	binary.BigEndian.PutUint16(raw, uint16(len(key.wrapped)))
+	raw = append(raw, key.wrapped...)
+	raw = append(raw, sealed...)
+
+	return CiphertextPrefix + base64.StdEncoding.EncodeToString(raw), nil
+}
+
+// Decrypt opens a value produced by Encrypt. Values that are not ciphertexts
+// are returned unchanged.
+func (e *Envelope) Decrypt(ctx context.Context, value, aad string) (string, error) {
+	if !IsCiphertext(value) {
+		return value, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, CiphertextPrefix))
+	if err != nil || len(raw) < 2 {
+		return "", errMalformedCiphertext
+	}
+	wrappedLen := int(binary.BigEndian.Uint16(raw))
+	if len(raw) < 2+wrappedLen {
+		return "", errMalformedCiphertext
+	}
+	wrapped, sealed := raw[2:2+wrappedLen], raw[2+wrappedLen:]
+
+	key, err := e.decryptionKey(ctx, wrapped)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(aead, sealed, []byte(aad))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// IsCiphertext reports whether value was produced by Envelope.Encrypt
+func IsCiphertext(value string) bool {
+	return strings.HasPrefix(value, CiphertextPrefix)
+}
+
+func (e *Envelope) encryptionKey(ctx context.Context) (*cachedKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if k := e.current; k != nil && k.uses < e.keyMaxUses && time.Since(k.createdAt) < e.keyMaxAge {
+		k.uses++
+		return k, nil
+	}
+
+	plaintext, wrapped, err := e.provider.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) > 0xFFFF {
+		return nil, fmt.Errorf("wrapped data key too large: %d bytes", len(wrapped))
+	}
+
+	e.current = &cachedKey{plaintext: plaintext, wrapped: wrapped, createdAt: time.Now(), uses: 1}
+	e.remember(e.current)
+	return e.current, nil
+}
+
+func (e *Envelope) decryptionKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	id := sha256.Sum256(wrapped)
+
+	e.mu.Lock()
+	if k, ok := e.unwrapped[id]; ok && time.Since(k.createdAt) < e.keyMaxAge {
+		e.mu.Unlock()
+		return k.plaintext, nil
+	}
+	e.mu.Unlock()
+
+	plaintext, err := e.provider.DecryptDataKey(ctx, wrapped)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.remember(&cachedKey{plaintext: plaintext, wrapped: append([]byte(nil), wrapped...), createdAt: time.Now()})
+	e.mu.Unlock()
+	return plaintext, nil
+}
+
+// remember must be called with mu held
+func (e *Envelope) remember(k *cachedKey) {
+	if len(e.unwrapped) >= maxCachedKeys {
+		for id, cached := range e.unwrapped {
+			if time.Since(cached.createdAt) >= e.keyMaxAge || len(e.unwrapped) >= maxCachedKeys {
+				delete(e.unwrapped, id)
+			}
+		}
+	}
+	e.unwrapped[sha256.Sum256(k.wrapped)] = k
+}
@@ -0,0 +1,70 @@
+package crypto
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TAG is the struct tag that marks sensitive fields:
+//
+//	pii:"encrypt"                 the attribute is stored encrypted
+//	pii:"encrypt,index=phoneHash" encrypted, plus a blind index in phoneHash
+//	pii:"hash"                    the attribute is replaced by its blind index
+//
+// The attribute name is read from the dynamodbav tag.
+const TAG = "pii"
+
+// Field describes how one attribute of an item is protected
+type Field struct {
+	Attribute  string
+	Encrypt    bool
+	Hash       bool
+	BlindIndex string
+}
+
+var fieldsCache sync.Map
+
+// FieldsOf returns the protected fields of a struct type, keyed by attribute
+func FieldsOf(v any) map[string]Field {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if cached, ok := fieldsCache.Load(t); ok {
+		return cached.(map[string]Field)
+	}
+
+	fields := make(map[string]Field)
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag, ok := sf.Tag.Lookup(TAG)
+			if !ok || !sf.IsExported() {
+				continue
+			}
+
+			attribute, _, _ := strings.Cut(sf.Tag.Get("dynamodbav"), ",")
+			if attribute == "" || attribute == "-" {
+				attribute = sf.Name
+			}
+
+			field := Field{Attribute: attribute}
+			for _, option := range strings.Split(tag, ",") {
+				name, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+				switch name {
+				case "encrypt":
+					field.Encrypt = true
+				case "hash":
+					field.Hash = true
+				case "index":
+					field.BlindIndex = value
+				}
+			}
+			fields[attribute] = field
+		}
+	}
+
+	fieldsCache.Store(t, fields)
+	return fields
+}
@@ -0,0 +1,75 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+var (
+	kmsInstance *kms.Client
+	kmsOnce     sync.Once
+)
+
+func GetKMSClient(ctx context.Context) (*kms.Client, error) {
+	var err error
+	kmsOnce.Do(func() {
+		cfg, cfgErr := config.LoadDefaultConfig(ctx)
+		if cfgErr != nil {
+			err = fmt.Errorf("failed to load config for KMS: %w", cfgErr)
+			return
+		}
+
+		kmsInstance = kms.NewFromConfig(cfg)
+	})
+
+	return kmsInstance, err
+}
+
+// KMSKeyProvider generates data keys under a KMS key. The encryption context
+// is bound to every data key, so a wrapped key copied to another service
+// cannot be unwrapped there.
+type KMSKeyProvider struct {
+	client            *kms.Client
+	keyID             string
+	encryptionContext map[string]string
+}
+
+func NewKMSKeyProvider(client *kms.Client, keyID string, encryptionContext map[string]string) *KMSKeyProvider {
+	return &KMSKeyProvider{
+		client:            client,
+		keyID:             keyID,
+		encryptionContext: encryptionContext,
+	}
+}
+
+func (p *KMSKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	res, err := p.client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:             aws.String(p.keyID),
+		KeySpec:           types.DataKeySpecAes256,
+		EncryptionContext: p.encryptionContext,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate KMS data key: %w", err)
+	}
+	return res.Plaintext, res.CiphertextBlob, nil
+}
+
+func (p *KMSKeyProvider) DecryptDataKey(ctx context.Context, wrapped []byte) ([]byte, error) {
+	res, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:             aws.String(p.keyID),
+		CiphertextBlob:    wrapped,
+		EncryptionContext: p.encryptionContext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt KMS data key: %w", err)
+	}
+	return res.Plaintext, nil
+}
+
+var _ KeyProvider = (*KMSKeyProvider)(nil)
@@ -0,0 +1,30 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+)
+
+var (
+	instance *eventbridge.Client
+	once     sync.Once
+)
+
+func GetClient(ctx context.Context) (*eventbridge.Client, error) {
+	var err error
+	once.Do(func() {
+		cfg, cfgErr := config.LoadDefaultConfig(ctx)
+		if cfgErr != nil {
+			err = fmt.Errorf("failed to load config for EventBridge: %w", cfgErr)
+			return
+		}
+
+		instance = eventbridge.NewFromConfig(cfg)
+	})
+
+	return instance, err
+}
@@ -0,0 +1,91 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Trigger is the kind of event that invoked the function
+type Trigger string
+
+const (
+	TriggerAPIGateway Trigger = "apigateway"
+	TriggerHTTPAPI    Trigger = "httpapi"
+	TriggerALB        Trigger = "alb"
+)
+
+// probe holds the fields that tell the event shapes apart: only HTTP APIs
+// send version 2.0 and only ALBs send requestContext.elb
+type probe struct {
+	Version        string `json:"version"`
+	RequestContext struct {
+		ELB *struct{} `json:"elb"`
+	} `json:"requestContext"`
+}
+
+// Detect returns the trigger of a raw event
+func Detect(payload []byte) (Trigger, error) {
+	var p probe
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return "", fmt.Errorf("gateway: invalid event: %w", err)
+	}
+
+	switch {
+	case p.Version == "2.0":
+		return TriggerHTTPAPI, nil
+	case p.RequestContext.ELB != nil:
+		return TriggerALB, nil
+	default:
+		return TriggerAPIGateway, nil
+	}
+}
+
+// Lambda returns a handler for lambda.Start that accepts REST API, HTTP API
+// and ALB events, so the same function can sit behind any of them. Responses
+// are returned in the shape of the trigger that sent the request.
+func Lambda(h Handler) func(ctx context.Context, payload json.RawMessage) (any, error) {
+	return func(ctx context.Context, payload json.RawMessage) (any, error) {
+		trigger, err := Detect(payload)
+		if err != nil {
+			return nil, err
+		}
+
+		switch trigger {
+		case TriggerHTTPAPI:
+			var event events.APIGatewayV2HTTPRequest
+			if err := json.Unmarshal(payload, &event); err != nil {
+				return nil, fmt.Errorf("gateway: invalid HTTP API event: %w", err)
+			}
+			res, err := h(ctx, FromHTTPAPI(event))
+			if res == nil {
+				return nil, err
+			}
+			return res.HTTPAPI(), err
+
+		case TriggerALB:
+			var event events.ALBTargetGroupRequest
+			if err := json.Unmarshal(payload, &event); err != nil {
+				return nil, fmt.Errorf("gateway: invalid ALB event: %w", err)
+			}
+			res, err := h(ctx, FromALB(event))
+			if res == nil {
+				return nil, err
+			}
+			return res.ALB(len(event.MultiValueHeaders) > 0), err
+
+		default:
+			var event events.APIGatewayProxyRequest
+			if err := json.Unmarshal(payload, &event); err != nil {
+				return nil, fmt.Errorf("gateway: invalid API Gateway event: %w", err)
+			}
+			res, err := h(ctx, FromAPIGateway(event))
+			if res == nil {
+				return nil, err
+			}
+			return res.APIGateway(), err
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package gateway
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Request is the HTTP request as seen by the router, the middlewares and the
+// controllers, whatever the trigger that delivered it. Header names keep the
+// casing of the trigger (HTTP APIs and ALBs lowercase them), so they must be
+// read with utils.GetHeader.
+type Request struct {
+	HTTPMethod            string
+	Path                  string
+	Headers               map[string]string
+	QueryStringParameters map[string]string
+	PathParameters        map[string]string
+	Body                  string
+	IsBase64Encoded       bool
+}
+
+// Response is written by handlers and converted back to the shape expected by
+// the trigger
+type Response struct {
+	StatusCode      int
+	Headers         map[string]string
+	Body            string
+	IsBase64Encoded bool
+}
+
+// Handler serves a Request; middlewares.LambdaHandlerFunc has this signature
+type Handler func(ctx context.Context, req Request) (*Response, error)
+
+// FromAPIGateway adapts a REST API (v1) proxy event
+func FromAPIGateway(event events.APIGatewayProxyRequest) Request {
+	return Request{
+		HTTPMethod:            event.HTTPMethod,
+		Path:                  event.Path,
+		Headers:               event.Headers,
+		QueryStringParameters: event.QueryStringParameters,
+		PathParameters:        event.PathParameters,
+		Body:                  event.Body,
+		IsBase64Encoded:       event.IsBase64Encoded,
+	}
+}
+
+// FromHTTPAPI adapts an HTTP API (v2) event. The raw path of a named stage
+// starts with the stage, which is removed so routes match as behind v1;
+// cookies come apart from the headers and are folded back into Cookie.
+func FromHTTPAPI(event events.APIGatewayV2HTTPRequest) Request {
+	path := event.RawPath
+	if stage := event.RequestContext.Stage; stage != "" && stage != "$default" {
+		if trimmed := strings.TrimPrefix(path, "/"+stage); trimmed != path && (trimmed == "" || trimmed[0] == '/') {
+			path = trimmed
+		}
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	headers := make(map[string]string, len(event.Headers)+1)
+	for k, v := range event.Headers {
+		headers[k] = v
+	}
+	if len(event.Cookies) > 0 {
+		headers["cookie"] = strings.Join(event.Cookies, "; ")
+	}
+
+	return Request{
+		HTTPMethod:            event.RequestContext.HTTP.Method,
+		Path:                  path,
+		Headers:               headers,
+		QueryStringParameters: event.QueryStringParameters,
+		PathParameters:        event.PathParameters,
+		Body:                  event.Body,
+		IsBase64Encoded:       event.IsBase64Encoded,
+	}
+}
+
+// FromALB adapts an ALB target group event, with or without multi-value
+// headers enabled. The ALB forwards the query string still URL-encoded, so it
+// is decoded here. As with v1, repeated headers and parameters keep the last
+// value.
+func FromALB(event events.ALBTargetGroupRequest) Request {
+	headers := event.Headers
+	if len(event.MultiValueHeaders) > 0 {
+		headers = lastValues(event.MultiValueHeaders)
+	}
+
+	query := event.QueryStringParameters
+	if len(event.MultiValueQueryStringParameters) > 0 {
+		query = lastValues(event.MultiValueQueryStringParameters)
+	}
+
+	var decoded map[string]string
+	if len(query) > 0 {
+		decoded = make(map[string]string, len(query))
+		for k, v := range query {
+			decoded[unescape(k)] = unescape(v)
+		}
+	}
+
+	return Request{
+		HTTPMethod:            event.HTTPMethod,
+		Path:                  event.Path,
+		Headers:               headers,
+		QueryStringParameters: decoded,
+		Body:                  event.Body,
+		IsBase64Encoded:       event.IsBase64Encoded,
+	}
+}
+
+func lastValues(values map[string][]string) map[string]string {
+	last := make(map[string]string, len(values))
+	for k, v := range values {
+		if len(v) > 0 {
+			last[k] = v[len(v)-1]
+		}
+	}
+	return last
+}
+
+func unescape(s string) string {
+	if decoded, err := url.QueryUnescape(s); err == nil {
+		return decoded
+	}
+	return s
+}
@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// APIGateway converts the response for a REST API (v1) trigger
+func (r *Response) APIGateway() *events.APIGatewayProxyResponse {
+	return &events.APIGatewayProxyResponse{
+		StatusCode:      r.StatusCode,
+		Headers:         r.Headers,
+		Body:            r.Body,
+		IsBase64Encoded: r.IsBase64Encoded,
+	}
+}
+
+// HTTPAPI converts the response for an HTTP API (v2) trigger
+func (r *Response) HTTPAPI() *events.APIGatewayV2HTTPResponse {
+	return &events.APIGatewayV2HTTPResponse{
+		StatusCode:      r.StatusCode,
+		Headers:         r.Headers,
+		Body:            r.Body,
+		IsBase64Encoded: r.IsBase64Encoded,
+	}
+}
+
+// ALB converts the response for an ALB trigger. A target group with
+// multi-value headers enabled ignores Headers, so multiValue must match the
+// request that was answered.
+func (r *Response) ALB(multiValue bool) *events.ALBTargetGroupResponse {
+	res := &events.ALBTargetGroupResponse{
+		StatusCode:        r.StatusCode,
+		StatusDescription: fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		Body:              r.Body,
+		IsBase64Encoded:   r.IsBase64Encoded,
+	}
+
+	if !multiValue {
+		res.Headers = r.Headers
+		return res
+	}
+	res.MultiValueHeaders = make(map[string][]string, len(r.Headers))
+	for k, v := range r.Headers {
+		res.MultiValueHeaders[k] = []string{v}
+	}
+	return res
+}
@@ -0,0 +1,224 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	catalogMu sync.RWMutex
+
+	// validationMessages are keyed by validator tag. A "tag.kind" key (kind is
+	// string, number or array) overrides the plain tag for that kind of field.
+	// {param} is replaced by the tag parameter, e.g. "10" in "max=10".
+	validationMessages = map[Language]map[string]string{
+		EN: {
+			"required":      "is required",
+			"notblank":      "must not be blank",
+			"uuid":          "must be a valid UUID",
+			"uuid4":         "must be a valid UUID v4",
+			"email":         "must be a valid email address",
+			"url":           "must be a valid URL",
+			"uri":           "must be a valid URI",
+			"numeric":       "must contain only numbers",
+			"alphanum":      "must contain only letters and numbers",
+			"datetime":      "must be a date with format {param}",
+			"oneof":         "must be one of: {param}",
+			"len.string":    "must be exactly {param} characters long",
+			"len.array":     "must contain exactly {param} items",
+			"len":           "must be equal to {param}",
+			"min.string":    "must be at least {param} characters long",
+			"min.array":     "must contain at least {param} items",
+			"min":           "must be greater than or equal to {param}",
+			"max.string":    "must be at most {param} characters long",
+			"max.array":     "must contain at most {param} items",
+			"max":           "must be less than or equal to {param}",
+			"gte":           "must be greater than or equal to {param}",
+			"lte":           "must be less than or equal to {param}",
+			"gt":            "must be greater than {param}",
+			"lt":            "must be less than {param}",
+			"boolean":       "must be true or false",
+			"required_with": "is required when {param} is present",
+			"timezone":      "must be an IANA time zone, e.g. America/Lima",
+			"default":       "is invalid ({tag})",
+		},
+		ES: {
+			"required":      "es obligatorio",
+			"notblank":      "no debe estar vacío",
+			"uuid":          "debe ser un UUID válido",
+			"uuid4":         "debe ser un UUID v4 válido",
+			"email":         "debe ser un correo electrónico válido",
+			"url":           "debe ser una URL válida",
+			"uri":           "debe ser una URI válida",
+			"numeric":       "debe contener solo números",
+			"alphanum":      "debe contener solo letras y números",
+			"datetime":      "debe ser una fecha con formato {param}",
+			"oneof":         "debe ser uno de: {param}",
+			"len.string":    "debe tener exactamente {param} caracteres",
+			"len.array":     "debe contener exactamente {param} elementos",
+			"len":           "debe ser igual a {param}",
+			"min.string":    "debe tener como mínimo {param} caracteres",
+			"min.array":     "debe contener como mínimo {param} elementos",
+			"min":           "debe ser mayor o igual a {param}",
+			"max.string":    "debe tener como máximo {param} caracteres",
+			"max.array":     "debe contener como máximo {param} elementos",
+			"max":           "debe ser menor o igual a {param}",
+			"gte":           "debe ser mayor o igual a {param}",
+			"lte":           "debe ser menor o igual a {param}",
+			"gt":            "debe ser mayor a {param}",
+			"lt":            "debe ser menor a {param}",
+			"boolean":       "debe ser verdadero o falso",
+			"required_with": "es obligatorio cuando se envía {param}",
+			"timezone":      "debe ser una zona horaria IANA, p. ej. America/Lima",
+			"default":       "no es válido ({tag})",
+		},
+	}
+
+	// errorMessages translate the Message of errorCustom errors by error code.
+	// English is omitted: the message written in the code is already English.
+	errorMessages = map[Language]map[string]string{
+		ES: {
+			"ERR_INVALID_PAYLOAD":           "Los datos enviados no son válidos",
+			"ERR_INVALID_ID":                "ID inválido",
+			"ERR_CANDIDATE_NOT_FOUND":       "Candidato no encontrado",
+			"ERR_EMPTY_BODY":                "El cuerpo de la solicitud es obligatorio",
+			"ERR_INVALID_JSON":              "Formato JSON inválido",
+			"ERR_INVALID_JSON_TYPE":         "El campo {field} debe ser de tipo {expected}",
+			"ERR_UNKNOWN_FIELD":             "Campo desconocido: {field}",
+			"ERR_INVALID_BASE64":            "El cuerpo de la solicitud no es base64 válido",
+			"ERR_INVALID_FORM":              "El formulario enviado no es válido",
+			"ERR_UNSUPPORTED_CONTENT_TYPE":  "El Content-Type debe ser application/json",
+			"ERR_PAYLOAD_TOO_LARGE":         "El cuerpo de la solicitud excede el tamaño permitido",
+			"ERR_INVALID_SIGNATURE":         "Firma de la solicitud inválida",
+			"ERR_INVALID_VERIFY_TOKEN":      "Token de verificación inválido",
+			"ERR_UNSUPPORTED_WEBHOOK":       "Notificación de webhook no soportada",
+			"ERR_IDEMPOTENCY":               "No se pudo registrar el mensaje, vuelva a intentarlo",
+			"ERR_INTERNAL":                  "Error interno del servidor",
+			"ERR_SECRET_NOT_FOUND":          "Secreto no encontrado",
+			"SECRETS_ERROR":                 "No se pudo obtener el secreto",
+			"VALIDATION_ERROR":              "Error de validación",
+			"DATABASE_ERROR":                "Error al acceder a la base de datos",
+			"DATABASE_CONFLICT":             "El registro fue modificado o ya existe",
+			"DATABASE_TRANSACTION_CONFLICT": "Conflicto de escritura, vuelva a intentarlo",
+			"DATABASE_THROTTLED":            "Demasiadas solicitudes, vuelva a intentarlo en unos segundos",
+			"DATABASE_UNAVAILABLE":          "Base de datos no disponible temporalmente",
+			"ENCRYPTION_ERROR":              "No se pudo proteger o leer los datos del candidato",
+			"ERR_INVALID_SHEET_ID":          "ID de hoja inválido",
+			"ERR_SHEET_ROW_NOT_FOUND":       "Fila de la hoja no encontrada",
+			"SHEET_ERROR":                   "No se pudo actualizar la hoja",
+			"SHEET_THROTTLED":               "Demasiadas solicitudes a la hoja, vuelva a intentarlo en unos segundos",
+			"SHEET_UNAVAILABLE":             "Servicio de hojas no disponible temporalmente",
+			"ERR_OUTBOX_NOT_FOUND":          "Registro del outbox no encontrado",
+			"ERR_OUTBOX_NOT_FAILED":         "Solo se pueden reintentar registros del outbox fallidos",
+			"OUTBOX_ERROR":                  "No se pudo registrar el efecto secundario",
+			"OUTBOX_INVALID_PAYLOAD":        "El contenido del registro del outbox no es válido",
+			"OUTBOX_UNKNOWN_TYPE":           "Tipo de registro del outbox no soportado",
+			"ERR_INVALID_TIME_ZONE":         "Zona horaria inválida",
+			"ERR_INVALID_SLOT_TIMES":        "La hora de fin del horario debe ser posterior a la de inicio",
+			"ERR_INVALID_DATE_RANGE":        "La fecha final no puede ser anterior a la inicial",
+			"ERR_SLOT_NOT_FOUND":            "Horario de entrevista no encontrado",
+			"ERR_SLOT_IN_PAST":              "El horario de entrevista ya empezó",
+			"ERR_SLOT_FULL":                 "El horario de entrevista no tiene cupos disponibles",
+			"ERR_CANDIDATE_ALREADY_BOOKED":  "El candidato ya tiene una entrevista reservada",
+			"ERR_BOOKING_NOT_FOUND":         "El candidato no tiene una entrevista reservada",
+			"ERR_BOOKING_CHANGED":           "La reserva cambió, vuelva a intentarlo",
+			"ERR_CANDIDATE_BOOKED":          "La entrevista la gestiona la reserva del horario; cancélela para cambiarla",
+			"ERR_CANDIDATE_CHANGED":         "El candidato fue modificado, vuelva a intentarlo",
+			"ERR_TEMPLATE_NOT_FOUND":        "Plantilla de mensaje no encontrada",
+			"ERR_TEMPLATE_DATA_MISSING":     "Al candidato le faltan datos para la plantilla",
+			"ERR_TEMPLATE_RENDER":           "No se pudo generar el mensaje",
+			"ERR_CANDIDATE_WITHOUT_PHONE":   "El candidato no tiene celular",
+			"ERR_RECIPIENT_UNREACHABLE":     "El celular del candidato no puede recibir mensajes",
+			"ERR_MESSAGE_WINDOW_CLOSED":     "WhatsApp solo permite plantillas aprobadas pasadas 24 horas del último mensaje del candidato",
+			"ERR_MESSAGE_NOT_RECORDED":      "El mensaje se envió pero no se pudo registrar",
+			"MESSAGING_ERROR":               "No se pudo enviar el mensaje",
+			"MESSAGING_THROTTLED":           "Demasiados mensajes enviados, vuelva a intentarlo en unos segundos",
+			"MESSAGING_UNAVAILABLE":         "Servicio de mensajería no disponible temporalmente",
+			"ERR_MEETING_NOT_FOUND":         "Reunión no encontrada",
+			"MEETING_ERROR":                 "No se pudo crear la reunión de la entrevista",
+			"MEETING_UNAVAILABLE":           "Servicio de reuniones no disponible temporalmente",
+			"EVENTS_ERROR":                  "No se pudo preparar el evento",
+			"EVENTS_UNAVAILABLE":            "No se pudo publicar el evento, vuelva a intentarlo",
+			"ERR_SCHEDULE_EXISTS":           "La tarea programada ya existe",
+			"SCHEDULER_ERROR":               "No se pudo programar la tarea",
+			"SCHEDULER_THROTTLED":           "Demasiadas tareas programadas, vuelva a intentarlo en unos segundos",
+			"SCHEDULER_UNAVAILABLE":         "Servicio de tareas programadas no disponible temporalmente",
+			"DATABASE_TIMEOUT":              "La base de datos no respondió a tiempo",
+			"ROUTE_NOT_FOUND":               "Ruta no encontrada",
+			"METHOD_NOT_ALLOWED":            "Método no permitido",
+		},
+	}
+)
+
+// RegisterValidationMessages adds or overrides messages for validator tags,
+// typically next to validators.RegisterCustomValidator
+func RegisterValidationMessages(lang Language, messages map[string]string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if validationMessages[lang] == nil {
+		validationMessages[lang] = make(map[string]string)
+	}
+	for k, v := range messages {
+		validationMessages[lang][k] = v
+	}
+}
+
+// RegisterErrorMessages adds or overrides translations for error codes
+func RegisterErrorMessages(lang Language, messages map[string]string) {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+	if errorMessages[lang] == nil {
+		errorMessages[lang] = make(map[string]string)
+	}
+	for k, v := range messages {
+		errorMessages[lang][k] = v
+	}
+}
+
+// ValidationMessage renders the message of a failed validator tag. kind is
+// "string", "number", "array" or empty.
+func ValidationMessage(lang Language, tag, param, kind string) string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	messages := validationMessages[lang]
+	if messages == nil {
+		messages = validationMessages[DefaultLanguage]
+	}
+
+	template, ok := messages[tag+"."+kind]
+	if !ok {
+		template, ok = messages[tag]
+	}
+	if !ok {
+		template = messages["default"]
+	}
+
+	return interpolate(template, map[string]any{"param": param, "tag": tag})
+}
+
+// ErrorMessage translates the message of an error code. fallback is returned
+// when the code has no translation; vars fill {name} placeholders.
+func ErrorMessage(lang Language, code, fallback string, vars map[string]any) string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	template, ok := errorMessages[lang][code]
+	if !ok {
+		return fallback
+	}
+	return interpolate(template, vars)
+}
+
+func interpolate(template string, vars map[string]any) string {
+	if len(vars) == 0 || !strings.Contains(template, "{") {
+		return template
+	}
+
+	pairs := make([]string, 0, len(vars)*2)
+	for k, v := range vars {
+		pairs = append(pairs, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	ES Language = "es"
+	EN Language = "en"
+)
+
+// DefaultLanguage is used when Accept-Language names no supported language.
+// Messages written in the code base are English, so this keeps responses
+// unchanged for clients that do not ask for a language.
+const DefaultLanguage = EN
+
+var supported = map[Language]bool{ES: true, EN: true}
+
+// ParseAcceptLanguage picks the supported language with the highest quality
+// in an Accept-Language header, e.g. "es-PE,es;q=0.9,en;q=0.8" -> es
+func ParseAcceptLanguage(header string) Language {
+	type candidate struct {
+		lang    Language
+		quality float64
+	}
+
+	candidates := make([]candidate, 0)
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if ok && key == "q" {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if lang := Language(base); supported[lang] && quality > 0 {
+			candidates = append(candidates, candidate{lang: lang, quality: quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return DefaultLanguage
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].lang
+}
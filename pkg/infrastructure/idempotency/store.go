@@ -0,0 +1,59 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store records keys that must only be processed once within a time window.
+// Claim returns true the first time a key is seen and false while a previous
+// claim for the same key is still alive. Release drops a claim so that a
+// delivery whose processing failed can be retried.
+type Store interface {
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, key string) error
+}
+
+// MemoryStore is a process-local Store. It only de-duplicates within a single
+// Lambda container, so it is meant for local runs and tests.
+type MemoryStore struct {
+	mu   sync.Mutex
+	keys map[string]time.Time
+	now  func() time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		keys: make(map[string]time.Time),
+		now:  time.Now,
+	}
+}
+
+func (s *MemoryStore) Claim(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for k, expiresAt := range s.keys {
+		if !expiresAt.After(now) {
+			delete(s.keys, k)
+		}
+	}
+
+	if _, exists := s.keys[key]; exists {
+		return false, nil
+	}
+
+	s.keys[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (s *MemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.keys, key)
+	return nil
+}
+
+var _ Store = (*MemoryStore)(nil)
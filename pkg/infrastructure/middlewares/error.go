@@ -6,42 +6,85 @@ import (
 
 	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
 	"github.com/Yolto7/api-candidates/pkg/domain/logger"
-	"github.com/aws/aws-lambda-go/events"
+	"github.com/Yolto7/api-candidates/pkg/domain/route"
+	"github.com/Yolto7/api-candidates/pkg/domain/trace"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
 )
 
+type ErrorMiddlewareConfig struct {
+	// DefaultFormat applies when neither the route nor the Accept header
+	// choose a format. Defaults to the {success, message, code} envelope.
+	DefaultFormat ErrorFormat
+	// ProblemTypeBaseURL prefixes the "type" of problem documents. When
+	// empty the type is "about:blank".
+	ProblemTypeBaseURL string
+}
+
 func ErrorMiddleware(log logger.Logger) Middleware {
+	return ErrorMiddlewareWithConfig(log, ErrorMiddlewareConfig{})
+}
+
+func ErrorMiddlewareWithConfig(log logger.Logger, cfg ErrorMiddlewareConfig) Middleware {
+	if cfg.DefaultFormat == "" {
+		cfg.DefaultFormat = ErrorFormatEnvelope
+	}
+
 	return func(next LambdaHandlerFunc) LambdaHandlerFunc {
-		return func(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-			resp, err := next(ctx, event)
+		return func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+			preference := &errorFormatPreference{}
+			resp, err := next(withErrorFormatPreference(ctx, preference), event)
 			if err == nil {
 				return resp, nil
 			}
 
-			log.Error(map[string]any{
-				"error": err,
-			})
-			
+			// Cause and details stay in the logs, RenderError never serializes them
 			appErr := errorCustom.FromError(err)
-			payload := map[string]any{
-				"success": false,
-				"message": appErr.Message,
-				"code":    appErr.ErrorCode,
+			info, _ := route.GetInfo(ctx)
+			fields := map[string]any{
+				"msg":       "Request failed",
+				"traceId":   trace.GetTraceID(ctx),
+				"route":     info.Pattern,
+				"status":    appErr.HttpCode,
+				"errorType": appErr.ErrorType,
+				"code":      appErr.ErrorCode,
+				"error":     err.Error(),
 			}
-
-			if appErr.Payload != nil {
-				payload["payload"] = appErr.Payload
+			if appErr.Details != nil {
+				fields["details"] = appErr.Details
 			}
+			log.Error(fields)
 
-			body, _ := json.Marshal(payload)
-			return &events.APIGatewayProxyResponse{
-				StatusCode:      appErr.HttpCode,
-				IsBase64Encoded: false,
-				Body:            string(body),
-				Headers: map[string]string{
-					"Content-Type":                "application/json",
-					"Access-Control-Allow-Origin": "*",
-				},
-			}, nil
+			appErr = LocalizeError(event, appErr)
+			switch negotiateErrorFormat(event, preference.format, cfg.DefaultFormat) {
+			case ErrorFormatProblem:
+				return RenderProblem(appErr, trace.GetTraceID(ctx), cfg.ProblemTypeBaseURL), nil
+			default:
+				return RenderError(appErr), nil
+			}
 		}
 	}
 }
+
+// RenderError builds the JSON error envelope shared by the middlewares and the router
+func RenderError(appErr *errorCustom.AppError) *gateway.Response {
+	payload := map[string]any{
+		"success": false,
+		"message": appErr.Message,
+		"code":    appErr.ErrorCode,
+	}
+
+	if appErr.Payload != nil {
+		payload["payload"] = appErr.Payload
+	}
+
+	body, _ := json.Marshal(payload)
+	return &gateway.Response{
+		StatusCode:      appErr.HttpCode,
+		IsBase64Encoded: false,
+		Body:            string(body),
+		Headers: map[string]string{
+			"Content-Type":                "application/json",
+			"Access-Control-Allow-Origin": "*",
+		},
+	}
+}
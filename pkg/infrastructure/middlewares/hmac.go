@@ -0,0 +1,120 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/idempotency"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+const defaultReplayWindow = 5 * time.Minute
+
+var clientIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+// HMACSecretLookup resolves the shared secret of a machine client
+type HMACSecretLookup func(ctx context.Context, clientID string) (string, error)
+
+type HMACAuthConfig struct {
+	SecretLookup HMACSecretLookup
+	Nonces       idempotency.Store
+	ReplayWindow time.Duration
+	Now          func() time.Time
+}
+
+// HMACAuthMiddleware authenticates machine callers that cannot obtain a user
+// JWT. Callers sign the request with the shared secret of their client ID:
+//
+//	signature = hex(HMAC-SHA256(secret, timestamp + "\n" + nonce + "\n" + METHOD + "\n" + path + "\n" + rawBody))
+//
+// and send it with the x-client-id, x-timestamp (unix seconds), x-nonce and
+// x-signature headers. Requests outside the replay window or reusing a nonce
+// are rejected.
+func HMACAuthMiddleware(log logger.Logger, cfg HMACAuthConfig) Middleware {
+	if cfg.ReplayWindow <= 0 {
+		cfg.ReplayWindow = defaultReplayWindow
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return func(next LambdaHandlerFunc) LambdaHandlerFunc {
+		return func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+			clientID := utils.GetHeader(event.Headers, constants.HEADER_CLIENT_ID)
+			timestamp := utils.GetHeader(event.Headers, constants.HEADER_TIMESTAMP)
+			nonce := utils.GetHeader(event.Headers, constants.HEADER_NONCE)
+			signature := utils.GetHeader(event.Headers, constants.HEADER_SIGNATURE)
+
+			if !clientIDPattern.MatchString(clientID) || timestamp == "" || nonce == "" || signature == "" {
+				return nil, unauthorized("Missing or malformed signature headers")
+			}
+
+			sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+			if err != nil {
+				return nil, unauthorized("Invalid signature timestamp")
+			}
+			skew := cfg.Now().Sub(time.Unix(sentAt, 0))
+			if skew > cfg.ReplayWindow || skew < -cfg.ReplayWindow {
+				return nil, unauthorized("Signature timestamp outside the allowed window")
+			}
+
+			secret, err := cfg.SecretLookup(ctx, clientID)
+			if err != nil || secret == "" {
+				log.Warn(map[string]any{
+					"msg":      "HMAC secret lookup failed",
+					"clientId": clientID,
+					"error":    err,
+				})
+				return nil, unauthorized("Invalid signature")
+			}
+
+			body := []byte(event.Body)
+			if event.IsBase64Encoded {
+				if body, err = base64.StdEncoding.DecodeString(event.Body); err != nil {
+					return nil, unauthorized("Invalid signature")
+				}
+			}
+
+			expected := signHMAC(secret, timestamp, nonce, event.HTTPMethod, event.Path, body)
+			provided, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+			if err != nil || !hmac.Equal(expected, provided) {
+				return nil, unauthorized("Invalid signature")
+			}
+
+			// The nonce is only consumed once the signature is valid, so that
+			// unauthenticated callers cannot burn nonces of legitimate clients.
+			fresh, err := cfg.Nonces.Claim(ctx, "nonce#"+clientID+"#"+nonce, 2*cfg.ReplayWindow)
+			if err != nil {
+				log.Error(utils.NewSafeError(err, "Error in HMACAuthMiddleware: Failed to claim nonce"))
+				return nil, unauthorized("Unable to verify request nonce")
+			}
+			if !fresh {
+				return nil, unauthorized("Request nonce already used")
+			}
+
+			return next(ctx, event)
+		}
+	}
+}
+
+func signHMAC(secret, timestamp, nonce, method, path string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + nonce + "\n" + strings.ToUpper(method) + "\n" + path + "\n"))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
+
+func unauthorized(message string) error {
+	return errorCustom.NewError(errorCustom.UNAUTHORIZED, message, "ERR_INVALID_SIGNATURE")
+}
@@ -0,0 +1,62 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// HubSecretLookup resolves the app secret used by the provider to sign
+// webhook notifications
+type HubSecretLookup func(ctx context.Context) (string, error)
+
+// HubSignatureMiddleware verifies the x-hub-signature-256 header sent by
+// Meta webhooks (WhatsApp Cloud API):
+//
+//	x-hub-signature-256: sha256=hex(HMAC-SHA256(appSecret, rawBody))
+//
+// Unlike HMACAuthMiddleware there is no timestamp or nonce, so replays must
+// be handled by the handler, e.g. by de-duplicating on message ID.
+func HubSignatureMiddleware(log logger.Logger, lookup HubSecretLookup) Middleware {
+	return func(next LambdaHandlerFunc) LambdaHandlerFunc {
+		return func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+			signature := utils.GetHeader(event.Headers, constants.HEADER_HUB_SIGNATURE)
+			if !strings.HasPrefix(signature, "sha256=") {
+				return nil, unauthorized("Missing or malformed signature header")
+			}
+
+			secret, err := lookup(ctx)
+			if err != nil || secret == "" {
+				log.Warn(map[string]any{
+					"msg":   "Hub signature secret lookup failed",
+					"error": err,
+				})
+				return nil, unauthorized("Invalid signature")
+			}
+
+			body := []byte(event.Body)
+			if event.IsBase64Encoded {
+				if body, err = base64.StdEncoding.DecodeString(event.Body); err != nil {
+					return nil, unauthorized("Invalid signature")
+				}
+			}
+
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write(body)
+			provided, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+			if err != nil || !hmac.Equal(mac.Sum(nil), provided) {
+				return nil, unauthorized("Invalid signature")
+			}
+
+			return next(ctx, event)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package middlewares
+
+import (
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/i18n"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/validators"
+)
+
+// LocalizeError returns a copy of the error with its message and field
+// errors translated to the language of the Accept-Language header
+func LocalizeError(event gateway.Request, appErr *errorCustom.AppError) *errorCustom.AppError {
+	lang := i18n.ParseAcceptLanguage(utils.GetHeader(event.Headers, "Accept-Language"))
+
+	localized := *appErr
+	vars, _ := appErr.Payload.(map[string]any)
+	localized.Message = i18n.ErrorMessage(lang, appErr.ErrorCode, appErr.Message, vars)
+
+	if issues, ok := appErr.Payload.([]validators.FieldErrorDetail); ok {
+		translated := make([]validators.FieldErrorDetail, len(issues))
+		for i, issue := range issues {
+			translated[i] = issue.Localize(lang)
+		}
+		localized.Payload = translated
+	}
+
+	return &localized
+}
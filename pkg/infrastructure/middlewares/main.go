@@ -2,12 +2,15 @@ package middlewares
 
 import (
 	"context"
+	"sort"
+	"strings"
 
 	"github.com/Yolto7/api-candidates/pkg/domain/logger"
-	"github.com/aws/aws-lambda-go/events"
+	"github.com/Yolto7/api-candidates/pkg/domain/route"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
 )
 
-type LambdaHandlerFunc func(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error)
+type LambdaHandlerFunc func(ctx context.Context, event gateway.Request) (*gateway.Response, error)
 
 type Middleware func(LambdaHandlerFunc) LambdaHandlerFunc
 
@@ -18,18 +21,52 @@ func ChainMiddlewares(handler LambdaHandlerFunc, middlewares ...Middleware) Lamb
 	return handler
 }
 
+// loggableHeaders are the only request headers written to the logs; the
+// others may carry credentials or webhook signatures
+var loggableHeaders = map[string]bool{
+	"content-type": true,
+	"user-agent":   true,
+	"x-request-id": true,
+	"x-trace-id":   true,
+}
+
+func loggedHeaders(headers map[string]string) map[string]string {
+	logged := make(map[string]string)
+	for key, value := range headers {
+		if loggableHeaders[strings.ToLower(key)] {
+			logged[strings.ToLower(key)] = value
+		}
+	}
+	return logged
+}
+
+// queryKeys returns the names of the query parameters, whose values may be
+// personal data such as the phone of FindByPhone
+func queryKeys(query map[string]string) []string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func BaseMiddleware(log logger.Logger) func(LambdaHandlerFunc) LambdaHandlerFunc {
 	return func(next LambdaHandlerFunc) LambdaHandlerFunc {
-		return func(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-			// Log completo del request
+		return func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+			// Log del request sin datos personales: el body y la query pueden
+			// traer DNI, teléfono o webhooks, y las cabeceras firmas o tokens
+			info, _ := route.GetInfo(ctx)
 			log.Info(map[string]any{
-				"msg":      "Incoming request",
-				"path":     event.Path,
-				"method":   event.HTTPMethod,
-				"headers":  event.Headers,
-				"query":    event.QueryStringParameters,
-				"pathVars": event.PathParameters,
-				"body":     event.Body,
+				"msg":       "Incoming request",
+				"path":      event.Path,
+				"method":    event.HTTPMethod,
+				"route":     info.Pattern,
+				"routeName": info.Name,
+				"headers":   loggedHeaders(event.Headers),
+				"query":     queryKeys(event.QueryStringParameters),
+				"pathVars":  event.PathParameters,
+				"bodyBytes": len(event.Body),
 			})
 
 			resp, err := next(ctx, event)
@@ -44,7 +81,9 @@ func BaseMiddleware(log logger.Logger) func(LambdaHandlerFunc) LambdaHandlerFunc
 
 			// CORS
 			resp.Headers["Access-Control-Allow-Origin"] = "*"
-			resp.Headers["Content-Type"] = "application/json"
+			if resp.Headers["Content-Type"] == "" {
+				resp.Headers["Content-Type"] = "application/json"
+			}
 
 			// Seguridad
 			resp.Headers["Strict-Transport-Security"] = "max-age=31536000; includeSubDomains; preload"
@@ -0,0 +1,57 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+)
+
+// capturingLogger keeps the entries logged at info level
+type capturingLogger struct {
+	infos []any
+}
+
+func (l *capturingLogger) Debug(any)       {}
+func (l *capturingLogger) Info(input any)  { l.infos = append(l.infos, input) }
+func (l *capturingLogger) Warn(any)        {}
+func (l *capturingLogger) Error(input any) {}
+
+func TestBaseMiddlewareLogsNoPersonalData(t *testing.T) {
+	log := &capturingLogger{}
+	handler := BaseMiddleware(log)(okHandler)
+
+	_, err := handler(context.Background(), gateway.Request{
+		HTTPMethod: http.MethodPost,
+		Path:       "/candidates",
+		Headers: map[string]string{
+			"Content-Type":        "application/json",
+			"Authorization":       "Bearer secret-token",
+			"X-Hub-Signature-256": "sha256=abcdef",
+			"x-twilio-signature":  "0/KCTR6DLpKmkAf8muzZqo1nDgQ=",
+		},
+		QueryStringParameters: map[string]string{"phone": "+51987654321"},
+		Body:                  `{"documentNumber":"45678912","phone":"987654321"}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logged, err := json.Marshal(log.infos)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"45678912", "987654321", "secret-token", "abcdef", "0/KCTR6D"} {
+		if strings.Contains(string(logged), secret) {
+			t.Errorf("logged %q: %s", secret, logged)
+		}
+	}
+	for _, kept := range []string{"application/json", `"phone"`, `"bodyBytes":49`} {
+		if !strings.Contains(string(logged), kept) {
+			t.Errorf("expected %s in the log: %s", kept, logged)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/validators"
+)
+
+const problemContentType = "application/problem+json"
+
+// ErrorFormat selects how ErrorMiddleware serializes errors
+type ErrorFormat string
+
+const (
+	ErrorFormatEnvelope ErrorFormat = "envelope"
+	ErrorFormatProblem  ErrorFormat = "problem"
+)
+
+// ProblemDetails is an RFC 7807 document. Code and Errors are extension
+// members: the stable error code and the invalid fields of a payload.
+type ProblemDetails struct {
+	Type     string                        `json:"type"`
+	Title    string                        `json:"title"`
+	Status   int                           `json:"status"`
+	Detail   string                        `json:"detail,omitempty"`
+	Instance string                        `json:"instance,omitempty"`
+	Code     string                        `json:"code,omitempty"`
+	Errors   []validators.FieldErrorDetail `json:"errors,omitempty"`
+	Payload  any                           `json:"payload,omitempty"`
+}
+
+type errorFormatPreferenceKey struct{}
+
+// errorFormatPreference is shared by pointer between ErrorMiddleware and the
+// inner route middlewares, which run later but must be able to choose
+type errorFormatPreference struct {
+	format ErrorFormat
+}
+
+func withErrorFormatPreference(ctx context.Context, preference *errorFormatPreference) context.Context {
+	return context.WithValue(ctx, errorFormatPreferenceKey{}, preference)
+}
+
+// PreferErrorFormat is a per-route middleware that selects the error format
+// of the route. An explicit Accept header still takes precedence.
+func PreferErrorFormat(format ErrorFormat) Middleware {
+	return func(next LambdaHandlerFunc) LambdaHandlerFunc {
+		return func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+			if preference, ok := ctx.Value(errorFormatPreferenceKey{}).(*errorFormatPreference); ok {
+				preference.format = format
+			}
+			return next(ctx, event)
+		}
+	}
+}
+
+func negotiateErrorFormat(event gateway.Request, routeFormat, defaultFormat ErrorFormat) ErrorFormat {
+	accept := strings.ToLower(utils.GetHeader(event.Headers, "Accept"))
+	switch {
+	case strings.Contains(accept, problemContentType):
+		return ErrorFormatProblem
+	case routeFormat != "":
+		return routeFormat
+	default:
+		return defaultFormat
+	}
+}
+
+// RenderProblem builds an application/problem+json response. The trace ID is
+// used as instance so clients can quote it when reporting issues.
+func RenderProblem(appErr *errorCustom.AppError, traceID, typeBaseURL string) *gateway.Response {
+	problem := ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(appErr.HttpCode),
+		Status:   appErr.HttpCode,
+		Detail:   appErr.Message,
+		Instance: traceID,
+		Code:     appErr.ErrorCode,
+	}
+	if typeBaseURL != "" && appErr.ErrorCode != "" {
+		problem.Type = strings.TrimSuffix(typeBaseURL, "/") + "/" + strings.ToLower(strings.ReplaceAll(appErr.ErrorCode, "_", "-"))
+	}
+
+	switch payload := appErr.Payload.(type) {
+	case nil:
+	case []validators.FieldErrorDetail:
+		problem.Errors = payload
+	default:
+		problem.Payload = payload
+	}
+
+	body, _ := json.Marshal(problem)
+	return &gateway.Response{
+		StatusCode: appErr.HttpCode,
+		Body:       string(body),
+		Headers: map[string]string{
+			"Content-Type":                problemContentType,
+			"Access-Control-Allow-Origin": "*",
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/logger"
+	"github.com/Yolto7/api-candidates/pkg/domain/route"
+	"github.com/Yolto7/api-candidates/pkg/domain/trace"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// RecoveryMiddleware turns a panic in any inner handler into an INTERNAL
+// error so the invocation does not crash and API Gateway does not answer an
+// opaque 502. It must run inside ErrorMiddleware, which renders the error in
+// the format of the request and with the problem type base URL, and inside
+// BaseMiddleware, which adds the security and CORS headers.
+func RecoveryMiddleware(log logger.Logger) Middleware {
+	return func(next LambdaHandlerFunc) LambdaHandlerFunc {
+		return func(ctx context.Context, event gateway.Request) (resp *gateway.Response, err error) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+
+				panicErr, ok := recovered.(error)
+				if !ok {
+					panicErr = fmt.Errorf("%v", recovered)
+				}
+
+				safeErr := utils.NewSafeError(panicErr, "Recovered from panic")
+				info, _ := route.GetInfo(ctx)
+				log.Error(map[string]any{
+					"msg":     safeErr.Message,
+					"traceId": trace.GetTraceID(ctx),
+					"route":   info.Pattern,
+					"method":  event.HTTPMethod,
+					"error":   panicErr.Error(),
+					"stack":   safeErr.Stack,
+				})
+
+				resp = nil
+				err = errorCustom.NewError(errorCustom.INTERNAL, "Internal server error", "ERR_INTERNAL").WithCause(panicErr)
+			}()
+
+			return next(ctx, event)
+		}
+	}
+}
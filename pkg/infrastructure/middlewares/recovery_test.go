@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+)
+
+func TestRecoveryRendersThroughErrorMiddleware(t *testing.T) {
+	log := pkgILogger.NewZeroLogLogger()
+	handler := ChainMiddlewares(func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+		panic("boom")
+	},
+		TraceMiddleware(log),
+		BaseMiddleware(log),
+		ErrorMiddlewareWithConfig(log, ErrorMiddlewareConfig{ProblemTypeBaseURL: "https://errors.example.com/"}),
+		RecoveryMiddleware(log),
+	)
+
+	tests := []struct {
+		name        string
+		accept      string
+		contentType string
+	}{
+		{"envelope", "", "application/json"},
+		{"problem", "application/problem+json", "application/problem+json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := handler(context.Background(), gateway.Request{HTTPMethod: http.MethodGet, Path: "/candidates", Headers: map[string]string{"Accept": tt.accept}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StatusCode != http.StatusInternalServerError {
+				t.Fatalf("status = %d, want 500", res.StatusCode)
+			}
+			if got := res.Headers["Content-Type"]; got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			for _, header := range []string{"Strict-Transport-Security", "X-Content-Type-Options", "Access-Control-Allow-Origin"} {
+				if res.Headers[header] == "" {
+					t.Errorf("missing %s header", header)
+				}
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal([]byte(res.Body), &body); err != nil {
+				t.Fatal(err)
+			}
+			if tt.name == "problem" && body["type"] != "https://errors.example.com/err-internal" {
+				t.Errorf("type = %v, want the configured base URL", body["type"])
+			}
+			if tt.name == "envelope" && body["code"] != "ERR_INTERNAL" {
+				t.Errorf("code = %v, want ERR_INTERNAL", body["code"])
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package middlewares
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	pkgILogger "github.com/Yolto7/api-candidates/pkg/infrastructure/logger"
+)
+
+func okHandler(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	return &gateway.Response{StatusCode: 200}, nil
+}
+
+func assertSignature(t *testing.T, handler LambdaHandlerFunc, event gateway.Request, valid bool) {
+	t.Helper()
+	res, err := handler(context.Background(), event)
+	if valid {
+		if err != nil || res == nil || res.StatusCode != 200 {
+			t.Fatalf("expected the request to pass, got %v", err)
+		}
+		return
+	}
+	var customErr *errorCustom.CustomError
+	if !errors.As(err, &customErr) || customErr.ErrorCode != "ERR_INVALID_SIGNATURE" {
+		t.Fatalf("expected ERR_INVALID_SIGNATURE, got %v", err)
+	}
+}
+
+func TestTwilioSignatureMiddleware(t *testing.T) {
+	// Example from the Twilio webhook security docs
+	const (
+		token       = "12345"
+		callbackURL = "https://mycompany.com/myapp.php?foo=1&bar=2"
+		body        = "CallSid=CA1234567890ABCDE&Caller=%2B12349013030&Digits=1234&From=%2B12349013030&To=%2B18005551212"
+		signature   = "0/KCTR6DLpKmkAf8muzZqo1nDgQ="
+	)
+	lookup := func(token string, err error) TwilioTokenLookup {
+		return func(ctx context.Context) (string, error) { return token, err }
+	}
+
+	tests := []struct {
+		name   string
+		lookup TwilioTokenLookup
+		event  gateway.Request
+		valid  bool
+	}{
+		{"valid", lookup(token, nil), gateway.Request{Headers: map[string]string{"X-Twilio-Signature": signature}, Body: body}, true},
+		{"valid base64 body", lookup(token, nil), gateway.Request{Headers: map[string]string{"x-twilio-signature": signature}, Body: base64.StdEncoding.EncodeToString([]byte(body)), IsBase64Encoded: true}, true},
+		{"missing header", lookup(token, nil), gateway.Request{Body: body}, false},
+		{"tampered body", lookup(token, nil), gateway.Request{Headers: map[string]string{"x-twilio-signature": signature}, Body: body + "&Extra=1"}, false},
+		{"wrong token", lookup("54321", nil), gateway.Request{Headers: map[string]string{"x-twilio-signature": signature}, Body: body}, false},
+		{"token lookup fails", lookup("", errors.New("unavailable")), gateway.Request{Headers: map[string]string{"x-twilio-signature": signature}, Body: body}, false},
+		{"malformed signature", lookup(token, nil), gateway.Request{Headers: map[string]string{"x-twilio-signature": "not base64!"}, Body: body}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := TwilioSignatureMiddleware(pkgILogger.NewZeroLogLogger(), callbackURL, tt.lookup)(okHandler)
+			assertSignature(t, handler, tt.event, tt.valid)
+		})
+	}
+}
+
+func TestHubSignatureMiddleware(t *testing.T) {
+	const (
+		secret = "app-secret"
+		body   = `{"object":"whatsapp_business_account","entry":[]}`
+	)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	lookup := func(secret string, err error) HubSecretLookup {
+		return func(ctx context.Context) (string, error) { return secret, err }
+	}
+
+	tests := []struct {
+		name   string
+		lookup HubSecretLookup
+		event  gateway.Request
+		valid  bool
+	}{
+		{"valid", lookup(secret, nil), gateway.Request{Headers: map[string]string{"X-Hub-Signature-256": signature}, Body: body}, true},
+		{"valid base64 body", lookup(secret, nil), gateway.Request{Headers: map[string]string{"x-hub-signature-256": signature}, Body: base64.StdEncoding.EncodeToString([]byte(body)), IsBase64Encoded: true}, true},
+		{"missing header", lookup(secret, nil), gateway.Request{Body: body}, false},
+		{"missing prefix", lookup(secret, nil), gateway.Request{Headers: map[string]string{"x-hub-signature-256": signature[len("sha256="):]}, Body: body}, false},
+		{"tampered body", lookup(secret, nil), gateway.Request{Headers: map[string]string{"x-hub-signature-256": signature}, Body: body + " "}, false},
+		{"wrong secret", lookup("other-secret", nil), gateway.Request{Headers: map[string]string{"x-hub-signature-256": signature}, Body: body}, false},
+		{"secret lookup fails", lookup("", errors.New("unavailable")), gateway.Request{Headers: map[string]string{"x-hub-signature-256": signature}, Body: body}, false},
+		{"malformed signature", lookup(secret, nil), gateway.Request{Headers: map[string]string{"x-hub-signature-256": "sha256=zz"}, Body: body}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := HubSignatureMiddleware(pkgILogger.NewZeroLogLogger(), tt.lookup)(okHandler)
+			assertSignature(t, handler, tt.event, tt.valid)
+		})
+	}
+}
@@ -8,13 +8,13 @@ import (
 	"github.com/Yolto7/api-candidates/pkg/domain/constants"
 	"github.com/Yolto7/api-candidates/pkg/domain/logger"
 	"github.com/Yolto7/api-candidates/pkg/domain/trace"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
 	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
-	"github.com/aws/aws-lambda-go/events"
 )
 
 func TraceMiddleware(log logger.Logger) Middleware {
 	return func(next LambdaHandlerFunc) LambdaHandlerFunc {
-		return func(ctx context.Context, event events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+		return func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
 			traceID := extractOrGenerateTraceID(event.Headers)
 
 			log.Info(fmt.Sprintf("TraceID: %s", traceID))
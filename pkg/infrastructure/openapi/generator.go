@@ -0,0 +1,264 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/router"
+)
+
+const version = "3.1.0"
+
+// Generate builds the OpenAPI document for the registered routes. Every
+// success body is wrapped in the {success, message, data} envelope returned
+// by response.Success and every error in the ErrorMiddleware envelope.
+func Generate(info Info, routes []router.Route, servers ...Server) *Document {
+	registry := newSchemaRegistry()
+	doc := &Document{
+		OpenAPI: version,
+		Info:    info,
+		Servers: servers,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas:   registry.schemas,
+			Responses: standardResponses(),
+		},
+	}
+	registry.schemas["SuccessEnvelope"] = successEnvelope()
+	registry.schemas["ErrorEnvelope"] = errorEnvelope()
+	registry.schemas["ProblemDetails"] = problemDetails()
+
+	for _, rt := range routes {
+		docs := rt.Docs
+		if docs == nil {
+			docs = &router.Docs{}
+		}
+		if docs.Hidden {
+			continue
+		}
+
+		path, pathParams := openAPIPath(rt.Pattern)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = make(PathItem)
+			doc.Paths[path] = item
+		}
+
+		op := &Operation{
+			OperationID: operationID(rt),
+			Summary:     docs.Summary,
+			Description: docs.Description,
+			Tags:        docs.Tags,
+			Parameters:  parameters(registry, pathParams, docs.Params),
+			Responses:   make(map[string]*Response),
+		}
+
+		if docs.Body != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content: map[string]MediaType{
+					"application/json": {Schema: registry.schemaFor(reflect.TypeOf(docs.Body))},
+				},
+			}
+		}
+
+		status := docs.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		op.Responses[strconv.Itoa(status)] = successResponse(registry, docs.Response)
+		for _, code := range errorStatuses(rt.Method, docs.Body != nil || docs.Params != nil || len(pathParams) > 0) {
+			op.Responses[code] = &Response{Ref: "#/components/responses/" + code}
+		}
+
+		item[strings.ToLower(rt.Method)] = op
+	}
+
+	return doc
+}
+
+// openAPIPath converts an API Gateway pattern ("candidates/{proxy+}") into an
+// OpenAPI path ("/candidates/{proxy}") and lists its parameters
+func openAPIPath(pattern string) (string, []string) {
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	params := make([]string, 0)
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name := strings.TrimSuffix(strings.Trim(segment, "{}"), "+")
+			segments[i] = "{" + name + "}"
+			params = append(params, name)
+		}
+	}
+	return "/" + strings.Join(segments, "/"), params
+}
+
+// parameters documents path parameters and, for the remaining fields of the
+// Params DTO, query parameters
+func parameters(registry *schemaRegistry, pathParams []string, dto any) []Parameter {
+	props := map[string]*Schema{}
+	required := map[string]bool{}
+	var order []string
+
+	if dto != nil {
+		t := reflect.TypeOf(dto)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			s := registry.structSchema(t)
+			props = s.Properties
+			for _, name := range s.Required {
+				required[name] = true
+			}
+			for i := 0; i < t.NumField(); i++ {
+				if name, ok := jsonName(t.Field(i)); ok && name != "" {
+					order = append(order, name)
+				}
+			}
+		}
+	}
+
+	params := make([]Parameter, 0, len(pathParams)+len(order))
+	inPath := make(map[string]bool, len(pathParams))
+	for _, name := range pathParams {
+		inPath[name] = true
+		schema, ok := props[name]
+		if !ok {
+			schema = &Schema{Type: "string"}
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	for _, name := range order {
+		if inPath[name] {
+			continue
+		}
+		params = append(params, Parameter{Name: name, In: "query", Required: required[name], Schema: props[name]})
+	}
+
+	return params
+}
+
+func successResponse(registry *schemaRegistry, payload any) *Response {
+	schema := &Schema{Ref: "#/components/schemas/SuccessEnvelope"}
+	if payload != nil {
+		schema = &Schema{AllOf: []*Schema{
+			schema,
+			{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"data": registry.schemaFor(reflect.TypeOf(payload)),
+				},
+			},
+		}}
+	}
+
+	return &Response{
+		Description: "Successful response",
+		Content: map[string]MediaType{
+			"application/json": {Schema: schema},
+		},
+	}
+}
+
+func errorStatuses(method string, hasInput bool) []string {
+	codes := []string{"500"}
+	if hasInput {
+		codes = append(codes, "400")
+	}
+	if method != http.MethodPost {
+		codes = append(codes, "404")
+	}
+	return codes
+}
+
+func standardResponses() map[string]*Response {
+	responses := make(map[string]*Response)
+	for _, status := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError} {
+		responses[strconv.Itoa(status)] = &Response{
+			Description: http.StatusText(status),
+			Content: map[string]MediaType{
+				"application/json":         {Schema: &Schema{Ref: "#/components/schemas/ErrorEnvelope"}},
+				"application/problem+json": {Schema: &Schema{Ref: "#/components/schemas/ProblemDetails"}},
+			},
+		}
+	}
+	return responses
+}
+
+func successEnvelope() *Schema {
+	return &Schema{
+		Type:     "object",
+		Required: []string{"success", "message", "data"},
+		Properties: map[string]*Schema{
+			"success": {Type: "boolean", Const: true},
+			"message": {Type: "string"},
+			"data":    {},
+		},
+	}
+}
+
+func errorEnvelope() *Schema {
+	return &Schema{
+		Type:     "object",
+		Required: []string{"success", "message", "code"},
+		Properties: map[string]*Schema{
+			"success": {Type: "boolean", Const: false},
+			"message": {Type: "string"},
+			"code":    {Type: "string"},
+			"payload": {
+				Description: "Optional error details, e.g. the list of invalid fields",
+			},
+		},
+	}
+}
+
+// problemDetails is the RFC 7807 alternative, returned when the client sends
+// Accept: application/problem+json or the route prefers it
+func problemDetails() *Schema {
+	return &Schema{
+		Type:     "object",
+		Required: []string{"type", "title", "status"},
+		Properties: map[string]*Schema{
+			"type":     {Type: "string", Format: "uri-reference"},
+			"title":    {Type: "string"},
+			"status":   {Type: "integer"},
+			"detail":   {Type: "string"},
+			"instance": {Type: "string", Description: "Trace ID of the request"},
+			"code":     {Type: "string"},
+			"errors": {
+				Type: "array",
+				Items: &Schema{
+					Type:     "object",
+					Required: []string{"key", "message"},
+					Properties: map[string]*Schema{
+						"key":     {Type: "string"},
+						"message": {Type: "string"},
+					},
+				},
+			},
+			"payload": {},
+		},
+	}
+}
+
+func operationID(rt router.Route) string {
+	if rt.Name != "" {
+		return rt.Name
+	}
+
+	var b strings.Builder
+	b.WriteString(strings.ToLower(rt.Method))
+	for _, segment := range strings.Split(rt.Pattern, "/") {
+		segment = strings.Trim(segment, "{}+")
+		if segment == "" {
+			continue
+		}
+		runes := []rune(segment)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/middlewares"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/router"
+)
+
+// Handler serves the document of the router. The document is generated on
+// the first request so that routes registered after the handler are included.
+func Handler(r *router.Router, info Info, servers ...Server) middlewares.LambdaHandlerFunc {
+	var (
+		once sync.Once
+		body []byte
+		err  error
+	)
+
+	return func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+		once.Do(func() {
+			body, err = json.Marshal(Generate(info, r.Routes(), servers...))
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &gateway.Response{
+			StatusCode: http.StatusOK,
+			Headers: map[string]string{
+				"Content-Type":  "application/json",
+				"Cache-Control": "public, max-age=300",
+			},
+			Body: string(body),
+		}, nil
+	}
+}
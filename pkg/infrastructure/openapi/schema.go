@@ -0,0 +1,314 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TagMapper applies a validator tag (and its parameter, e.g. "10" for
+// "max=10") to the schema of the field that carries it
+type TagMapper func(schema *Schema, param string)
+
+var (
+	tagMappersMu sync.RWMutex
+	tagMappers   = map[string]TagMapper{
+		"notblank": func(s *Schema, _ string) {
+			s.MinLength = intPtr(1)
+			s.Pattern = `\S`
+		},
+		"uuid":     format("uuid"),
+		"uuid4":    format("uuid"),
+		"email":    format("email"),
+		"url":      format("uri"),
+		"uri":      format("uri"),
+		"datetime": format("date-time"),
+		"numeric": func(s *Schema, _ string) {
+			s.Pattern = `^-?[0-9]+(\.[0-9]+)?$`
+		},
+		"alphanum": func(s *Schema, _ string) {
+			s.Pattern = `^[A-Za-z0-9]+$`
+		},
+		"dni":             pattern(`^[0-9]{8}$`),
+		"ce":              pattern(`^[A-Za-z0-9]{9,12}$`),
+		"pe_phone":        pattern(`^(\+?51)?\s?9[0-9\s-]{8,10}$`),
+		"smartsheet_id":   pattern(`^[0-9]{1,19}$`),
+		"lima_date":       format("date"),
+		"hhmm":            pattern(`^([01][0-9]|2[0-3]):[0-5][0-9]$`),
+		"future_datetime": format("date-time"),
+		"oneof": func(s *Schema, param string) {
+			for _, value := range strings.Fields(param) {
+				s.Enum = append(s.Enum, value)
+			}
+		},
+		"len": func(s *Schema, param string) {
+			bound(s, param, true)
+			bound(s, param, false)
+		},
+		"min": func(s *Schema, param string) { bound(s, param, true) },
+		"gte": func(s *Schema, param string) { bound(s, param, true) },
+		"max": func(s *Schema, param string) { bound(s, param, false) },
+		"lte": func(s *Schema, param string) { bound(s, param, false) },
+		"gt": func(s *Schema, param string) {
+			if v, err := strconv.ParseFloat(param, 64); err == nil && isNumeric(s) {
+				s.ExclusiveMinimum = &v
+			}
+		},
+		"lt": func(s *Schema, param string) {
+			if v, err := strconv.ParseFloat(param, 64); err == nil && isNumeric(s) {
+				s.ExclusiveMaximum = &v
+			}
+		},
+	}
+)
+
+// RegisterTagMapper documents a custom validator tag. It should be called
+// alongside validators.RegisterCustomValidator so the contract stays in sync.
+func RegisterTagMapper(tag string, fn TagMapper) {
+	tagMappersMu.Lock()
+	defer tagMappersMu.Unlock()
+	tagMappers[tag] = fn
+}
+
+func lookupTagMapper(tag string) (TagMapper, bool) {
+	tagMappersMu.RLock()
+	defer tagMappersMu.RUnlock()
+	fn, ok := tagMappers[tag]
+	return fn, ok
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaRegistry turns Go types into component schemas, reusing a $ref for
+// every named struct so that DTOs appear once in the document.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: make(map[string]*Schema)}
+}
+
+func (r *schemaRegistry) schemaFor(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+
+	var s *Schema
+	switch {
+	case t == timeType:
+		s = &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		r.define(t)
+		ref := &Schema{Ref: "#/components/schemas/" + t.Name()}
+		if nullable {
+			return &Schema{AllOf: []*Schema{ref}, Description: "nullable"}
+		}
+		return ref
+	case t.Kind() == reflect.Struct:
+		s = r.structSchema(t)
+	case t.Kind() == reflect.String:
+		s = &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		s = &Schema{Type: "boolean"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		s = &Schema{Type: "integer"}
+		if t.Kind() == reflect.Int64 || t.Kind() == reflect.Uint64 {
+			s.Format = "int64"
+		}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		s = &Schema{Type: "number"}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			s = &Schema{Type: "string", Format: "byte"}
+		} else {
+			s = &Schema{Type: "array", Items: r.schemaFor(t.Elem())}
+		}
+	case t.Kind() == reflect.Map:
+		s = &Schema{Type: "object", AdditionalProperties: r.schemaFor(t.Elem())}
+	default:
+		s = &Schema{}
+	}
+
+	if nullable && s.Type != nil {
+		s.Type = []string{s.Type.(string), "null"}
+	}
+	return s
+}
+
+func (r *schemaRegistry) define(t reflect.Type) {
+	if _, exists := r.schemas[t.Name()]; exists {
+		return
+	}
+	// Reserve the name first so recursive types terminate
+	r.schemas[t.Name()] = &Schema{Type: "object"}
+	r.schemas[t.Name()] = r.structSchema(t)
+}
+
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+
+		// Embedded structs are flattened like encoding/json does
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := r.structSchema(embedded)
+				for k, v := range inner.Properties {
+					s.Properties[k] = v
+				}
+				s.Required = append(s.Required, inner.Required...)
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop := r.schemaFor(field.Type)
+		if required := applyValidateTag(prop, field.Tag.Get("validate")); required {
+			s.Required = append(s.Required, name)
+		}
+		if desc := field.Tag.Get("description"); desc != "" {
+			prop.Description = desc
+		}
+		s.Properties[name] = prop
+	}
+
+	return s
+}
+
+// applyValidateTag maps validator rules to schema keywords and reports
+// whether the field is required
+func applyValidateTag(s *Schema, tag string) bool {
+	if tag == "" || tag == "-" {
+		return false
+	}
+
+	required := false
+	optional := false
+	target := s
+	for _, rule := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required", "notblank":
+			if target == s && !optional {
+				required = true
+			}
+		case "dive":
+			if target.Items == nil {
+				return required
+			}
+			target = target.Items
+			continue
+		case "omitempty", "omitnil":
+			if target == s {
+				optional = true
+			}
+			continue
+		}
+
+		if target.Ref != "" {
+			continue
+		}
+		if fn, ok := lookupTagMapper(name); ok {
+			fn(target, param)
+		}
+	}
+
+	return required
+}
+
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, true
+}
+
+func format(f string) TagMapper {
+	return func(s *Schema, _ string) {
+		s.Format = f
+	}
+}
+
+func pattern(p string) TagMapper {
+	return func(s *Schema, _ string) {
+		s.Pattern = p
+	}
+}
+
+func bound(s *Schema, param string, lower bool) {
+	switch baseType(s) {
+	case "string":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		if lower {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case "array":
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return
+		}
+		if lower {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	case "integer", "number":
+		v, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		if lower {
+			s.Minimum = &v
+		} else {
+			s.Maximum = &v
+		}
+	}
+}
+
+func isNumeric(s *Schema) bool {
+	t := baseType(s)
+	return t == "integer" || t == "number"
+}
+
+func baseType(s *Schema) string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []string:
+		if len(t) > 0 {
+			return t[0]
+		}
+	}
+	return ""
+}
+
+func intPtr(v int) *int {
+	return &v
+}
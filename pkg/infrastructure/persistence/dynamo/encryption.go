@@ -0,0 +1,127 @@
+package dynamo
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/crypto"
+)
+
+// ItemEncryptor applies the pii tags of an entity to its DynamoDB item:
+// tagged attributes are encrypted with the envelope and blind indexes are
+// written next to them. The ciphertext of each attribute is bound to the
+// item key and the attribute name.
+type ItemEncryptor struct {
+	envelope *crypto.Envelope
+	index    *crypto.BlindIndex
+}
+
+func NewItemEncryptor(envelope *crypto.Envelope, index *crypto.BlindIndex) *ItemEncryptor {
+	return &ItemEncryptor{
+		envelope: envelope,
+		index:    index,
+	}
+}
+
+// EncryptItem protects the tagged attributes of a marshaled item in place
+func (e *ItemEncryptor) EncryptItem(ctx context.Context, item map[string]types.AttributeValue, fields map[string]crypto.Field, itemKey string) error {
+	for attribute, field := range fields {
+		av, ok := item[attribute].(*types.AttributeValueMemberS)
+		if !ok {
+			continue
+		}
+
+		protected, index, err := e.protect(ctx, field, av.Value, itemKey)
+		if err != nil {
+			return err
+		}
+		item[attribute] = &types.AttributeValueMemberS{Value: protected}
+		if index != "" {
+			item[field.BlindIndex] = &types.AttributeValueMemberS{Value: index}
+		}
+	}
+	return nil
+}
+
+// EncryptUpdates returns a copy of an update map with the tagged attributes
+// protected; the map of the caller keeps the plaintext values
+func (e *ItemEncryptor) EncryptUpdates(ctx context.Context, updates map[string]interface{}, fields map[string]crypto.Field, itemKey string) (map[string]interface{}, error) {
+	protectedUpdates := make(map[string]interface{}, len(updates))
+	for attribute, value := range updates {
+		protectedUpdates[attribute] = value
+	}
+
+	for attribute, field := range fields {
+		var value string
+		switch v := updates[attribute].(type) {
+		case string:
+			value = v
+		case *string:
+			if v == nil {
+				continue
+			}
+			value = *v
+		default:
+			continue
+		}
+
+		protected, index, err := e.protect(ctx, field, value, itemKey)
+		if err != nil {
+			return nil, err
+		}
+		protectedUpdates[attribute] = protected
+		if index != "" {
+			protectedUpdates[field.BlindIndex] = index
+		}
+	}
+	return protectedUpdates, nil
+}
+
+// DecryptItem reverts EncryptItem. Hashed attributes cannot be reverted and
+// are left as they are.
+func (e *ItemEncryptor) DecryptItem(ctx context.Context, item map[string]types.AttributeValue, fields map[string]crypto.Field, itemKey string) error {
+	for attribute, field := range fields {
+		av, ok := item[attribute].(*types.AttributeValueMemberS)
+		if !ok || !field.Encrypt {
+			continue
+		}
+
+		plaintext, err := e.envelope.Decrypt(ctx, av.Value, aad(itemKey, attribute))
+		if err != nil {
+			return err
+		}
+		item[attribute] = &types.AttributeValueMemberS{Value: plaintext}
+	}
+	return nil
+}
+
+// BlindIndex returns the digest to query an index written by EncryptItem
+func (e *ItemEncryptor) BlindIndex(index, value string) string {
+	return e.index.Compute(index, value)
+}
+
+func (e *ItemEncryptor) protect(ctx context.Context, field crypto.Field, value, itemKey string) (string, string, error) {
+	if value == "" || crypto.IsCiphertext(value) {
+		return value, "", nil
+	}
+
+	var index string
+	if field.BlindIndex != "" {
+		index = e.index.Compute(field.BlindIndex, value)
+	}
+
+	switch {
+	case field.Hash:
+		return e.index.Compute(field.Attribute, value), index, nil
+	case field.Encrypt:
+		ciphertext, err := e.envelope.Encrypt(ctx, value, aad(itemKey, field.Attribute))
+		return ciphertext, index, err
+	default:
+		return value, index, nil
+	}
+}
+
+func aad(itemKey, attribute string) string {
+	return itemKey + "#" + attribute
+}
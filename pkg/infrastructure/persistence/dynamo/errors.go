@@ -0,0 +1,82 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+)
+
+// ClassifyError maps a DynamoDB (or any AWS SDK) failure to the error
+// taxonomy so callers get 409/429/503/504 instead of a generic 400. The
+// message is the client-facing text; the SDK error is kept as the cause for
+// logging and is never exposed.
+func ClassifyError(err error, message string) *errorCustom.CustomError {
+	return classify(err, message).WithCause(err)
+}
+
+// ConditionFailed reports whether err is a cancelled transaction whose item
+// at index failed its condition
+func ConditionFailed(err error, index int) bool {
+	var canceledErr *types.TransactionCanceledException
+	if !errors.As(err, &canceledErr) || index >= len(canceledErr.CancellationReasons) {
+		return false
+	}
+	reason := canceledErr.CancellationReasons[index]
+	return reason.Code != nil && *reason.Code == "ConditionalCheckFailed"
+}
+
+func classify(err error, message string) *errorCustom.CustomError {
+	var (
+		conditionalErr *types.ConditionalCheckFailedException
+		canceledErr    *types.TransactionCanceledException
+		netErr         net.Error
+		maxAttemptsErr *retry.MaxAttemptsError
+		responseErr    *awshttp.ResponseError
+		apiErr         smithy.APIError
+	)
+
+	switch {
+	case errors.As(err, &conditionalErr):
+		return errorCustom.NewError(errorCustom.CONFLICT, message, "DATABASE_CONFLICT")
+	case errors.As(err, &canceledErr):
+		for _, reason := range canceledErr.CancellationReasons {
+			if reason.Code != nil && *reason.Code == "ConditionalCheckFailed" {
+				return errorCustom.NewError(errorCustom.CONFLICT, message, "DATABASE_CONFLICT")
+			}
+		}
+		return errorCustom.NewError(errorCustom.CONFLICT, message, "DATABASE_TRANSACTION_CONFLICT")
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, context.Canceled):
+		return errorCustom.NewError(errorCustom.TIMEOUT, message, "DATABASE_TIMEOUT")
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return errorCustom.NewError(errorCustom.TIMEOUT, message, "DATABASE_TIMEOUT")
+	}
+
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "TransactionConflictException", "TransactionInProgressException":
+			return errorCustom.NewError(errorCustom.CONFLICT, message, "DATABASE_TRANSACTION_CONFLICT")
+		case "ProvisionedThroughputExceededException", "RequestLimitExceeded", "ThrottlingException", "Throttling", "TooManyRequestsException":
+			return errorCustom.NewError(errorCustom.TOO_MANY_REQUESTS, message, "DATABASE_THROTTLED")
+		case "InternalServerError", "ServiceUnavailable", "ServiceUnavailableException":
+			return errorCustom.NewError(errorCustom.SERVICE_UNAVAILABLE, message, "DATABASE_UNAVAILABLE")
+		}
+	}
+
+	// Retries exhausted on a retryable error, or a 5xx without a known code
+	if errors.As(err, &maxAttemptsErr) {
+		return errorCustom.NewError(errorCustom.SERVICE_UNAVAILABLE, message, "DATABASE_UNAVAILABLE")
+	}
+	if errors.As(err, &responseErr) && responseErr.HTTPStatusCode() >= http.StatusInternalServerError {
+		return errorCustom.NewError(errorCustom.SERVICE_UNAVAILABLE, message, "DATABASE_UNAVAILABLE")
+	}
+
+	return errorCustom.NewError(errorCustom.INTERNAL, message, "DATABASE_ERROR")
+}
@@ -0,0 +1,181 @@
+package request
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"strings"
+
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// DefaultMaxBodyBytes caps decoded request bodies (1 MiB)
+const DefaultMaxBodyBytes = 1 << 20
+
+type BindOptions struct {
+	MaxBodyBytes       int
+	AllowUnknownFields bool
+}
+
+// SyntaxErrorDetail locates a JSON syntax error in the request body
+type SyntaxErrorDetail struct {
+	Offset int64 `json:"offset"`
+	Line   int   `json:"line"`
+	Column int   `json:"column"`
+}
+
+// BindJSON decodes the body of the event into dst. It requires an
+// application/json content type, decodes base64 bodies, enforces a size
+// limit and rejects unknown fields unless opts allow them.
+func BindJSON(event gateway.Request, dst any, opts ...BindOptions) error {
+	opt := BindOptions{MaxBodyBytes: DefaultMaxBodyBytes}
+	if len(opts) > 0 {
+		opt = opts[0]
+		if opt.MaxBodyBytes <= 0 {
+			opt.MaxBodyBytes = DefaultMaxBodyBytes
+		}
+	}
+
+	if err := checkContentType(utils.GetHeader(event.Headers, "Content-Type")); err != nil {
+		return err
+	}
+
+	body, err := rawBody(event, opt.MaxBodyBytes)
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Request body is required", "ERR_EMPTY_BODY")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if !opt.AllowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(body, err)
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Request body must contain a single JSON value", "ERR_INVALID_JSON",
+			syntaxDetail(body, decoder.InputOffset()))
+	}
+
+	return nil
+}
+
+// BindForm parses an application/x-www-form-urlencoded body, as posted by
+// provider callbacks, with the same base64 handling and size limit as BindJSON
+func BindForm(event gateway.Request, opts ...BindOptions) (url.Values, error) {
+	limit := DefaultMaxBodyBytes
+	if len(opts) > 0 && opts[0].MaxBodyBytes > 0 {
+		limit = opts[0].MaxBodyBytes
+	}
+
+	mediaType, _, err := mime.ParseMediaType(utils.GetHeader(event.Headers, "Content-Type"))
+	if err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return nil, errorCustom.NewError(errorCustom.UNSUPPORTED_CONTENT, "Content-Type must be application/x-www-form-urlencoded", "ERR_UNSUPPORTED_CONTENT_TYPE")
+	}
+
+	body, err := rawBody(event, limit)
+	if err != nil {
+		return nil, err
+	}
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid form body", "ERR_INVALID_FORM")
+	}
+	return values, nil
+}
+
+func checkContentType(header string) error {
+	if header == "" {
+		return errorCustom.NewError(errorCustom.UNSUPPORTED_CONTENT, "Content-Type must be application/json", "ERR_UNSUPPORTED_CONTENT_TYPE")
+	}
+
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err != nil {
+		return errorCustom.NewError(errorCustom.UNSUPPORTED_CONTENT, "Content-Type must be application/json", "ERR_UNSUPPORTED_CONTENT_TYPE")
+	}
+	if mediaType != "application/json" && !(strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json")) {
+		return errorCustom.NewError(errorCustom.UNSUPPORTED_CONTENT, fmt.Sprintf("Content-Type %s is not supported, use application/json", mediaType), "ERR_UNSUPPORTED_CONTENT_TYPE")
+	}
+
+	return nil
+}
+
+// rawBody returns the body bytes, decoding base64 when the trigger flagged it
+func rawBody(event gateway.Request, limit int) ([]byte, error) {
+	tooLarge := errorCustom.NewError(errorCustom.PAYLOAD_TOO_LARGE, fmt.Sprintf("Request body exceeds %d bytes", limit), "ERR_PAYLOAD_TOO_LARGE")
+
+	if !event.IsBase64Encoded {
+		if len(event.Body) > limit {
+			return nil, tooLarge
+		}
+		return []byte(event.Body), nil
+	}
+
+	if base64.StdEncoding.DecodedLen(len(event.Body)) > limit+3 {
+		return nil, tooLarge
+	}
+	body, err := base64.StdEncoding.DecodeString(event.Body)
+	if err != nil {
+		return nil, errorCustom.NewError(errorCustom.BAD_REQUEST, "Request body is not valid base64", "ERR_INVALID_BASE64")
+	}
+	if len(body) > limit {
+		return nil, tooLarge
+	}
+	return body, nil
+}
+
+func decodeError(body []byte, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid JSON format", "ERR_INVALID_JSON",
+			syntaxDetail(body, syntaxErr.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid JSON format: unexpected end of input", "ERR_INVALID_JSON",
+			syntaxDetail(body, int64(len(body))))
+	case errors.As(err, &typeErr):
+		return errorCustom.NewError(errorCustom.BAD_REQUEST, fmt.Sprintf("Field %s must be of type %s", typeErr.Field, typeErr.Type), "ERR_INVALID_JSON_TYPE",
+			map[string]any{
+				"field":    typeErr.Field,
+				"expected": typeErr.Type.String(),
+				"received": typeErr.Value,
+			})
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return errorCustom.NewError(errorCustom.BAD_REQUEST, fmt.Sprintf("Unknown field %s", field), "ERR_UNKNOWN_FIELD",
+			map[string]any{"field": field})
+	default:
+		return errorCustom.NewError(errorCustom.BAD_REQUEST, "Invalid JSON format", "ERR_INVALID_JSON")
+	}
+}
+
+func syntaxDetail(body []byte, offset int64) SyntaxErrorDetail {
+	if offset > int64(len(body)) {
+		offset = int64(len(body))
+	}
+
+	line, column := 1, 1
+	for _, b := range body[:offset] {
+		if b == '\n' {
+			line++
+			column = 1
+			continue
+		}
+		column++
+	}
+
+	return SyntaxErrorDetail{Offset: offset, Line: line, Column: column}
+}
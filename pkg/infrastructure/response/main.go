@@ -3,17 +3,17 @@ package response
 import (
 	"encoding/json"
 
-	"github.com/aws/aws-lambda-go/events"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
 )
 
-func Success(status int, message string, data interface{}) (*events.APIGatewayProxyResponse, error) {
+func Success(status int, message string, data interface{}) (*gateway.Response, error) {
 	res := map[string]interface{}{
 		"success": true,
 		"message": message,
 		"data":    data,
 	}
 	body, _ := json.Marshal(res)
-	return &events.APIGatewayProxyResponse{
+	return &gateway.Response{
 		StatusCode: status,
 		Body:       string(body),
 	}, nil
@@ -0,0 +1,150 @@
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/middlewares"
+)
+
+// Route is a registered endpoint. It is exposed read-only through
+// Router.Routes so that documentation can be generated from the table.
+type Route struct {
+	Method      string
+	Pattern     string
+	Name        string
+	Docs        *Docs
+	handler     middlewares.LambdaHandlerFunc
+	middlewares []middlewares.Middleware
+}
+
+// RouteOption customizes a single route at registration time
+type RouteOption func(*Route)
+
+// Name sets a unique, stable name for the route (e.g. "candidates.getById")
+func Name(name string) RouteOption {
+	return func(r *Route) {
+		r.Name = name
+	}
+}
+
+// Docs describes a route for the generated OpenAPI contract. Params, Body
+// and Response are zero values of the DTOs; their json and validate tags are
+// turned into schemas.
+type Docs struct {
+	Summary     string
+	Description string
+	Tags        []string
+	Params      any
+	Body        any
+	Response    any
+	Status      int
+	Hidden      bool
+}
+
+// Doc attaches OpenAPI documentation to the route
+func Doc(docs Docs) RouteOption {
+	return func(r *Route) {
+		r.Docs = &docs
+	}
+}
+
+// With appends middlewares that only apply to this route. They run after the
+// router and group middlewares. Nil middlewares are skipped, so optional ones
+// can be passed unconditionally.
+func With(mws ...middlewares.Middleware) RouteOption {
+	return func(r *Route) {
+		for _, mw := range mws {
+			if mw != nil {
+				r.middlewares = append(r.middlewares, mw)
+			}
+		}
+	}
+}
+
+// Group registers routes under a common prefix and middleware stack. Nested
+// groups inherit both, which keeps versioning ("v1", "v2") out of handlers.
+type Group struct {
+	router      *Router
+	prefix      string
+	middlewares []middlewares.Middleware
+}
+
+// Group creates a top-level group. Router.Use middlewares must be registered
+// before any group route, since chains are compiled on registration.
+func (r *Router) Group(prefix string, mws ...middlewares.Middleware) *Group {
+	return &Group{
+		router:      r,
+		prefix:      joinPath("", prefix),
+		middlewares: append([]middlewares.Middleware{}, mws...),
+	}
+}
+
+// Group creates a nested group that inherits the parent prefix and middlewares
+func (g *Group) Group(prefix string, mws ...middlewares.Middleware) *Group {
+	stack := make([]middlewares.Middleware, 0, len(g.middlewares)+len(mws))
+	stack = append(stack, g.middlewares...)
+	stack = append(stack, mws...)
+
+	return &Group{
+		router:      g.router,
+		prefix:      joinPath(g.prefix, prefix),
+		middlewares: stack,
+	}
+}
+
+// Use appends middlewares to the group for the routes registered afterwards
+func (g *Group) Use(mws ...middlewares.Middleware) {
+	g.middlewares = append(g.middlewares, mws...)
+}
+
+func (g *Group) GET(pattern string, handler middlewares.LambdaHandlerFunc, opts ...RouteOption) *Route {
+	return g.Handle(http.MethodGet, pattern, handler, opts...)
+}
+
+func (g *Group) POST(pattern string, handler middlewares.LambdaHandlerFunc, opts ...RouteOption) *Route {
+	return g.Handle(http.MethodPost, pattern, handler, opts...)
+}
+
+func (g *Group) PUT(pattern string, handler middlewares.LambdaHandlerFunc, opts ...RouteOption) *Route {
+	return g.Handle(http.MethodPut, pattern, handler, opts...)
+}
+
+func (g *Group) PATCH(pattern string, handler middlewares.LambdaHandlerFunc, opts ...RouteOption) *Route {
+	return g.Handle(http.MethodPatch, pattern, handler, opts...)
+}
+
+func (g *Group) DELETE(pattern string, handler middlewares.LambdaHandlerFunc, opts ...RouteOption) *Route {
+	return g.Handle(http.MethodDelete, pattern, handler, opts...)
+}
+
+// Handle registers a route relative to the group prefix
+func (g *Group) Handle(method, pattern string, handler middlewares.LambdaHandlerFunc, opts ...RouteOption) *Route {
+	route := &Route{
+		Method:  strings.ToUpper(method),
+		Pattern: joinPath(g.prefix, pattern),
+		handler: handler,
+	}
+	route.middlewares = append(route.middlewares, g.middlewares...)
+	for _, opt := range opts {
+		opt(route)
+	}
+
+	g.router.register(route)
+	return route
+}
+
+func joinPath(prefix, pattern string) string {
+	prefix = strings.Trim(prefix, "/")
+	pattern = strings.Trim(pattern, "/")
+
+	switch {
+	case prefix == "":
+		return pattern
+	case pattern == "":
+		return prefix
+	default:
+		return fmt.Sprintf("%s/%s", prefix, pattern)
+	}
+}
@@ -0,0 +1,294 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/domain/route"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/middlewares"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/utils"
+)
+
+// Router dispatches gateway requests, whatever their trigger, using a segment trie that is built
+// once at init. Matching precedence per segment is static > {param} >
+// {param+}, static segments are case-insensitive and a path whose routes
+// all lack the method yields 405 with an Allow header.
+type Router struct {
+	root        *node
+	middlewares []middlewares.Middleware
+	routes      []*Route
+	names       map[string]struct{}
+}
+
+type node struct {
+	static map[string]*node
+
+	param     *node
+	paramName string
+
+	greedy     *node
+	greedyName string
+
+	handlers map[string]middlewares.LambdaHandlerFunc
+}
+
+func New() *Router {
+	return &Router{
+		root:  newNode(),
+		names: make(map[string]struct{}),
+	}
+}
+
+func newNode() *node {
+	return &node{static: make(map[string]*node)}
+}
+
+// Use appends middlewares that wrap every route registered afterwards
+func (r *Router) Use(mws ...middlewares.Middleware) {
+	r.middlewares = append(r.middlewares, mws...)
+}
+
+// Handle registers a handler for the method and pattern outside any group
+func (r *Router) Handle(method, pattern string, handler middlewares.LambdaHandlerFunc, opts ...RouteOption) *Route {
+	route := &Route{
+		Method:  strings.ToUpper(method),
+		Pattern: joinPath("", pattern),
+		handler: handler,
+	}
+	for _, opt := range opts {
+		opt(route)
+	}
+
+	r.register(route)
+	return route
+}
+
+// Routes returns the registered routes in registration order
+func (r *Router) Routes() []Route {
+	routes := make([]Route, 0, len(r.routes))
+	for _, rt := range r.routes {
+		routes = append(routes, *rt)
+	}
+	return routes
+}
+
+// register compiles the middleware chain of the route and inserts it in the
+// trie. Patterns use API Gateway syntax, e.g. "candidates/{id}" or
+// "files/{proxy+}". It panics on conflicting registrations since routes are
+// only declared at init.
+func (r *Router) register(rt *Route) {
+	pattern := rt.Pattern
+	current := r.root
+
+	if rt.Name != "" {
+		if _, exists := r.names[rt.Name]; exists {
+			panic(fmt.Sprintf("router: duplicate route name %q", rt.Name))
+		}
+		r.names[rt.Name] = struct{}{}
+	}
+
+	segments := splitPath(pattern)
+	for i, segment := range segments {
+		switch {
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "+}"):
+			if i != len(segments)-1 {
+				panic(fmt.Sprintf("router: greedy parameter must be the last segment in %q", pattern))
+			}
+			name := strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "+}")
+			if current.greedy == nil {
+				current.greedy = newNode()
+				current.greedyName = name
+			} else if current.greedyName != name {
+				panic(fmt.Sprintf("router: conflicting parameter {%s+} in %q", name, pattern))
+			}
+			current = current.greedy
+		case strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}"):
+			name := strings.Trim(segment, "{}")
+			if current.param == nil {
+				current.param = newNode()
+				current.paramName = name
+			} else if current.paramName != name {
+				panic(fmt.Sprintf("router: conflicting parameter {%s} in %q, already registered as {%s}", name, pattern, current.paramName))
+			}
+			current = current.param
+		default:
+			key := strings.ToLower(segment)
+			child, ok := current.static[key]
+			if !ok {
+				child = newNode()
+				current.static[key] = child
+			}
+			current = child
+		}
+	}
+
+	if current.handlers == nil {
+		current.handlers = make(map[string]middlewares.LambdaHandlerFunc)
+	}
+	if _, exists := current.handlers[rt.Method]; exists {
+		panic(fmt.Sprintf("router: duplicate route %s %s", rt.Method, pattern))
+	}
+
+	stack := make([]middlewares.Middleware, 0, len(r.middlewares)+len(rt.middlewares))
+	stack = append(stack, r.middlewares...)
+	stack = append(stack, rt.middlewares...)
+
+	chain := middlewares.ChainMiddlewares(rt.handler, stack...)
+	info := route.Info{Method: rt.Method, Pattern: pattern, Name: rt.Name}
+	current.handlers[rt.Method] = func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+		return chain(route.SetInfo(ctx, info), event)
+	}
+
+	r.routes = append(r.routes, rt)
+}
+
+// Serve resolves the route for the event and invokes its handler
+func (r *Router) Serve(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	segments := splitPath(event.Path)
+	method := strings.ToUpper(event.HTTPMethod)
+	params := make(map[string]string)
+
+	matched := r.root.match(segments, method, params)
+	if matched == nil {
+		routed := r.root.collect(segments, nil)
+		if len(routed) == 0 {
+			return notFound(event), nil
+		}
+		allowed := allowedMethods(routed)
+		if method == http.MethodOptions {
+			return preflight(event, allowed), nil
+		}
+		return methodNotAllowed(event, allowed), nil
+	}
+
+	if len(params) > 0 {
+		if event.PathParameters == nil {
+			event.PathParameters = make(map[string]string, len(params))
+		}
+		for k, v := range params {
+			event.PathParameters[k] = v
+		}
+	}
+
+	return matched.handlers[method](ctx, event)
+}
+
+// match walks the trie in precedence order, backtracking whenever a branch
+// cannot serve the path with method, so that the most specific route owning
+// both wins: DELETE /candidates/search reaches DELETE /candidates/{id} even
+// if GET /candidates/search exists.
+func (n *node) match(segments []string, method string, params map[string]string) *node {
+	if len(segments) == 0 {
+		if n.handlers[method] != nil {
+			return n
+		}
+		return nil
+	}
+
+	head, rest := segments[0], segments[1:]
+
+	if child, ok := n.static[strings.ToLower(head)]; ok {
+		if found := child.match(rest, method, params); found != nil {
+			return found
+		}
+	}
+
+	if n.param != nil {
+		if found := n.param.match(rest, method, params); found != nil {
+			params[n.paramName] = head
+			return found
+		}
+	}
+
+	if n.greedy != nil && n.greedy.handlers[method] != nil {
+		params[n.greedyName] = strings.Join(segments, "/")
+		return n.greedy
+	}
+
+	return nil
+}
+
+// collect appends the nodes with a route for the path, whatever the method,
+// so a 405 lists the methods of every branch
+func (n *node) collect(segments []string, found []*node) []*node {
+	if len(segments) == 0 {
+		if len(n.handlers) > 0 {
+			found = append(found, n)
+		}
+		return found
+	}
+
+	head, rest := segments[0], segments[1:]
+
+	if child, ok := n.static[strings.ToLower(head)]; ok {
+		found = child.collect(rest, found)
+	}
+	if n.param != nil {
+		found = n.param.collect(rest, found)
+	}
+	if n.greedy != nil && len(n.greedy.handlers) > 0 {
+		found = append(found, n.greedy)
+	}
+	return found
+}
+
+func allowedMethods(nodes []*node) []string {
+	seen := map[string]struct{}{http.MethodOptions: {}}
+	methods := []string{http.MethodOptions}
+	for _, n := range nodes {
+		for method := range n.handlers {
+			if _, ok := seen[method]; !ok {
+				seen[method] = struct{}{}
+				methods = append(methods, method)
+			}
+		}
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+func splitPath(path string) []string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	segments := parts[:0]
+	for _, part := range parts {
+		if part != "" {
+			segments = append(segments, part)
+		}
+	}
+	return segments
+}
+
+func preflight(event gateway.Request, allowed []string) *gateway.Response {
+	allowHeaders := utils.GetHeader(event.Headers, "Access-Control-Request-Headers")
+	if allowHeaders == "" {
+		allowHeaders = "Content-Type, Authorization, X-Trace-Id, X-Client-Id, X-Timestamp, X-Nonce, X-Signature"
+	}
+
+	return &gateway.Response{
+		StatusCode: http.StatusNoContent,
+		Headers: map[string]string{
+			"Access-Control-Allow-Origin":  "*",
+			"Access-Control-Allow-Methods": strings.Join(allowed, ", "),
+			"Access-Control-Allow-Headers": allowHeaders,
+			"Access-Control-Max-Age":       "600",
+			"Allow":                        strings.Join(allowed, ", "),
+		},
+	}
+}
+
+func notFound(event gateway.Request) *gateway.Response {
+	appErr := errorCustom.New(errorCustom.NOT_FOUND, "Route not found", "ROUTE_NOT_FOUND")
+	return middlewares.RenderError(middlewares.LocalizeError(event, appErr))
+}
+
+func methodNotAllowed(event gateway.Request, allowed []string) *gateway.Response {
+	appErr := errorCustom.New(errorCustom.NOT_ALLOWED, "Method not allowed", "METHOD_NOT_ALLOWED")
+	res := middlewares.RenderError(middlewares.LocalizeError(event, appErr))
+	res.Headers["Allow"] = strings.Join(allowed, ", ")
+	return res
+}
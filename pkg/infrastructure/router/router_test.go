@@ -0,0 +1,70 @@
+package router
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/gateway"
+)
+
+func named(name string) func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+	return func(ctx context.Context, event gateway.Request) (*gateway.Response, error) {
+		body := name
+		for key, value := range event.PathParameters {
+			body += " " + key + "=" + value
+		}
+		return &gateway.Response{StatusCode: http.StatusOK, Body: body, Headers: map[string]string{}}, nil
+	}
+}
+
+func TestServe(t *testing.T) {
+	r := New()
+	r.Handle(http.MethodGet, "candidates/search", named("search"))
+	r.Handle(http.MethodGet, "candidates/{id}", named("get"))
+	r.Handle(http.MethodDelete, "candidates/{id}", named("delete"))
+	r.Handle(http.MethodPost, "candidates/{id}/slots/book", named("book"))
+	r.Handle(http.MethodGet, "files/{proxy+}", named("files"))
+	r.Handle(http.MethodGet, "files/readme", named("readme"))
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+		body   string
+		allow  string
+	}{
+		{http.MethodGet, "/candidates/search", http.StatusOK, "search", ""},
+		{http.MethodGet, "/Candidates/SEARCH", http.StatusOK, "search", ""},
+		{http.MethodGet, "/candidates/c1", http.StatusOK, "get id=c1", ""},
+		// The static branch lacks DELETE, so the param branch serves it
+		{http.MethodDelete, "/candidates/search", http.StatusOK, "delete id=search", ""},
+		{http.MethodPost, "/candidates/search/slots/book", http.StatusOK, "book id=search", ""},
+		{http.MethodGet, "/files/readme", http.StatusOK, "readme", ""},
+		{http.MethodGet, "/files/a/b", http.StatusOK, "files proxy=a/b", ""},
+		// No branch has the method: 405 with the methods of every branch
+		{http.MethodPut, "/candidates/search", http.StatusMethodNotAllowed, "", "DELETE, GET, OPTIONS"},
+		{http.MethodPost, "/files/readme", http.StatusMethodNotAllowed, "", "GET, OPTIONS"},
+		{http.MethodOptions, "/candidates/search", http.StatusNoContent, "", "DELETE, GET, OPTIONS"},
+		{http.MethodGet, "/candidates", http.StatusNotFound, "", ""},
+		{http.MethodGet, "/candidates/c1/slots", http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			res, err := r.Serve(context.Background(), gateway.Request{HTTPMethod: tt.method, Path: tt.path})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", res.StatusCode, tt.status)
+			}
+			if tt.body != "" && res.Body != tt.body {
+				t.Errorf("body = %q, want %q", res.Body, tt.body)
+			}
+			if allow := res.Headers["Allow"]; allow != tt.allow {
+				t.Errorf("Allow = %q, want %q", allow, tt.allow)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package schedules
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/scheduler"
+)
+
+var (
+	instance *scheduler.Client
+	once     sync.Once
+)
+
+func GetClient(ctx context.Context) (*scheduler.Client, error) {
+	var err error
+	once.Do(func() {
+		cfg, cfgErr := config.LoadDefaultConfig(ctx)
+		if cfgErr != nil {
+			err = fmt.Errorf("failed to load config for EventBridge Scheduler: %w", cfgErr)
+			return
+		}
+
+		instance = scheduler.NewFromConfig(cfg)
+	})
+
+	return instance, err
+}
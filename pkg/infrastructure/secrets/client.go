@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+var (
+	instance *secretsmanager.Client
+	once     sync.Once
+)
+
+func GetClient(ctx context.Context) (*secretsmanager.Client, error) {
+	var err error
+	once.Do(func() {
+		cfg, cfgErr := config.LoadDefaultConfig(ctx)
+		if cfgErr != nil {
+			err = fmt.Errorf("failed to load config for SecretsManager: %w", cfgErr)
+			return
+		}
+
+		instance = secretsmanager.NewFromConfig(cfg)
+	})
+
+	return instance, err
+}
@@ -0,0 +1,85 @@
+package templates
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var (
+	weekdays = [...]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"}
+	months   = [...]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}
+)
+
+// Engine renders named text templates. Templates are parsed once, when the
+// engine is built, and rendered with missingkey=error, so a variable that
+// is misspelled fails instead of printing "<no value>".
+//
+// Besides the text/template builtins, templates can call:
+//
+//	longDate "2006-01-02"  -> "lunes 2 de noviembre"
+//	upper, lower, trim     -> strings.ToUpper, ToLower, TrimSpace
+type Engine struct {
+	templates map[string]*template.Template
+}
+
+// New parses sources, keyed by template name
+func New(sources map[string]string) (*Engine, error) {
+	engine := &Engine{templates: make(map[string]*template.Template, len(sources))}
+	for name, source := range sources {
+		tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(source)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+		engine.templates[name] = tmpl
+	}
+	return engine, nil
+}
+
+// Must panics if err is not nil. It is meant for templates built into the
+// binary, parsed at init.
+func Must(engine *Engine, err error) *Engine {
+	if err != nil {
+		panic(err)
+	}
+	return engine
+}
+
+// Has reports whether a template is defined
+func (e *Engine) Has(name string) bool {
+	_, ok := e.templates[name]
+	return ok
+}
+
+// Render executes the template name with data. data is usually a
+// map[string]any holding every variable the template may use, empty or not.
+func (e *Engine) Render(name string, data any) (string, error) {
+	tmpl, ok := e.templates[name]
+	if !ok {
+		return "", fmt.Errorf("template %s is not defined", name)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+var funcs = template.FuncMap{
+	"longDate": LongDate,
+	"upper":    strings.ToUpper,
+	"lower":    strings.ToLower,
+	"trim":     strings.TrimSpace,
+}
+
+// LongDate writes a 2006-01-02 date in Spanish, as "lunes 2 de noviembre".
+// Other values are returned unchanged.
+func LongDate(date string) string {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return fmt.Sprintf("%s %d de %s", weekdays[day.Weekday()], day.Day(), months[day.Month()-1])
+}
@@ -0,0 +1,18 @@
+package utils
+
+import "strings"
+
+// GetHeader returns the value of a header using a case-insensitive lookup.
+// API Gateway does not normalize header names, so callers should never index
+// the headers map directly.
+func GetHeader(headers map[string]string, key string) string {
+	if val, ok := headers[key]; ok {
+		return val
+	}
+	for k, val := range headers {
+		if strings.EqualFold(k, key) {
+			return val
+		}
+	}
+	return ""
+}
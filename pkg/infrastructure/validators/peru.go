@@ -0,0 +1,173 @@
+package validators
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/Yolto7/api-candidates/pkg/domain/constants"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/i18n"
+)
+
+const (
+	DATE_LAYOUT = "2006-01-02"
+	HHMM_LAYOUT = "15:04"
+)
+
+var (
+	dniPattern   = regexp.MustCompile(`^[0-9]{8}$`)
+	cePattern    = regexp.MustCompile(`^[A-Z0-9]{9,12}$`)
+	phoneCleaner = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+	mobilePE     = regexp.MustCompile(`^9[0-9]{8}$`)
+
+	dateTimeLayouts = []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04",
+	}
+
+	locationMu sync.RWMutex
+	location   = mustLoadLocation(constants.DEFAULT_TIME_ZONE)
+)
+
+// registerDomainValidators registers the tags for Peruvian candidate data.
+// It runs from the init of validator_zod.go, once validate exists.
+func registerDomainValidators() {
+	validate.RegisterValidation("dni", func(fl validator.FieldLevel) bool {
+		return IsDNI(fl.Field().String())
+	})
+	validate.RegisterValidation("ce", func(fl validator.FieldLevel) bool {
+		return IsCE(fl.Field().String())
+	})
+	validate.RegisterValidation("pe_phone", func(fl validator.FieldLevel) bool {
+		normalized, ok := NormalizePEPhone(fl.Field().String())
+		if ok && fl.Field().CanSet() && fl.Field().Kind() == reflect.String {
+			fl.Field().SetString(normalized)
+		}
+		return ok
+	})
+	validate.RegisterValidation("smartsheet_id", func(fl validator.FieldLevel) bool {
+		return IsSmartsheetID(fl.Field().String())
+	})
+	validate.RegisterValidation("lima_date", func(fl validator.FieldLevel) bool {
+		_, err := time.ParseInLocation(DATE_LAYOUT, fl.Field().String(), TimeZone())
+		return err == nil
+	})
+	validate.RegisterValidation("hhmm", func(fl validator.FieldLevel) bool {
+		value := fl.Field().String()
+		_, err := time.Parse(HHMM_LAYOUT, value)
+		return err == nil && len(value) == len(HHMM_LAYOUT)
+	})
+	validate.RegisterValidation("future_datetime", func(fl validator.FieldLevel) bool {
+		at, ok := ParseDateTime(fl.Field().String())
+		return ok && at.After(time.Now())
+	})
+
+	i18n.RegisterValidationMessages(i18n.EN, map[string]string{
+		"dni":             "must be a DNI of 8 digits",
+		"ce":              "must be a carné de extranjería of 9 to 12 letters or digits",
+		"pe_phone":        "must be a Peruvian mobile number (+51 9xxxxxxxx)",
+		"smartsheet_id":   "must be a numeric Smartsheet ID",
+		"lima_date":       "must be a date with format YYYY-MM-DD",
+		"hhmm":            "must be a time with format HH:MM",
+		"future_datetime": "must be a date and time in the future",
+	})
+	i18n.RegisterValidationMessages(i18n.ES, map[string]string{
+		"dni":             "debe ser un DNI de 8 dígitos",
+		"ce":              "debe ser un carné de extranjería de 9 a 12 letras o dígitos",
+		"pe_phone":        "debe ser un celular peruano (+51 9xxxxxxxx)",
+		"smartsheet_id":   "debe ser un ID numérico de Smartsheet",
+		"lima_date":       "debe ser una fecha con formato AAAA-MM-DD",
+		"hhmm":            "debe ser una hora con formato HH:MM",
+		"future_datetime": "debe ser una fecha y hora futura",
+	})
+}
+
+// SetTimeZone sets the zone used by lima_date and future_datetime when the
+// value carries no offset
+func SetTimeZone(timeZone string) error {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", timeZone, err)
+	}
+
+	locationMu.Lock()
+	defer locationMu.Unlock()
+	location = loc
+	return nil
+}
+
+// TimeZone returns the zone configured for date validations
+func TimeZone() *time.Location {
+	locationMu.RLock()
+	defer locationMu.RUnlock()
+	return location
+}
+
+// IsDNI reports whether value is a Peruvian DNI (8 digits)
+func IsDNI(value string) bool {
+	return dniPattern.MatchString(value)
+}
+
+// IsCE reports whether value is a carné de extranjería number. Current cards
+// use 9 digits; older ones may contain letters, up to 12 characters.
+func IsCE(value string) bool {
+	return cePattern.MatchString(strings.ToUpper(value))
+}
+
+// NormalizePEPhone returns the E.164 form (+519xxxxxxxx) of a Peruvian mobile
+// number written with or without country code, spaces or dashes
+func NormalizePEPhone(value string) (string, bool) {
+	digits := phoneCleaner.Replace(strings.TrimSpace(value))
+
+	switch {
+	case strings.HasPrefix(digits, "+51"):
+		digits = digits[3:]
+	case strings.HasPrefix(digits, "0051"):
+		digits = digits[4:]
+	case strings.HasPrefix(digits, "51") && len(digits) == 11:
+		digits = digits[2:]
+	}
+
+	if !mobilePE.MatchString(digits) {
+		return "", false
+	}
+	return "+51" + digits, true
+}
+
+// IsSmartsheetID reports whether value is a positive 64-bit integer, the
+// format Smartsheet uses for sheet, row and column IDs
+func IsSmartsheetID(value string) bool {
+	if value == "" || strings.TrimLeft(value, "0123456789") != "" {
+		return false
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	return err == nil && id > 0
+}
+
+// ParseDateTime parses a date and time. Values without offset are read in
+// the configured time zone.
+func ParseDateTime(value string) (time.Time, bool) {
+	for _, layout := range dateTimeLayouts {
+		if at, err := time.ParseInLocation(layout, value, TimeZone()); err == nil {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func mustLoadLocation(timeZone string) *time.Location {
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
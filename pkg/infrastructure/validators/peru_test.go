@@ -0,0 +1,117 @@
+package validators
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDomainTags(t *testing.T) {
+	type document struct {
+		DNI string `json:"dni" validate:"omitempty,dni"`
+		CE  string `json:"ce" validate:"omitempty,ce"`
+	}
+	type sheet struct {
+		ID string `json:"id" validate:"omitempty,smartsheet_id"`
+	}
+	type interview struct {
+		Date string `json:"date" validate:"omitempty,lima_date"`
+		Time string `json:"time" validate:"omitempty,hhmm"`
+		At   string `json:"at" validate:"omitempty,future_datetime"`
+	}
+
+	future := time.Now().In(TimeZone()).Add(48 * time.Hour)
+	past := time.Now().In(TimeZone()).Add(-48 * time.Hour)
+
+	tests := []struct {
+		name  string
+		input any
+		valid bool
+	}{
+		{"dni with 8 digits", &document{DNI: "45678912"}, true},
+		{"dni with 7 digits", &document{DNI: "4567891"}, false},
+		{"dni with 9 digits", &document{DNI: "456789123"}, false},
+		{"dni with letters", &document{DNI: "4567891A"}, false},
+		{"ce with 9 digits", &document{CE: "001234567"}, true},
+		{"ce alphanumeric", &document{CE: "ab1234567"}, true},
+		{"ce too short", &document{CE: "12345678"}, false},
+		{"ce too long", &document{CE: "1234567890123"}, false},
+		{"ce with symbols", &document{CE: "1234-56789"}, false},
+		{"smartsheet id", &sheet{ID: "4583173393803140"}, true},
+		{"smartsheet id max int64", &sheet{ID: "9223372036854775807"}, true},
+		{"smartsheet id overflow", &sheet{ID: "9223372036854775808"}, false},
+		{"smartsheet id zero", &sheet{ID: "0"}, false},
+		{"smartsheet id negative", &sheet{ID: "-12"}, false},
+		{"smartsheet id with letters", &sheet{ID: "12ab"}, false},
+		{"date", &interview{Date: "2025-02-28"}, true},
+		{"date out of calendar", &interview{Date: "2025-02-30"}, false},
+		{"date wrong format", &interview{Date: "28/02/2025"}, false},
+		{"time", &interview{Time: "09:30"}, true},
+		{"time midnight", &interview{Time: "00:00"}, true},
+		{"time out of range", &interview{Time: "24:00"}, false},
+		{"time without padding", &interview{Time: "9:30"}, false},
+		{"time with seconds", &interview{Time: "09:30:00"}, false},
+		{"future datetime", &interview{At: future.Format("2006-01-02 15:04")}, true},
+		{"future datetime rfc3339", &interview{At: future.Format(time.RFC3339)}, true},
+		{"past datetime", &interview{At: past.Format("2006-01-02 15:04:05")}, false},
+		{"invalid datetime", &interview{At: "tomorrow"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSchema(tt.input)
+			if tt.valid && err != nil {
+				t.Fatalf("expected valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Fatalf("expected validation error")
+			}
+		})
+	}
+}
+
+func TestPEPhoneNormalization(t *testing.T) {
+	type contact struct {
+		Phone string `json:"phone" validate:"pe_phone"`
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		valid bool
+	}{
+		{"local mobile", "987654321", "+51987654321", true},
+		{"e164", "+51987654321", "+51987654321", true},
+		{"country code without plus", "51987654321", "+51987654321", true},
+		{"international prefix", "0051987654321", "+51987654321", true},
+		{"spaces and dashes", "+51 987-654-321", "+51987654321", true},
+		{"parentheses", "(+51) 987 654 321", "+51987654321", true},
+		{"landline", "014567890", "", false},
+		{"too short", "98765432", "", false},
+		{"too long", "9876543210", "", false},
+		{"other country", "+56987654321", "", false},
+		{"empty", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizePEPhone(tt.input)
+			if ok != tt.valid || got != tt.want {
+				t.Fatalf("NormalizePEPhone(%q) = %q, %v; want %q, %v", tt.input, got, ok, tt.want, tt.valid)
+			}
+
+			in := &contact{Phone: tt.input}
+			err := ValidateSchema(in)
+			if tt.valid {
+				if err != nil {
+					t.Fatalf("expected valid, got %v", err)
+				}
+				if in.Phone != tt.want {
+					t.Fatalf("expected field normalized to %q, got %q", tt.want, in.Phone)
+				}
+			} else if err == nil {
+				t.Fatalf("expected validation error")
+			}
+		})
+	}
+}
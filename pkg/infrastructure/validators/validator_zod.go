@@ -1,11 +1,11 @@
 package validators
 
 import (
-	"fmt"
 	"reflect"
 	"strings"
 
 	errorCustom "github.com/Yolto7/api-candidates/pkg/domain/error"
+	"github.com/Yolto7/api-candidates/pkg/infrastructure/i18n"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -30,15 +30,36 @@ func init() {
 	validate.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
 		return strings.TrimSpace(fl.Field().String()) != ""
 	})
+
+	registerDomainValidators()
 }
 
 func RegisterCustomValidator(tag string, fn validator.Func) error {
 	return validate.RegisterValidation(tag, fn)
 }
 
+// RegisterStructValidator adds a cross-field rule for the given types. Errors
+// are reported with sl.ReportError so they render like any other field error.
+func RegisterStructValidator(fn validator.StructLevelFunc, types ...any) {
+	validate.RegisterStructValidation(fn, types...)
+}
+
+// FieldErrorDetail describes an invalid field. Tag, Param and Kind are kept
+// so the message can be rendered again in the language of the client.
 type FieldErrorDetail struct {
 	Key     string `json:"key"`
 	Message string `json:"message"`
+	Tag     string `json:"-"`
+	Param   string `json:"-"`
+	Kind    string `json:"-"`
+}
+
+// Localize renders the message of the field error in the given language
+func (d FieldErrorDetail) Localize(lang i18n.Language) FieldErrorDetail {
+	if d.Tag != "" {
+		d.Message = i18n.ValidationMessage(lang, d.Tag, d.Param, d.Kind)
+	}
+	return d
 }
 
 func ValidateSchema(input any) error {
@@ -53,9 +74,13 @@ func ValidateSchema(input any) error {
 		issues := make([]FieldErrorDetail, 0, len(validationErrors))
 
 		for _, ve := range validationErrors {
+			kind := fieldKind(ve.Kind())
 			issues = append(issues, FieldErrorDetail{
 				Key:     ve.Field(),
-				Message: fmt.Sprintf("validation failed on '%s' constraint", ve.Tag()),
+				Message: i18n.ValidationMessage(i18n.DefaultLanguage, ve.Tag(), ve.Param(), kind),
+				Tag:     ve.Tag(),
+				Param:   ve.Param(),
+				Kind:    kind,
 			})
 		}
 
@@ -70,6 +95,19 @@ func ValidateSchema(input any) error {
 	return err
 }
 
+func fieldKind(kind reflect.Kind) string {
+	switch {
+	case kind == reflect.String:
+		return "string"
+	case kind >= reflect.Int && kind <= reflect.Float64:
+		return "number"
+	case kind == reflect.Slice || kind == reflect.Array || kind == reflect.Map:
+		return "array"
+	default:
+		return ""
+	}
+}
+
 func trimStringFields(i any) {
   v := reflect.ValueOf(i)
   if v.Kind() == reflect.Ptr {
@@ -91,8 +129,11 @@ func trimStringFields(i any) {
     case reflect.Struct:
       trimStringFields(f.Addr().Interface())
     case reflect.Ptr:
-      if f.Elem().Kind() == reflect.Struct {
+      switch f.Elem().Kind() {
+      case reflect.Struct:
         trimStringFields(f.Interface())
+      case reflect.String:
+        f.Elem().SetString(strings.TrimSpace(f.Elem().String()))
       }
     }
   }
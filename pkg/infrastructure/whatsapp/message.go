@@ -0,0 +1,71 @@
+package whatsapp
+
+const (
+	MESSAGING_PRODUCT = "whatsapp"
+
+	MESSAGE_TYPE_TEMPLATE = "template"
+)
+
+// SendRequest is the body of POST /{phone-number-id}/messages of the
+// WhatsApp Cloud API, for text and template messages
+type SendRequest struct {
+	MessagingProduct string        `json:"messaging_product"`
+	RecipientType    string        `json:"recipient_type"`
+	To               string        `json:"to"`
+	Type             string        `json:"type"`
+	Text             *TextBody     `json:"text,omitempty"`
+	Template         *TemplateBody `json:"template,omitempty"`
+}
+
+type TextBody struct {
+	PreviewURL bool   `json:"preview_url"`
+	Body       string `json:"body"`
+}
+
+type TemplateBody struct {
+	Name       string              `json:"name"`
+	Language   TemplateLanguage    `json:"language"`
+	Components []TemplateComponent `json:"components,omitempty"`
+}
+
+type TemplateLanguage struct {
+	Code string `json:"code"`
+}
+
+type TemplateComponent struct {
+	Type       string              `json:"type"`
+	Parameters []TemplateParameter `json:"parameters"`
+}
+
+type TemplateParameter struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SendResponse lists the IDs (wamid) of the messages accepted
+type SendResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+}
+
+// ErrorResponse is the error body of the Graph API
+type ErrorResponse struct {
+	Error struct {
+		Message   string `json:"message"`
+		Type      string `json:"type"`
+		Code      int    `json:"code"`
+		FBTraceID string `json:"fbtrace_id"`
+	} `json:"error"`
+}
+
+// Graph API error codes handled by the sender
+const (
+	ERROR_RATE_LIMIT            = 4
+	ERROR_SPAM_RATE_LIMIT       = 131048
+	ERROR_PAIR_RATE_LIMIT       = 131056
+	ERROR_THROUGHPUT_LIMIT      = 130429
+	ERROR_RECIPIENT_INVALID     = 131026
+	ERROR_RECIPIENT_NOT_ALLOWED = 131030
+	ERROR_REENGAGEMENT          = 131047
+)
@@ -0,0 +1,168 @@
+package whatsapp
+
+import (
+	"crypto/subtle"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	OBJECT_BUSINESS_ACCOUNT = "whatsapp_business_account"
+	FIELD_MESSAGES          = "messages"
+
+	MESSAGE_TYPE_TEXT        = "text"
+	MESSAGE_TYPE_BUTTON      = "button"
+	MESSAGE_TYPE_INTERACTIVE = "interactive"
+
+	STATUS_SENT      = "sent"
+	STATUS_DELIVERED = "delivered"
+	STATUS_READ      = "read"
+	STATUS_FAILED    = "failed"
+)
+
+// WebhookPayload is the notification sent by the WhatsApp Cloud API. Only
+// the parts used by the service are modelled.
+type WebhookPayload struct {
+	Object string  `json:"object"`
+	Entry  []Entry `json:"entry"`
+}
+
+type Entry struct {
+	ID      string   `json:"id"`
+	Changes []Change `json:"changes"`
+}
+
+type Change struct {
+	Field string `json:"field"`
+	Value Value  `json:"value"`
+}
+
+type Value struct {
+	MessagingProduct string    `json:"messaging_product"`
+	Metadata         Metadata  `json:"metadata"`
+	Contacts         []Contact `json:"contacts"`
+	Messages         []Message `json:"messages"`
+	Statuses         []Status  `json:"statuses"`
+}
+
+type Metadata struct {
+	DisplayPhoneNumber string `json:"display_phone_number"`
+	PhoneNumberID      string `json:"phone_number_id"`
+}
+
+type Contact struct {
+	WaID    string `json:"wa_id"`
+	Profile struct {
+		Name string `json:"name"`
+	} `json:"profile"`
+}
+
+type Message struct {
+	ID        string `json:"id"`
+	From      string `json:"from"`
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"`
+	Text      *struct {
+		Body string `json:"body"`
+	} `json:"text,omitempty"`
+	Button *struct {
+		Text    string `json:"text"`
+		Payload string `json:"payload"`
+	} `json:"button,omitempty"`
+	Interactive *struct {
+		Type        string `json:"type"`
+		ButtonReply *Reply `json:"button_reply,omitempty"`
+		ListReply   *Reply `json:"list_reply,omitempty"`
+	} `json:"interactive,omitempty"`
+}
+
+type Reply struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+type Status struct {
+	ID          string `json:"id"`
+	RecipientID string `json:"recipient_id"`
+	Status      string `json:"status"`
+	Timestamp   string `json:"timestamp"`
+	Errors      []struct {
+		Code    int    `json:"code"`
+		Title   string `json:"title"`
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
+}
+
+// Messages returns every inbound message of the payload
+func (p *WebhookPayload) Messages() []Message {
+	messages := make([]Message, 0)
+	for _, entry := range p.Entry {
+		for _, change := range entry.Changes {
+			if change.Field == FIELD_MESSAGES {
+				messages = append(messages, change.Value.Messages...)
+			}
+		}
+	}
+	return messages
+}
+
+// Statuses returns every status update of outbound messages in the payload
+func (p *WebhookPayload) Statuses() []Status {
+	statuses := make([]Status, 0)
+	for _, entry := range p.Entry {
+		for _, change := range entry.Changes {
+			if change.Field == FIELD_MESSAGES {
+				statuses = append(statuses, change.Value.Statuses...)
+			}
+		}
+	}
+	return statuses
+}
+
+// Content returns the text written or chosen by the user: the body of a text
+// message or the title of a quick reply. It is empty for media messages.
+func (m Message) Content() string {
+	switch {
+	case m.Text != nil:
+		return strings.TrimSpace(m.Text.Body)
+	case m.Button != nil:
+		return strings.TrimSpace(m.Button.Text)
+	case m.Interactive != nil && m.Interactive.ButtonReply != nil:
+		return strings.TrimSpace(m.Interactive.ButtonReply.Title)
+	case m.Interactive != nil && m.Interactive.ListReply != nil:
+		return strings.TrimSpace(m.Interactive.ListReply.Title)
+	}
+	return ""
+}
+
+// SentAt parses the unix timestamp of the message, falling back to now
+func (m Message) SentAt() time.Time {
+	seconds, err := strconv.ParseInt(m.Timestamp, 10, 64)
+	if err != nil || seconds <= 0 {
+		return time.Now()
+	}
+	return time.Unix(seconds, 0)
+}
+
+// ReportedAt parses the unix timestamp of the status, falling back to now
+func (s Status) ReportedAt() time.Time {
+	seconds, err := strconv.ParseInt(s.Timestamp, 10, 64)
+	if err != nil || seconds <= 0 {
+		return time.Now()
+	}
+	return time.Unix(seconds, 0)
+}
+
+// VerifyChallenge answers the subscription handshake: it returns hub.challenge
+// when hub.mode is "subscribe" and hub.verify_token matches the configured one
+func VerifyChallenge(query map[string]string, verifyToken string) (string, bool) {
+	if verifyToken == "" || query["hub.mode"] != "subscribe" {
+		return "", false
+	}
+	token := query["hub.verify_token"]
+	if subtle.ConstantTimeCompare([]byte(token), []byte(verifyToken)) != 1 {
+		return "", false
+	}
+	return query["hub.challenge"], true
+}